@@ -0,0 +1,152 @@
+package sprout
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// RegistryFactory is a function creating a new instance of a registry. It is
+// used by the registry catalog to instantiate registries selected by name.
+type RegistryFactory func() Registry
+
+// RegistryGroupFactory is a function creating a new instance of a registry
+// group. It is used by the registry catalog to instantiate groups selected by
+// name.
+type RegistryGroupFactory func() *RegistryGroup
+
+// catalog is the global catalog of registries and registry groups available
+// to be selected by name, typically from a configuration file.
+var catalog = struct {
+	sync.RWMutex
+	registries map[string]RegistryFactory
+	groups     map[string]RegistryGroupFactory
+}{
+	registries: make(map[string]RegistryFactory),
+	groups:     make(map[string]RegistryGroupFactory),
+}
+
+// RegisterRegistry adds a registry to the global registry catalog, making it
+// selectable by its UID, or by its namespace when it is not ambiguous, in a
+// configuration loaded with NewFromConfig.
+//
+// The factory is called once to retrieve the UID of the registry. When a
+// registry with the same UID is already in the catalog, this function does
+// nothing to prevent accidental overwriting of registered registries.
+//
+// Registries usually register themselves from the `init` function of their
+// package, so importing the package is enough to make them available:
+//
+//	func init() {
+//	    sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+//	}
+func RegisterRegistry(factory RegistryFactory) {
+	if factory == nil {
+		return
+	}
+
+	uid := factory().UID()
+
+	catalog.Lock()
+	defer catalog.Unlock()
+
+	if _, ok := catalog.registries[uid]; ok {
+		return // Prevent overwriting existing registries
+	}
+	catalog.registries[uid] = factory
+}
+
+// RegisterRegistryGroup adds a registry group to the global registry catalog
+// under the given name, making it selectable in a configuration loaded with
+// NewFromConfig. When a group with the same name is already in the catalog,
+// this function does nothing.
+//
+// Example:
+//
+//	func init() {
+//	    sprout.RegisterRegistryGroup("all", RegistryGroup)
+//	}
+func RegisterRegistryGroup(name string, factory RegistryGroupFactory) {
+	if name == "" || factory == nil {
+		return
+	}
+
+	catalog.Lock()
+	defer catalog.Unlock()
+
+	if _, ok := catalog.groups[name]; ok {
+		return // Prevent overwriting existing groups
+	}
+	catalog.groups[name] = factory
+}
+
+// CatalogRegistries returns the sorted list of the UIDs of all registries
+// available in the global registry catalog.
+func CatalogRegistries() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	uids := make([]string, 0, len(catalog.registries))
+	for uid := range catalog.registries {
+		uids = append(uids, uid)
+	}
+	slices.Sort(uids)
+	return uids
+}
+
+// CatalogRegistryGroups returns the sorted list of the names of all registry
+// groups available in the global registry catalog.
+func CatalogRegistryGroups() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	names := make([]string, 0, len(catalog.groups))
+	for name := range catalog.groups {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// lookupRegistry creates a new instance of the registry identified by name in
+// the global registry catalog. The name is either the UID of the registry or
+// its namespace, as returned by RegistryNamespace, when only one registry of
+// the catalog has this namespace.
+func lookupRegistry(name string) (Registry, error) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	if factory, ok := catalog.registries[name]; ok {
+		return factory(), nil
+	}
+
+	var matches []string
+	for uid := range catalog.registries {
+		if RegistryNamespace(uid) == name {
+			matches = append(matches, uid)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("registry %q not found in the catalog, did you import its package?", name)
+	case 1:
+		return catalog.registries[matches[0]](), nil
+	default:
+		slices.Sort(matches)
+		return nil, fmt.Errorf("registry name %q is ambiguous, use one of the UIDs %v", name, matches)
+	}
+}
+
+// lookupRegistryGroup creates a new instance of the registry group identified
+// by name in the global registry catalog.
+func lookupRegistryGroup(name string) (*RegistryGroup, error) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	factory, ok := catalog.groups[name]
+	if !ok {
+		return nil, fmt.Errorf("registry group %q not found in the catalog, did you import its package?", name)
+	}
+	return factory(), nil
+}
@@ -0,0 +1,64 @@
+package sprout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRegistry(t *testing.T) {
+	first := &funcsRegistry{uid: "test/catalog.register", funcs: FunctionMap{"hello": func() string { return "first" }}}
+	second := &funcsRegistry{uid: "test/catalog.register", funcs: FunctionMap{"hello": func() string { return "second" }}}
+
+	RegisterRegistry(func() Registry { return first })
+	RegisterRegistry(func() Registry { return second })
+	RegisterRegistry(nil)
+
+	assert.Contains(t, CatalogRegistries(), "test/catalog.register")
+
+	reg, err := lookupRegistry("test/catalog.register")
+	require.NoError(t, err)
+	assert.Same(t, first, reg, "Registered registries should not be overwritten")
+}
+
+func TestRegisterRegistryGroup(t *testing.T) {
+	first := NewRegistryGroup()
+	second := NewRegistryGroup()
+
+	RegisterRegistryGroup("test-catalog-group", func() *RegistryGroup { return first })
+	RegisterRegistryGroup("test-catalog-group", func() *RegistryGroup { return second })
+	RegisterRegistryGroup("", func() *RegistryGroup { return second })
+	RegisterRegistryGroup("test-catalog-nil", nil)
+
+	assert.Contains(t, CatalogRegistryGroups(), "test-catalog-group")
+	assert.NotContains(t, CatalogRegistryGroups(), "")
+	assert.NotContains(t, CatalogRegistryGroups(), "test-catalog-nil")
+
+	group, err := lookupRegistryGroup("test-catalog-group")
+	require.NoError(t, err)
+	assert.Same(t, first, group, "Registered groups should not be overwritten")
+
+	_, err = lookupRegistryGroup("test-catalog-unknown")
+	require.ErrorContains(t, err, `registry group "test-catalog-unknown" not found in the catalog`)
+}
+
+func TestLookupRegistry(t *testing.T) {
+	RegisterRegistry(func() Registry { return &funcsRegistry{uid: "test/catalog.lookup"} })
+	RegisterRegistry(func() Registry { return &funcsRegistry{uid: "test/first.ambiguous"} })
+	RegisterRegistry(func() Registry { return &funcsRegistry{uid: "test/second.ambiguous"} })
+
+	reg, err := lookupRegistry("test/catalog.lookup")
+	require.NoError(t, err)
+	assert.Equal(t, "test/catalog.lookup", reg.UID())
+
+	reg, err = lookupRegistry("lookup")
+	require.NoError(t, err)
+	assert.Equal(t, "test/catalog.lookup", reg.UID())
+
+	_, err = lookupRegistry("ambiguous")
+	require.ErrorContains(t, err, `registry name "ambiguous" is ambiguous, use one of the UIDs [test/first.ambiguous test/second.ambiguous]`)
+
+	_, err = lookupRegistry("unknown")
+	require.ErrorContains(t, err, `registry "unknown" not found in the catalog`)
+}
@@ -0,0 +1,176 @@
+package sprout
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Config describes a DefaultHandler to build with NewFromConfig, typically
+// loaded from a YAML or JSON file with ParseConfig.
+//
+// Registries and groups are selected by name from the global registry catalog,
+// see RegisterRegistry and RegisterRegistryGroup.
+//
+// Example:
+//
+//	registries: [strings, maps, go-sprout/sprout.crypto]
+//	groups: [hermetic]
+//	options:
+//	  acme/tools.vault: { address: "https://vault.local" }
+//	aliases:
+//	  toUpper: [upper]
+//	notices:
+//	  - kind: deprecated
+//	    functions: [upper]
+//	    message: please use `toUpper` instead
+//	safeFuncs: false
+//	namespaces: true
+type Config struct {
+	// Registries is the list of registries to add to the handler, by UID or by
+	// namespace. Registries are added before the groups, so their functions
+	// take precedence.
+	Registries []string `json:"registries" yaml:"registries"`
+	// Groups is the list of registry groups to add to the handler, by name.
+	Groups []string `json:"groups" yaml:"groups"`
+	// Options are the options given to registries implementing
+	// RegistryWithConfig, keyed by registry UID or namespace.
+	Options map[string]map[string]any `json:"options" yaml:"options"`
+	// Aliases are additional function aliases, see WithAliases.
+	Aliases FunctionAliasMap `json:"aliases" yaml:"aliases"`
+	// Notices are additional function notices, see WithNotices.
+	Notices []NoticeConfig `json:"notices" yaml:"notices"`
+	// SafeFuncs enables the safe functions, see WithSafeFuncs.
+	SafeFuncs bool `json:"safeFuncs" yaml:"safeFuncs"`
+	// Namespaces enables the namespaced functions, see WithNamespaces.
+	Namespaces bool `json:"namespaces" yaml:"namespaces"`
+}
+
+// NoticeConfig describes a function notice in a Config.
+type NoticeConfig struct {
+	// Kind is the kind of the notice: `deprecated`, `info` or `debug`.
+	Kind string `json:"kind" yaml:"kind"`
+	// Functions is the list of function names to which the notice applies.
+	Functions []string `json:"functions" yaml:"functions"`
+	// Message is the message of the notice.
+	Message string `json:"message" yaml:"message"`
+}
+
+// noticeKinds maps the notice kinds accepted in a Config to their NoticeKind.
+var noticeKinds = map[string]NoticeKind{
+	"deprecated": NoticeKindDeprecated,
+	"info":       NoticeKindInfo,
+	"debug":      NoticeKindDebug,
+}
+
+// ParseConfig decodes a YAML or JSON document into a Config.
+//
+// Example:
+//
+//	cfg, err := ParseConfig([]byte(`registries: [strings, maps]`))
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// NewFromConfig creates and returns a new instance of DefaultHandler built from
+// the given configuration. Registries and groups are instantiated from the
+// global registry catalog, so their packages must be imported beforehand.
+//
+// The handler options are applied before the configuration, unlike New, every
+// error encountered while applying the configuration is returned.
+//
+// Example:
+//
+//	import _ "github.com/go-sprout/sprout/group/all" // fill the catalog
+//
+//	cfg, err := sprout.ParseConfig(data)
+//	handler, err := sprout.NewFromConfig(cfg, sprout.WithLogger(logger))
+func NewFromConfig(cfg *Config, opts ...HandlerOption[*DefaultHandler]) (*DefaultHandler, error) {
+	dh := New(opts...)
+	if cfg == nil {
+		return dh, nil
+	}
+
+	registries := make([]Registry, 0, len(cfg.Registries))
+	for _, name := range cfg.Registries {
+		reg, err := lookupRegistry(name)
+		if err != nil {
+			return nil, err
+		}
+		registries = append(registries, reg)
+	}
+	for _, name := range cfg.Groups {
+		group, err := lookupRegistryGroup(name)
+		if err != nil {
+			return nil, err
+		}
+		registries = append(registries, group.Registries...)
+	}
+
+	if err := configureRegistries(registries, cfg.Options); err != nil {
+		return nil, err
+	}
+
+	notices := make([]*FunctionNotice, 0, len(cfg.Notices))
+	for _, notice := range cfg.Notices {
+		kind, ok := noticeKinds[notice.Kind]
+		if !ok {
+			return nil, fmt.Errorf("invalid notice kind %q, expected one of %v", notice.Kind, slices.Sorted(maps.Keys(noticeKinds)))
+		}
+		notices = append(notices, NewNotice(kind, notice.Functions, notice.Message))
+	}
+
+	for _, opt := range []HandlerOption[*DefaultHandler]{
+		WithRegistries(registries...),
+		WithAliases(cfg.Aliases),
+		WithNotices(notices...),
+		WithSafeFuncs(cfg.SafeFuncs),
+		WithNamespaces(cfg.Namespaces),
+	} {
+		if err := opt(dh); err != nil {
+			return nil, err
+		}
+	}
+
+	return dh, nil
+}
+
+// configureRegistries applies the options to the registries they are configured
+// for, keyed by registry UID or namespace. It returns an error when options are
+// configured for a registry which is not selected or not configurable.
+func configureRegistries(registries []Registry, options map[string]map[string]any) error {
+	unused := make(map[string]struct{}, len(options))
+	for key := range options {
+		unused[key] = struct{}{}
+	}
+
+	for _, reg := range registries {
+		for _, key := range []string{reg.UID(), RegistryNamespace(reg.UID())} {
+			regOptions, ok := options[key]
+			if !ok {
+				continue
+			}
+			delete(unused, key)
+
+			regConfig, ok := reg.(RegistryWithConfig)
+			if !ok {
+				return fmt.Errorf("registry %s does not accept options", reg.UID())
+			}
+			if err := regConfig.Configure(regOptions); err != nil {
+				return fmt.Errorf("cannot configure registry %s: %w", reg.UID(), err)
+			}
+			break
+		}
+	}
+
+	if len(unused) > 0 {
+		return fmt.Errorf("options configured for unselected registries %v", slices.Sorted(maps.Keys(unused)))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package sprout
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configurableRegistry is a registry implementing RegistryWithConfig.
+type configurableRegistry struct {
+	funcsRegistry
+	greeting string
+}
+
+func (r *configurableRegistry) Configure(options map[string]any) error {
+	greeting, ok := options["greeting"].(string)
+	if !ok {
+		return errors.New("greeting must be a string")
+	}
+	r.greeting = greeting
+	return nil
+}
+
+func (r *configurableRegistry) RegisterFunctions(fnMap FunctionMap) error {
+	AddFunction(fnMap, "greet", func() string { return r.greeting })
+	return nil
+}
+
+func init() {
+	RegisterRegistry(func() Registry {
+		return &funcsRegistry{uid: "test/config.hello", funcs: FunctionMap{"hello": func() string { return "hello" }}}
+	})
+	RegisterRegistry(func() Registry {
+		return &configurableRegistry{funcsRegistry: funcsRegistry{uid: "test/config.greeter"}, greeting: "hi"}
+	})
+	RegisterRegistryGroup("test-config", func() *RegistryGroup {
+		return NewRegistryGroup(&funcsRegistry{uid: "test/config.world", funcs: FunctionMap{"world": func() string { return "world" }}})
+	})
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+registries: [hello, test/config.greeter]
+groups: [test-config]
+options:
+  greeter: { greeting: bonjour }
+aliases:
+  hello: [hi]
+notices:
+  - kind: info
+    functions: [hello]
+    message: amazing
+safeFuncs: true
+namespaces: true
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{
+		Registries: []string{"hello", "test/config.greeter"},
+		Groups:     []string{"test-config"},
+		Options:    map[string]map[string]any{"greeter": {"greeting": "bonjour"}},
+		Aliases:    FunctionAliasMap{"hello": {"hi"}},
+		Notices:    []NoticeConfig{{Kind: "info", Functions: []string{"hello"}, Message: "amazing"}},
+		SafeFuncs:  true,
+		Namespaces: true,
+	}, cfg)
+
+	cfg, err = ParseConfig([]byte(`{"registries": ["hello"], "safeFuncs": true}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{Registries: []string{"hello"}, SafeFuncs: true}, cfg)
+
+	_, err = ParseConfig([]byte(`registries: {`))
+	require.ErrorContains(t, err, "cannot parse config")
+}
+
+func TestNewFromConfig(t *testing.T) {
+	handler, err := NewFromConfig(&Config{
+		Registries: []string{"hello", "test/config.greeter"},
+		Groups:     []string{"test-config"},
+		Options:    map[string]map[string]any{"greeter": {"greeting": "bonjour"}},
+		Aliases:    FunctionAliasMap{"hello": {"hi"}},
+		Notices:    []NoticeConfig{{Kind: "deprecated", Functions: []string{"hi"}, Message: "please use `hello` instead"}},
+		SafeFuncs:  true,
+		Namespaces: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, handler.registries, 3)
+	require.Len(t, handler.Notices(), 1)
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ hi }} {{ greet }} {{ world }} {{ hello_hello }} {{ safeGreet }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "hello bonjour world hello bonjour", buf.String())
+}
+
+func TestNewFromConfig_Nil(t *testing.T) {
+	handler, err := NewFromConfig(nil)
+	require.NoError(t, err)
+	assert.Empty(t, handler.registries)
+}
+
+func TestNewFromConfig_Errors(t *testing.T) {
+	tc := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{name: "UnknownRegistry", cfg: &Config{Registries: []string{"unknown"}}, expectedErr: `registry "unknown" not found in the catalog`},
+		{name: "UnknownGroup", cfg: &Config{Groups: []string{"unknown"}}, expectedErr: `registry group "unknown" not found in the catalog`},
+		{name: "NotConfigurable", cfg: &Config{Registries: []string{"hello"}, Options: map[string]map[string]any{"hello": {}}}, expectedErr: "registry test/config.hello does not accept options"},
+		{name: "InvalidOptions", cfg: &Config{Registries: []string{"greeter"}, Options: map[string]map[string]any{"test/config.greeter": {"greeting": 1}}}, expectedErr: "cannot configure registry test/config.greeter: greeting must be a string"},
+		{name: "UnselectedRegistryOptions", cfg: &Config{Registries: []string{"hello"}, Options: map[string]map[string]any{"greeter": {}}}, expectedErr: "options configured for unselected registries [greeter]"},
+		{name: "InvalidNoticeKind", cfg: &Config{Notices: []NoticeConfig{{Kind: "warning", Functions: []string{"hello"}}}}, expectedErr: `invalid notice kind "warning", expected one of [debug deprecated info]`},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFromConfig(test.cfg)
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
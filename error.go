@@ -9,6 +9,14 @@ import (
 // fails. You can create a new error message using NewErrConvertFailed.
 var ErrConvertFailed = errors.New("failed to convert")
 
+// ErrInvalidFunction is an error message when a function cannot be registered
+// because its signature cannot be called by the template engine.
+var ErrInvalidFunction = errors.New("invalid function")
+
+// ErrHandlerClosed is an error message when a handler is used after being
+// closed.
+var ErrHandlerClosed = errors.New("handler is closed")
+
 // ErrRecoverPanic are an utility function to recover panic from a function and
 // set the error message to unsure no panic is thrown in the template engine.
 //
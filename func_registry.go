@@ -0,0 +1,167 @@
+package sprout
+
+import (
+	"fmt"
+	"maps"
+	"unicode"
+
+	"github.com/go-sprout/sprout/internal/runtime"
+)
+
+// FunctionRegistry is a ready-to-use Registry built from a map of functions.
+// It is useful to add a few functions to a handler without writing a full
+// registry. Create one with FuncRegistry.
+type FunctionRegistry struct {
+	uid       string
+	functions FunctionMap
+	aliases   FunctionAliasMap
+	notices   []FunctionNotice
+	metadata  map[string]string
+}
+
+// FuncRegistry creates a new registry identified by uid and registering the
+// given functions. Every function is validated when the registry is added to a
+// handler, it must return one or two values, the last one being an error when
+// two values are returned.
+//
+// Aliases, notices and metadata can be added with the chainable With* methods.
+//
+// Example:
+//
+//	reg := sprout.FuncRegistry("acme/tools.greetings", map[string]any{
+//	    "hello": func(name string) string { return "Hello, " + name },
+//	}).WithAlias("hello", "hi").WithMetadata("version", "1.0.0")
+//
+//	handler := sprout.New(sprout.WithRegistries(reg))
+func FuncRegistry(uid string, functions map[string]any) *FunctionRegistry {
+	funcs := make(FunctionMap, len(functions))
+	maps.Copy(funcs, functions)
+
+	return &FunctionRegistry{
+		uid:       uid,
+		functions: funcs,
+		aliases:   make(FunctionAliasMap),
+		notices:   make([]FunctionNotice, 0),
+		metadata:  make(map[string]string),
+	}
+}
+
+// WithAlias adds one or more aliases for the original function name of the
+// registry and returns the registry for chaining.
+func (fr *FunctionRegistry) WithAlias(originalFunction string, aliases ...string) *FunctionRegistry {
+	AddAlias(fr.aliases, originalFunction, aliases...)
+	return fr
+}
+
+// WithNotices adds one or more function notices to the registry and returns
+// the registry for chaining.
+func (fr *FunctionRegistry) WithNotices(notices ...*FunctionNotice) *FunctionRegistry {
+	for _, notice := range notices {
+		AddNotice(&fr.notices, notice)
+	}
+	return fr
+}
+
+// WithMetadata sets a metadata of the registry, like its description or its
+// version, and returns the registry for chaining.
+func (fr *FunctionRegistry) WithMetadata(key, value string) *FunctionRegistry {
+	fr.metadata[key] = value
+	return fr
+}
+
+// Metadata returns the metadata of the registry.
+func (fr *FunctionRegistry) Metadata() map[string]string {
+	return fr.metadata
+}
+
+// UID returns the unique identifier of the registry.
+func (fr *FunctionRegistry) UID() string {
+	return fr.uid
+}
+
+// LinkHandler does nothing, the functions of the registry do not use the
+// handler.
+func (fr *FunctionRegistry) LinkHandler(_ Handler) error {
+	return nil
+}
+
+// RegisterFunctions validates and registers all functions of the registry.
+func (fr *FunctionRegistry) RegisterFunctions(funcsMap FunctionMap) error {
+	for name, fn := range fr.functions {
+		if err := validateFunction(name, fn); err != nil {
+			return err
+		}
+	}
+
+	for name, fn := range fr.functions {
+		AddFunction(funcsMap, name, fn)
+	}
+	return nil
+}
+
+// RegisterAliases registers all aliases of the registry.
+func (fr *FunctionRegistry) RegisterAliases(aliasesMap FunctionAliasMap) error {
+	for originalFunction, aliases := range fr.aliases {
+		AddAlias(aliasesMap, originalFunction, aliases...)
+	}
+	return nil
+}
+
+// RegisterNotices registers all notices of the registry.
+func (fr *FunctionRegistry) RegisterNotices(notices *[]FunctionNotice) error {
+	for i := range fr.notices {
+		AddNotice(notices, &fr.notices[i])
+	}
+	return nil
+}
+
+// WithFunction returns a HandlerOption that adds a single function to the
+// handler under the given name. The function is validated immediately, it must
+// return one or two values, the last one being an error when two values are
+// returned. If the function name already exists, the function is not added.
+//
+// Example:
+//
+//	handler := New(WithFunction("hello", func(name string) string {
+//	    return "Hello, " + name
+//	}))
+func WithFunction(name string, fn any) HandlerOption[*DefaultHandler] {
+	return func(dh *DefaultHandler) error {
+		if err := validateFunction(name, fn); err != nil {
+			return err
+		}
+
+		AddFunction(dh.cachedFuncsMap, name, fn)
+		return nil
+	}
+}
+
+// validateFunction checks the function can be called by the template engine,
+// see [runtime.ValidateFunction], under a valid name.
+func validateFunction(name string, fn any) error {
+	if err := validateFunctionName(name); err != nil {
+		return err
+	}
+
+	if err := runtime.ValidateFunction(fn); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidFunction, name, err)
+	}
+	return nil
+}
+
+// validateFunctionName checks the name can be used in a template: like
+// text/template requires, it must start with a letter or an underscore,
+// followed by letters, digits or underscores.
+func validateFunctionName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: function name cannot be empty", ErrInvalidFunction)
+	}
+
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return fmt.Errorf("%w %q: function name is not a valid identifier", ErrInvalidFunction, name)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package sprout
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncRegistry(t *testing.T) {
+	loggerHandler := &noticeLoggerHandler{}
+	reg := FuncRegistry("acme/tools.greetings", map[string]any{
+		"hello": func(name string) string { return "Hello, " + name },
+	}).
+		WithAlias("hello", "hi").
+		WithNotices(NewInfoNotice("hi", "amazing")).
+		WithMetadata("version", "1.0.0")
+
+	assert.Equal(t, "acme/tools.greetings", reg.UID())
+	assert.Equal(t, map[string]string{"version": "1.0.0"}, reg.Metadata())
+
+	handler := New(WithLogger(slog.New(loggerHandler)), WithRegistries(reg))
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ hello "Alice" }} {{ hi "Bob" }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "Hello, Alice Hello, Bob", buf.String())
+	assert.Equal(t, "[INFO] amazing\n", loggerHandler.messages.String())
+}
+
+func TestFuncRegistry_InvalidFunction(t *testing.T) {
+	reg := FuncRegistry("acme/tools.invalid", map[string]any{
+		"nothing": func() {},
+	})
+
+	err := New().AddRegistry(reg)
+	require.ErrorIs(t, err, ErrInvalidFunction)
+	require.ErrorContains(t, err, `invalid function "nothing": cannot safecall function: function returns no value`)
+}
+
+func TestWithFunction(t *testing.T) {
+	handler := New(
+		WithFunction("hello", func() string { return "hello" }),
+		WithFunction("hello", func() string { return "overwritten" }),
+	)
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ hello }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "hello", buf.String())
+}
+
+// customError is an error type, which text/template does not accept as the
+// last return type of a function.
+type customError struct{}
+
+func (*customError) Error() string { return "custom" }
+
+func TestWithFunction_ValidNames(t *testing.T) {
+	for _, name := range []string{"f", "_f", "f_2", "été"} {
+		require.NoError(t, WithFunction(name, func() string { return name })(New()), name)
+	}
+}
+
+func TestWithFunction_Invalid(t *testing.T) {
+	tc := []struct {
+		name        string
+		funcName    string
+		fn          any
+		expectedErr string
+	}{
+		{name: "EmptyName", funcName: "", fn: func() string { return "" }, expectedErr: "invalid function: function name cannot be empty"},
+		{name: "NotAFunction", funcName: "fn", fn: "cheese", expectedErr: "first argument is not a function"},
+		{name: "NilFunction", funcName: "fn", fn: nil, expectedErr: "first argument is not a function"},
+		{name: "NoReturnValue", funcName: "fn", fn: func() {}, expectedErr: "function returns no value"},
+		{name: "LastReturnNotError", funcName: "fn", fn: func() (string, string) { return "", "" }, expectedErr: "invalid last return type"},
+		{name: "InvalidName", funcName: "my-func", fn: func() string { return "" }, expectedErr: `invalid function "my-func": function name is not a valid identifier`},
+		{name: "LeadingDigit", funcName: "3d", fn: func() string { return "" }, expectedErr: "function name is not a valid identifier"},
+		{name: "LastReturnImplementsError", funcName: "fn", fn: func() (string, *customError) { return "", nil }, expectedErr: "invalid last return type"},
+		{name: "TooManyReturns", funcName: "fn", fn: func() (string, string, error) { return "", "", nil }, expectedErr: "function returns more than two values"},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			handler := New()
+			err := WithFunction(test.funcName, test.fn)(handler)
+			require.ErrorIs(t, err, ErrInvalidFunction)
+			require.ErrorContains(t, err, test.expectedErr)
+			assert.NotContains(t, handler.RawFunctions(), test.funcName)
+		})
+	}
+}
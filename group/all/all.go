@@ -10,6 +10,7 @@ import (
 	"github.com/go-sprout/sprout/registry/maps"
 	"github.com/go-sprout/sprout/registry/network"
 	"github.com/go-sprout/sprout/registry/numeric"
+	"github.com/go-sprout/sprout/registry/query"
 	"github.com/go-sprout/sprout/registry/random"
 	"github.com/go-sprout/sprout/registry/reflect"
 	//nolint:staticcheck // kept until v1.2 to not break templates using the sprig signatures, will be swapped for `regex`
@@ -22,12 +23,16 @@ import (
 	"github.com/go-sprout/sprout/registry/uniqueid"
 )
 
+func init() {
+	sprout.RegisterRegistryGroup("all", RegistryGroup)
+}
+
 // all.RegistryGroup is a group of all registries available in Sprout excluding
 // deprecated and experimental registries.
 //
 // Included registries: checksum, conversion, encoding, env, filesystem, maps,
-// network, numeric, random, reflect, regexp, semver, slices, std, strings, time,
-// uniqueid.
+// network, numeric, query, random, reflect, regexp, semver, slices, std, strings,
+// time, uniqueid.
 //
 // The deprecated `regexp` registry is still included to keep this group
 // non-breaking, it will be replaced by `regex` in Sprout v1.2. To opt in right
@@ -43,6 +48,7 @@ func RegistryGroup() *sprout.RegistryGroup {
 		maps.NewRegistry(),
 		network.NewRegistry(),
 		numeric.NewRegistry(),
+		query.NewRegistry(),
 		random.NewRegistry(),
 		reflect.NewRegistry(),
 		regexp.NewRegistry(), //nolint:staticcheck // see the note above about the v1.2 migration to `regex`
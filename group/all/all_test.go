@@ -3,6 +3,10 @@ package all_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sprout/sprout"
 	"github.com/go-sprout/sprout/group/all"
 	"github.com/go-sprout/sprout/pesticide"
 )
@@ -18,6 +22,7 @@ func TestRegistryGroup(t *testing.T) {
 			"go-sprout/sprout.maps",
 			"go-sprout/sprout.network",
 			"go-sprout/sprout.numeric",
+			"go-sprout/sprout.query",
 			"go-sprout/sprout.random",
 			"go-sprout/sprout.reflect",
 			"go-sprout/sprout.regexp",
@@ -32,3 +37,15 @@ func TestRegistryGroup(t *testing.T) {
 
 	pesticide.RunGroupTest(t, all.RegistryGroup(), tc)
 }
+
+func TestRegistryGroupFromConfig(t *testing.T) {
+	assert.Contains(t, sprout.CatalogRegistryGroups(), "all")
+
+	handler, err := sprout.NewFromConfig(&sprout.Config{
+		Registries: []string{"strings", "go-sprout/sprout.maps"},
+		Groups:     []string{"all"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, handler.Build(), "toUpper")
+	assert.Contains(t, handler.Build(), "dig")
+}
@@ -19,6 +19,10 @@ import (
 	"github.com/go-sprout/sprout/registry/uniqueid"
 )
 
+func init() {
+	sprout.RegisterRegistryGroup("hermetic", RegistryGroup)
+}
+
 // hermetic.RegistryGroup is a group of all registries don't depend on external services
 // or influenced by the environment where the application is running.
 //
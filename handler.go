@@ -1,6 +1,8 @@
 package sprout
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"slices"
 	"strings"
@@ -55,19 +57,30 @@ type DefaultHandler struct {
 	registries []Registry
 	notices    []FunctionNotice
 
-	wantSafeFuncs bool
-	built         bool
+	wantSafeFuncs  bool
+	wantNamespaces bool
+	built          bool
+	closed         bool
 
 	cachedFuncsMap   FunctionMap
 	cachedFuncsAlias FunctionAliasMap
+	funcsOwners      map[string]string // UID of the registry of each function
 }
 
 // RegisterHandler registers a single FunctionRegistry implementation (e.g., a handler)
 // into the FunctionHandler's internal function registry. This method allows for integrating
 // additional functions into the template processing environment.
 // This function prevents duplicate registry registration by checking the UID
-// of the registry.
+// of the registry. Registries cannot be added to a closed handler.
+//
+// A registry added once the handler is built is initialized immediately, if
+// it implements RegistryWithInit. When its initialization fails, the registry
+// is not added and the error is returned.
 func (dh *DefaultHandler) AddRegistry(reg Registry) error {
+	if dh.closed {
+		return fmt.Errorf("cannot add registry %s: %w", reg.UID(), ErrHandlerClosed)
+	}
+
 	if slices.ContainsFunc(dh.registries, func(r Registry) bool {
 		return r.UID() == reg.UID()
 	}) {
@@ -80,7 +93,14 @@ func (dh *DefaultHandler) AddRegistry(reg Registry) error {
 		return err
 	}
 
-	if err := reg.RegisterFunctions(dh.cachedFuncsMap); err != nil {
+	if regInit, ok := reg.(RegistryWithInit); ok && dh.built {
+		if err := regInit.Init(); err != nil {
+			dh.registries = dh.registries[:len(dh.registries)-1]
+			return fmt.Errorf("cannot initialize registry %s: %w", reg.UID(), err)
+		}
+	}
+
+	if err := dh.registerFunctions(reg); err != nil {
 		return err
 	}
 
@@ -118,16 +138,39 @@ func (dh *DefaultHandler) AddRegistries(registries ...Registry) error {
 // multiple times, so it is safe to call this method multiple times to retrieve
 // the same built function map.
 //
+// The first call also initializes every registry implementing RegistryWithInit,
+// once all registries are linked to the handler. The functions of a registry
+// failing to initialize are not part of the function map, and the error is
+// logged.
+//
+// A closed handler cannot be built, an empty function map is returned and the
+// error is logged.
+//
 // NOTE: This replaces the [github.com/Masterminds/sprig.FuncMap],
 // [github.com/Masterminds/sprig.TxtFuncMap] and [github.com/Masterminds/sprig.HtmlFuncMap]
 // from sprig
 func (dh *DefaultHandler) Build() FunctionMap {
+	if dh.closed {
+		dh.logger.With("error", ErrHandlerClosed).Error("Failed to build the function map")
+		return make(FunctionMap)
+	}
+
 	if dh.built {
 		return dh.cachedFuncsMap
 	}
 
+	if err := dh.initRegistries(); err != nil {
+		dh.logger.With("error", err).Error("Failed to initialize registries")
+	}
+
 	AssignAliases(dh) // Ensure all aliases are processed before returning the registry
 	AssignNotices(dh) // Ensure all notices are processed before returning the registry
+	if dh.wantNamespaces {
+		// Ensure all functions are reachable through their registry namespace
+		if err := dh.assignNamespaces(); err != nil {
+			dh.logger.With("error", err).Error("Failed to assign namespaced functions")
+		}
+	}
 	if dh.wantSafeFuncs {
 		AssignSafeFuncs(dh) // Ensure all functions are wrapped with safe functions
 	}
@@ -136,6 +179,89 @@ func (dh *DefaultHandler) Build() FunctionMap {
 	return dh.cachedFuncsMap
 }
 
+// Close releases the resources owned by the registries of the DefaultHandler.
+//
+// Every registry implementing RegistryWithClose is closed, in the reverse
+// order of their registration, so a registry is always closed before the
+// registries it was registered after. All registries are closed even when
+// one of them fails, the returned error joins every error encountered.
+//
+// Calling Close more than once is safe, registries are closed only once. The
+// handler cannot be built nor receive new registries once closed.
+func (dh *DefaultHandler) Close() error {
+	if dh.closed {
+		return nil
+	}
+	dh.closed = true
+
+	var errs []error
+	for i := len(dh.registries) - 1; i >= 0; i-- {
+		if regClose, ok := dh.registries[i].(RegistryWithClose); ok {
+			if err := regClose.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("cannot close registry %s: %w", dh.registries[i].UID(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// initRegistries initializes every registry implementing RegistryWithInit, in
+// the order of their registration. A registry failing to initialize is removed
+// from the handler with its functions, so the functions it hid are registered
+// from the next registries exposing them. The returned error joins every error
+// encountered.
+func (dh *DefaultHandler) initRegistries() error {
+	var errs []error
+	registries := make([]Registry, 0, len(dh.registries))
+	for _, reg := range dh.registries {
+		if regInit, ok := reg.(RegistryWithInit); ok {
+			if err := regInit.Init(); err != nil {
+				errs = append(errs, fmt.Errorf("cannot initialize registry %s: %w", reg.UID(), err))
+				continue
+			}
+		}
+		registries = append(registries, reg)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	dh.registries = registries
+	for name, uid := range dh.funcsOwners {
+		if !slices.ContainsFunc(registries, func(r Registry) bool { return r.UID() == uid }) {
+			delete(dh.cachedFuncsMap, name)
+			delete(dh.funcsOwners, name)
+		}
+	}
+	for _, reg := range registries {
+		if err := dh.registerFunctions(reg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// registerFunctions registers the functions of a registry in the function map
+// of the handler, remembering the registry of every function it adds. Existing
+// functions are never overwritten.
+func (dh *DefaultHandler) registerFunctions(reg Registry) error {
+	regFuncs := make(FunctionMap)
+	if err := reg.RegisterFunctions(regFuncs); err != nil {
+		return err
+	}
+
+	for name, fn := range regFuncs {
+		if _, ok := dh.cachedFuncsMap[name]; !ok {
+			dh.cachedFuncsMap[name] = fn
+			dh.funcsOwners[name] = reg.UID()
+		}
+	}
+	return nil
+}
+
 // Logger returns the logger instance associated with the DefaultHandler.
 //
 // The logger is used for logging information, warnings, and errors that occur
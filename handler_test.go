@@ -348,3 +348,136 @@ func TestSafeFuncName(t *testing.T) {
 	assert.Equal(t, "safeFn", safeFuncName("Fn"))
 	assert.Empty(t, safeFuncName(""))
 }
+
+// lifecycleRegistry records the lifecycle hooks called on it into events.
+type lifecycleRegistry struct {
+	name     string
+	events   *[]string
+	funcs    FunctionMap
+	initErr  error
+	closeErr error
+}
+
+func (m *lifecycleRegistry) UID() string { return m.name }
+
+func (m *lifecycleRegistry) LinkHandler(_ Handler) error { return nil }
+
+func (m *lifecycleRegistry) RegisterFunctions(fnMap FunctionMap) error {
+	for name, fn := range m.funcs {
+		AddFunction(fnMap, name, fn)
+	}
+	return nil
+}
+
+func (m *lifecycleRegistry) Init() error {
+	*m.events = append(*m.events, "init:"+m.name)
+	return m.initErr
+}
+
+func (m *lifecycleRegistry) Close() error {
+	*m.events = append(*m.events, "close:"+m.name)
+	return m.closeErr
+}
+
+func TestDefaultHandler_Lifecycle(t *testing.T) {
+	var events []string
+	handler := New(WithRegistries(
+		&lifecycleRegistry{name: "first", events: &events},
+		new(funcsRegistry),
+		&lifecycleRegistry{name: "second", events: &events},
+	))
+
+	handler.Build()
+	handler.Build()
+	assert.Equal(t, []string{"init:first", "init:second"}, events, "Registries should be initialized once, in registration order")
+
+	require.NoError(t, handler.Close())
+	require.NoError(t, handler.Close())
+	assert.Equal(t, []string{"init:first", "init:second", "close:second", "close:first"}, events, "Registries should be closed once, in reverse order")
+}
+
+func TestDefaultHandler_Lifecycle_InitError(t *testing.T) {
+	var events []string
+	loggerHandler := &noticeLoggerHandler{}
+	handler := New(
+		WithLogger(slog.New(loggerHandler)),
+		WithRegistries(
+			&lifecycleRegistry{name: "first", events: &events, initErr: errMock, funcs: FunctionMap{
+				"hello": func() string { return "first" },
+				"only":  func() string { return "first" },
+			}},
+			&lifecycleRegistry{name: "second", events: &events, funcs: FunctionMap{
+				"hello": func() string { return "second" },
+			}},
+		),
+	)
+
+	funcs := handler.Build()
+	assert.Equal(t, []string{"init:first", "init:second"}, events, "All registries should be initialized even on error")
+	assert.Equal(t, "[ERROR] Failed to initialize registries\n", loggerHandler.messages.String())
+	assert.NotContains(t, funcs, "only", "Functions of a registry failing to initialize should be removed")
+	require.Contains(t, funcs, "hello")
+	assert.Equal(t, "second", funcs["hello"].(func() string)(), "Functions hidden by a failed registry should be registered")
+
+	require.NoError(t, handler.Close())
+	assert.Equal(t, []string{"init:first", "init:second", "close:second"}, events, "Registries failing to initialize should not be closed")
+}
+
+func TestDefaultHandler_Lifecycle_AddAfterBuild(t *testing.T) {
+	var events []string
+	handler := New(WithRegistries(&lifecycleRegistry{name: "first", events: &events}))
+	handler.Build()
+
+	require.NoError(t, handler.AddRegistry(&lifecycleRegistry{name: "second", events: &events, funcs: FunctionMap{
+		"hello": func() string { return "second" },
+	}}))
+	assert.Equal(t, []string{"init:first", "init:second"}, events, "A registry added after build should be initialized immediately")
+	assert.Contains(t, handler.Build(), "hello")
+
+	err := handler.AddRegistry(&lifecycleRegistry{name: "third", events: &events, initErr: errMock, funcs: FunctionMap{
+		"only": func() string { return "third" },
+	}})
+	require.ErrorIs(t, err, errMock)
+	require.ErrorContains(t, err, "cannot initialize registry third")
+	assert.NotContains(t, handler.Build(), "only", "Functions of a registry failing to initialize should not be added")
+
+	require.NoError(t, handler.Close())
+	assert.Equal(t, []string{"init:first", "init:second", "init:third", "close:second", "close:first"}, events, "Registries failing to initialize should not be closed")
+}
+
+func TestDefaultHandler_Lifecycle_InitErrors(t *testing.T) {
+	var events []string
+	handler := New(WithRegistries(
+		&lifecycleRegistry{name: "first", events: &events, initErr: errMock},
+		&lifecycleRegistry{name: "second", events: &events, initErr: errMock},
+	))
+
+	err := handler.initRegistries()
+	require.ErrorIs(t, err, errMock)
+	require.ErrorContains(t, err, "cannot initialize registry first")
+	require.ErrorContains(t, err, "cannot initialize registry second")
+}
+
+func TestDefaultHandler_Lifecycle_Closed(t *testing.T) {
+	loggerHandler := &noticeLoggerHandler{}
+	handler := New(WithLogger(slog.New(loggerHandler)), WithRegistries(&funcsRegistry{uid: "go-sprout/sprout.first", funcs: FunctionMap{"hello": func() string { return "first" }}}))
+	require.NoError(t, handler.Close())
+
+	assert.Empty(t, handler.Build(), "A closed handler should not be built")
+	assert.Equal(t, "[ERROR] Failed to build the function map\n", loggerHandler.messages.String())
+	require.ErrorIs(t, handler.AddRegistry(new(funcsRegistry)), ErrHandlerClosed)
+}
+
+func TestDefaultHandler_Lifecycle_CloseError(t *testing.T) {
+	var events []string
+	handler := New(WithRegistries(
+		&lifecycleRegistry{name: "first", events: &events, closeErr: errMock},
+		&lifecycleRegistry{name: "second", events: &events, closeErr: errMock},
+	))
+
+	err := handler.Close()
+	require.ErrorIs(t, err, errMock)
+	require.ErrorContains(t, err, "cannot close registry first")
+	require.ErrorContains(t, err, "cannot close registry second")
+	assert.Equal(t, []string{"close:second", "close:first"}, events, "All registries should be closed even on error")
+}
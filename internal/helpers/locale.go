@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ParseLocale parses a BCP 47 locale, like "en-US" or "fr", also accepting
+// underscores as separators, like "fr_FR".
+//
+// Parameters:
+//
+//	locale string - the locale to parse.
+//
+// Returns:
+//
+//	language.Tag - the parsed locale.
+//	error - an error if the locale is not a valid BCP 47 language tag.
+//
+// Example:
+//
+//	tag, _ := ParseLocale("fr_CA")
+//	fmt.Println(tag) // Output: fr-CA
+func ParseLocale(locale string) (language.Tag, error) {
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return language.Und, fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+	return tag, nil
+}
+
+// LookupLocale returns the entry of a table of locale data for the locale,
+// looked up by language and explicit region first, like "pt-PT", then by
+// language alone, like "pt".
+//
+// Parameters:
+//
+//	table map[string]T - the locale data, by language or language and region.
+//	locale language.Tag - the locale to look up.
+//
+// Returns:
+//
+//	T - the entry of the locale.
+//	bool - whether the table has an entry for the locale.
+//
+// Example:
+//
+//	names := map[string]string{"pt": "Português", "pt-PT": "Português europeu"}
+//	name, _ := LookupLocale(names, language.MustParse("pt-BR"))
+//	fmt.Println(name) // Output: Português
+func LookupLocale[T any](table map[string]T, locale language.Tag) (T, bool) {
+	base, _ := locale.Base()
+	if region, confidence := locale.Region(); confidence == language.Exact {
+		if entry, ok := table[base.String()+"-"+region.String()]; ok {
+			return entry, true
+		}
+	}
+	entry, ok := table[base.String()]
+	return entry, ok
+}
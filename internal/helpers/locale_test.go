@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseLocale(t *testing.T) {
+	tag, err := ParseLocale("fr_CA")
+	require.NoError(t, err)
+	assert.Equal(t, language.CanadianFrench, tag)
+
+	_, err = ParseLocale("zz")
+	require.ErrorContains(t, err, "invalid locale \"zz\"")
+
+	_, err = ParseLocale("")
+	require.ErrorContains(t, err, "invalid locale \"\"")
+}
+
+func TestLookupLocale(t *testing.T) {
+	table := map[string]string{"pt": "Brazil", "pt-PT": "Portugal"}
+
+	entry, ok := LookupLocale(table, language.MustParse("pt-PT"))
+	assert.True(t, ok)
+	assert.Equal(t, "Portugal", entry)
+
+	entry, ok = LookupLocale(table, language.MustParse("pt-BR"))
+	assert.True(t, ok)
+	assert.Equal(t, "Brazil", entry)
+
+	entry, ok = LookupLocale(table, language.MustParse("pt"))
+	assert.True(t, ok)
+	assert.Equal(t, "Brazil", entry)
+
+	_, ok = LookupLocale(table, language.MustParse("en-GB"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitEscapedPath splits a path on unescaped separators and resolves the
+// escape sequences:
+//   - \<sep> → literal separator (not a path separator)
+//   - \\ → literal backslash
+//   - \x (other) → error
+//
+// Empty segments, from consecutive, leading or trailing separators, are
+// rejected, while an empty path is a single empty key.
+//
+// Parameters:
+//
+//	path string - a path that may contain escape sequences.
+//	sep string - the non-empty separator, without backslash.
+//	sepName string - the plural name of the separator in error messages, like "dots".
+//
+// Returns:
+//
+//	[]string - the path split on unescaped separators, with escapes resolved.
+//	error - an error if an invalid escape sequence is found or if the path contains empty segments.
+//
+// Example:
+//
+//	keys, _ := SplitEscapedPath(`example\.com.port`, ".", "dots")
+//	fmt.Printf("%q\n", keys) // Output: ["example.com" "port"]
+func SplitEscapedPath(path, sep, sepName string) ([]string, error) {
+	var parts []string
+	var segment strings.Builder
+	lastWasSep := true // Start true to detect leading separator
+
+	for i := 0; i < len(path); {
+		// Handle unescaped separator as path separator
+		if strings.HasPrefix(path[i:], sep) {
+			if lastWasSep {
+				return nil, fmt.Errorf("empty key segment in path %q (consecutive or leading/trailing %s)", path, sepName)
+			}
+			parts = append(parts, segment.String())
+			segment.Reset()
+			lastWasSep = true
+			i += len(sep)
+			continue
+		}
+
+		lastWasSep = false
+
+		// Handle non-escape characters
+		r, size := utf8.DecodeRuneInString(path[i:])
+		if r != '\\' {
+			segment.WriteString(path[i : i+size])
+			i += size
+			continue
+		}
+
+		// Handle escape sequences
+		i++
+		switch {
+		case i >= len(path):
+			return nil, fmt.Errorf("invalid escape sequence: trailing backslash in key %q", path)
+		case strings.HasPrefix(path[i:], sep):
+			segment.WriteString(sep)
+			i += len(sep)
+		case path[i] == '\\':
+			segment.WriteByte('\\')
+			i++
+		default:
+			next, _ := utf8.DecodeRuneInString(path[i:])
+			return nil, fmt.Errorf("invalid escape sequence: \\%c in key %q", next, path)
+		}
+	}
+
+	// Check for trailing separator
+	if lastWasSep && len(path) > 0 {
+		return nil, fmt.Errorf("empty key segment in path %q (consecutive or leading/trailing %s)", path, sepName)
+	}
+
+	parts = append(parts, segment.String())
+	return parts, nil
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitEscapedPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		sep      string
+		expected []string
+		wantErr  string
+	}{
+		{name: "multi-character separator", input: "APP__DB__HOST", sep: "__", expected: []string{"APP", "DB", "HOST"}},
+		{name: "single underscore kept", input: "APP_NAME__PORT", sep: "__", expected: []string{"APP_NAME", "PORT"}},
+		{name: "escaped separator", input: `a\/b/c`, sep: "/", expected: []string{"a/b", "c"}},
+		{name: "dots are not separators", input: "a.b/c", sep: "/", expected: []string{"a.b", "c"}},
+		{name: "escaped backslash", input: `a\\/b`, sep: "/", expected: []string{`a\`, "b"}},
+		{name: "escaped dot is invalid", input: `a\.b`, sep: "/", wantErr: `invalid escape sequence: \.`},
+		{name: "unicode segments", input: "日本::語", sep: "::", expected: []string{"日本", "語"}},
+		{name: "trailing separator", input: "a__", sep: "__", wantErr: "empty key segment"},
+		{name: "leading separator", input: "__a", sep: "__", wantErr: "empty key segment"},
+		{name: "consecutive separators", input: "a//b", sep: "/", wantErr: `empty key segment in path "a//b" (consecutive or leading/trailing separators)`},
+		{name: "empty path", input: "", sep: "/", expected: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SplitEscapedPath(tt.input, tt.sep, "separators")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
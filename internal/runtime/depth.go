@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	goruntime "runtime"
+)
+
+// callersChunk is the number of program counters read at once from the stack.
+const callersChunk = 64
+
+// CallDepth returns how many calls of the function calling CallDepth are on
+// the stack of the current goroutine, the current call included.
+//
+// Templates are executed on the goroutine calling Execute, so a template
+// function rendering a template can use it to bound its own recursion. Each
+// execution has its own stack, concurrent executions never add up.
+//
+//go:noinline
+func CallDepth() int {
+	caller := make([]uintptr, 1)
+	if goruntime.Callers(2, caller) == 0 {
+		return 0
+	}
+	name := funcName(caller[0])
+
+	depth := 0
+	pcs := make([]uintptr, callersChunk)
+	for skip := 2; ; skip += callersChunk {
+		n := goruntime.Callers(skip, pcs)
+		for _, pc := range pcs[:n] {
+			if funcName(pc) == name {
+				depth++
+			}
+		}
+		if n < callersChunk {
+			return depth
+		}
+	}
+}
+
+// funcName returns the name of the function of a return address, or an empty
+// string when it is unknown. Functions inlined in another one have their own
+// return address, so they are told apart by name rather than by entry.
+func funcName(pc uintptr) string {
+	// The return address may be the first instruction of the next function.
+	fn := goruntime.FuncForPC(pc - 1)
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
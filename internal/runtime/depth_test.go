@@ -0,0 +1,44 @@
+package runtime
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:noinline
+func recurse(n int, depths *[]int) {
+	*depths = append(*depths, CallDepth())
+	if n > 1 {
+		recurse(n-1, depths)
+	}
+}
+
+//go:noinline
+func deepRecurse(n int) int {
+	if n > 1 {
+		return deepRecurse(n - 1)
+	}
+	return CallDepth()
+}
+
+func TestCallDepth(t *testing.T) {
+	var depths []int
+	recurse(3, &depths)
+	assert.Equal(t, []int{1, 2, 3}, depths)
+
+	assert.Equal(t, 1000, deepRecurse(1000), "The whole stack should be read")
+}
+
+func TestCallDepth_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 50, deepRecurse(50))
+		}()
+	}
+	wg.Wait()
+}
@@ -12,8 +12,41 @@ var (
 	ErrIncorrectArguments    = errors.New("cannot safecall function: number of arguments does not match function's input arity")
 	ErrMoreThanTwoReturns    = errors.New("cannot safecall function: function returns more than two values")
 	ErrInvalidLastReturnType = errors.New("cannot safecall function: invalid last return type (expected error)")
+	ErrNoReturnValue         = errors.New("cannot safecall function: function returns no value")
 )
 
+// errorType is the reflected type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ValidateFunction checks, without calling it, that `fn` is a function that
+// can be safely called with SafeCall and installed in a template function map:
+// it must return one or two values, and when it returns two values the last
+// one must be of type error, like text/template requires.
+func ValidateFunction(fn any) error {
+	if fn == nil {
+		return ErrInvalidFunction
+	}
+
+	fnType := reflect.TypeOf(fn)
+	if fnType.Kind() != reflect.Func {
+		return ErrInvalidFunction
+	}
+
+	switch fnType.NumOut() {
+	case 0:
+		return ErrNoReturnValue
+	case 1:
+		return nil
+	case 2:
+		if fnType.Out(1) != errorType {
+			return ErrInvalidLastReturnType
+		}
+		return nil
+	default:
+		return ErrMoreThanTwoReturns
+	}
+}
+
 // SafeCall safely calls a function using reflection. It handles potential
 // panics by recovering and returning an error. The function `fn` is expected
 // to be a function, and `args` are the arguments to pass to that function.
@@ -66,7 +99,7 @@ func SafeCall(fn any, args ...any) (result any, err error) {
 	case 1:
 		return out[0].Interface(), nil
 	case 2:
-		if out[1].Type().Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		if out[1].Type().Implements(errorType) {
 			err, _ = out[1].Interface().(error)
 			return out[0].Interface(), err
 		}
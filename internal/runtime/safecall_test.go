@@ -89,3 +89,21 @@ func TestSafeCall(t *testing.T) {
 	require.ErrorIs(t, err, ErrInvalidLastReturnType)
 	assert.Equal(t, "a", out)
 }
+
+// customError implements error, but text/template only accepts functions
+// whose last return type is error itself.
+type customError struct{}
+
+func (*customError) Error() string { return "custom" }
+
+func TestValidateFunction(t *testing.T) {
+	require.NoError(t, ValidateFunction(func() string { return "" }))
+	require.NoError(t, ValidateFunction(func(_ ...any) (any, error) { return nil, nil }))
+
+	require.ErrorIs(t, ValidateFunction(nil), ErrInvalidFunction)
+	require.ErrorIs(t, ValidateFunction("cheese"), ErrInvalidFunction)
+	require.ErrorIs(t, ValidateFunction(func() {}), ErrNoReturnValue)
+	require.ErrorIs(t, ValidateFunction(func() (string, string) { return "", "" }), ErrInvalidLastReturnType)
+	require.ErrorIs(t, ValidateFunction(func() (string, *customError) { return "", nil }), ErrInvalidLastReturnType)
+	require.ErrorIs(t, ValidateFunction(func() (string, string, error) { return "", "", nil }), ErrMoreThanTwoReturns)
+}
@@ -0,0 +1,157 @@
+package sprout
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// namespaceSeparator is the separator placed between the namespace of a
+// registry and the name of one of its functions, e.g. `strings_toUpper`.
+const namespaceSeparator = "_"
+
+// WithNamespaces enables the namespaced access to functions in a DefaultHandler.
+// When namespaces are enabled, the handler additionally exposes every function
+// of every registry under a name prefixed by the namespace of its registry,
+// derived from the registry UID.
+//
+// Namespaced functions are registered from each registry directly, they are
+// never shadowed by a function of the same name coming from another registry.
+// This is useful to disambiguate registries sharing function names, like
+// `regex` and `regexp`. Registries sharing the same namespace are not
+// namespaced, see RegistryNamespace.
+//
+// Example:
+//
+//	handler := New(WithNamespaces(true))
+//
+//	{{ "hello" | strings_toUpper }} // Output: HELLO
+func WithNamespaces(enabled bool) HandlerOption[*DefaultHandler] {
+	return func(dh *DefaultHandler) error {
+		dh.wantNamespaces = enabled
+		return nil
+	}
+}
+
+// assignNamespaces registers the functions of every registry of the handler
+// under their namespaced name. Registries are asked to register their functions
+// a second time into a dedicated map, so functions hidden by another registry
+// in the global namespace are still reachable through their own namespace.
+//
+// The aliases of a function are namespaced with it, and the notices of a
+// function or alias apply to its namespaced name too.
+//
+// Registries sharing the same namespace are not namespaced at all, since their
+// namespaced functions would be ambiguous, and an error is returned. In the
+// same way, a namespaced name produced by several registries, like `a_b_c`
+// from the namespace `a` and the function `b_c` and from the namespace `a_b`
+// and the function `c`, is not registered. Existing functions are never
+// overwritten.
+func (dh *DefaultHandler) assignNamespaces() error {
+	owners := make(map[string][]string, len(dh.registries))
+	for _, reg := range dh.registries {
+		namespace := RegistryNamespace(reg.UID())
+		owners[namespace] = append(owners[namespace], reg.UID())
+	}
+
+	var errs []error
+	namespacedFuncs := make(FunctionMap)
+	funcsOwners := make(map[string][]string)
+	for _, reg := range dh.registries {
+		namespace := RegistryNamespace(reg.UID())
+		if namespace == "" {
+			continue
+		}
+		if uids := owners[namespace]; len(uids) > 1 {
+			if uids[0] == reg.UID() {
+				errs = append(errs, fmt.Errorf("registries %v share the namespace %q, none of them is namespaced", uids, namespace))
+			}
+			continue
+		}
+
+		regFuncs := make(FunctionMap)
+		if err := reg.RegisterFunctions(regFuncs); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for originalName, aliases := range dh.cachedFuncsAlias {
+			if fn, ok := regFuncs[originalName]; ok {
+				for _, alias := range aliases {
+					AddFunction(regFuncs, alias, fn)
+				}
+			}
+		}
+
+		for _, notice := range dh.notices {
+			for _, funcName := range notice.FunctionNames {
+				if fn, ok := regFuncs[funcName]; ok {
+					regFuncs[funcName] = noticeWrapper(dh, notice, namespacedFuncName(namespace, funcName), fn)
+				}
+			}
+		}
+
+		for funcName, fn := range regFuncs {
+			name := namespacedFuncName(namespace, funcName)
+			namespacedFuncs[name] = fn
+			funcsOwners[name] = append(funcsOwners[name], reg.UID())
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(namespacedFuncs)) {
+		if uids := funcsOwners[name]; len(uids) > 1 {
+			errs = append(errs, fmt.Errorf("registries %v share the namespaced function %q, it is not registered", uids, name))
+			continue
+		}
+		AddFunction(dh.cachedFuncsMap, name, namespacedFuncs[name])
+	}
+
+	return errors.Join(errs...)
+}
+
+// RegistryNamespace returns the namespace derived from a registry UID. The
+// namespace is the registry name, the part of the UID after the last dot, with
+// every character not allowed in a template identifier replaced by an
+// underscore. A namespace starting with a digit is prefixed with an
+// underscore, so namespaced names stay valid identifiers.
+//
+// Registries of different owners can share the same namespace, like
+// `go-sprout/sprout.strings` and `acme/tools.strings`.
+//
+// Example:
+//
+//	RegistryNamespace("go-sprout/sprout.strings") // strings
+//	RegistryNamespace("acme/tools.my-registry")   // my_registry
+//	RegistryNamespace("acme/tools.3d")            // _3d
+func RegistryNamespace(uid string) string {
+	name := uid
+	if idx := strings.LastIndexAny(name, "./"); idx != -1 {
+		name = name[idx+1:]
+	}
+
+	namespace := strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if namespace != "" && '0' <= namespace[0] && namespace[0] <= '9' {
+		namespace = "_" + namespace
+	}
+	return namespace
+}
+
+// namespacedFuncName generates the namespaced name of a function.
+//
+// Example:
+//
+//	namespacedFuncName("strings", "toUpper") // strings_toUpper
+func namespacedFuncName(namespace, name string) string {
+	if namespace == "" || name == "" {
+		return name
+	}
+
+	return namespace + namespaceSeparator + name
+}
@@ -0,0 +1,154 @@
+package sprout
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// funcsRegistry is a minimal registry registering the given functions.
+type funcsRegistry struct {
+	uid   string
+	funcs FunctionMap
+}
+
+func (r *funcsRegistry) UID() string                 { return r.uid }
+func (r *funcsRegistry) LinkHandler(_ Handler) error { return nil }
+func (r *funcsRegistry) RegisterFunctions(fnMap FunctionMap) error {
+	for name, fn := range r.funcs {
+		AddFunction(fnMap, name, fn)
+	}
+	return nil
+}
+
+func TestWithNamespaces(t *testing.T) {
+	handler := New(WithNamespaces(true))
+	assert.True(t, handler.wantNamespaces)
+
+	handler = New(WithNamespaces(false))
+	assert.False(t, handler.wantNamespaces)
+}
+
+func TestNamespacesInTemplate(t *testing.T) {
+	first := &funcsRegistry{uid: "go-sprout/sprout.first", funcs: FunctionMap{"hello": func() string { return "first" }}}
+	second := &funcsRegistry{uid: "acme/tools.second-one", funcs: FunctionMap{"hello": func() string { return "second" }}}
+
+	handler := New(WithRegistries(first, second), WithNamespaces(true))
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ hello }} {{ first_hello }} {{ second_one_hello }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "first first second", buf.String())
+}
+
+func TestNamespacesWithAliasesAndNotices(t *testing.T) {
+	loggerHandler := &noticeLoggerHandler{}
+	reg := FuncRegistry("acme/tools.greetings", map[string]any{
+		"hello": func() string { return "hello" },
+	}).WithAlias("hello", "hi").WithNotices(NewDeprecatedNotice("hi", "please use `hello` instead"))
+
+	handler := New(WithLogger(slog.New(loggerHandler)), WithRegistries(reg), WithNamespaces(true))
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ greetings_hello }} {{ greetings_hi }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "hello hello", buf.String())
+	assert.Equal(t, "[WARN] Template function `greetings_hi` is deprecated: please use `hello` instead\n", loggerHandler.messages.String())
+}
+
+func TestNamespacesCollision(t *testing.T) {
+	sproutStrings := &funcsRegistry{uid: "go-sprout/sprout.strings", funcs: FunctionMap{"hello": func() string { return "sprout" }}}
+	acmeStrings := &funcsRegistry{uid: "acme/tools.strings", funcs: FunctionMap{"hello": func() string { return "acme" }}}
+	other := &funcsRegistry{uid: "acme/tools.other", funcs: FunctionMap{"bye": func() string { return "bye" }}}
+
+	handler := New(WithRegistries(sproutStrings, acmeStrings, other))
+	require.EqualError(t, handler.assignNamespaces(), `registries [go-sprout/sprout.strings acme/tools.strings] share the namespace "strings", none of them is namespaced`)
+
+	funcs := handler.RawFunctions()
+	assert.NotContains(t, funcs, "strings_hello")
+	assert.Contains(t, funcs, "other_bye")
+}
+
+func TestNamespacesFunctionCollision(t *testing.T) {
+	a := &funcsRegistry{uid: "acme/tools.a", funcs: FunctionMap{"b_c": func() string { return "a" }, "d": func() string { return "d" }}}
+	ab := &funcsRegistry{uid: "acme/tools.a_b", funcs: FunctionMap{"c": func() string { return "a_b" }}}
+
+	handler := New(WithRegistries(a, ab))
+	require.EqualError(t, handler.assignNamespaces(), `registries [acme/tools.a acme/tools.a_b] share the namespaced function "a_b_c", it is not registered`)
+
+	funcs := handler.RawFunctions()
+	assert.NotContains(t, funcs, "a_b_c")
+	assert.Contains(t, funcs, "a_d")
+}
+
+func TestNamespacesStartingWithDigit(t *testing.T) {
+	reg := FuncRegistry("acme/x.3d", map[string]any{"f": func() string { return "f" }})
+
+	handler := New(WithRegistries(reg), WithNamespaces(true))
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ _3d_f }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "f", buf.String())
+}
+
+func TestNamespacesDisabled(t *testing.T) {
+	reg := &funcsRegistry{uid: "go-sprout/sprout.first", funcs: FunctionMap{"hello": func() string { return "first" }}}
+
+	funcs := New(WithRegistries(reg)).Build()
+	assert.Contains(t, funcs, "hello")
+	assert.NotContains(t, funcs, "first_hello")
+}
+
+func TestNamespacesWithSafeFuncs(t *testing.T) {
+	reg := &funcsRegistry{uid: "go-sprout/sprout.first", funcs: FunctionMap{"hello": func() string { return "first" }}}
+
+	funcs := New(WithRegistries(reg), WithNamespaces(true), WithSafeFuncs(true)).Build()
+	assert.Contains(t, funcs, "first_hello")
+	assert.Contains(t, funcs, "safeFirst_hello")
+}
+
+func TestNamespacesRegisterFunctionsError(t *testing.T) {
+	mockRegistry := new(MockRegistry)
+	mockRegistry.On("UID").Return("go-sprout/sprout.mock")
+	mockRegistry.On("LinkHandler", mock.Anything).Return()
+	mockRegistry.On("RegisterFunctions", mock.Anything).Return()
+
+	other := &funcsRegistry{uid: "acme/tools.other", funcs: FunctionMap{"bye": func() string { return "bye" }}}
+	sproutStrings := &funcsRegistry{uid: "go-sprout/sprout.strings", funcs: FunctionMap{"hello": func() string { return "sprout" }}}
+	acmeStrings := &funcsRegistry{uid: "acme/tools.strings", funcs: FunctionMap{"hello": func() string { return "acme" }}}
+
+	handler := New(WithRegistries(sproutStrings, acmeStrings, mockRegistry, other), WithNamespaces(true))
+	mockRegistry.registerFuncsMustCrash = true
+
+	err := handler.assignNamespaces()
+	require.ErrorIs(t, err, errMock)
+	require.ErrorContains(t, err, `share the namespace "strings"`, "errors collected before the failure must be kept")
+	assert.Contains(t, handler.RawFunctions(), "other_bye", "registries after the failure must still be namespaced")
+}
+
+func TestRegistryNamespace(t *testing.T) {
+	assert.Equal(t, "strings", RegistryNamespace("go-sprout/sprout.strings"))
+	assert.Equal(t, "my_registry", RegistryNamespace("acme/tools.my-registry"))
+	assert.Equal(t, "registry", RegistryNamespace("acme/registry"))
+	assert.Equal(t, "registry", RegistryNamespace("registry"))
+	assert.Empty(t, RegistryNamespace("acme/tools."))
+	assert.Equal(t, "_3d", RegistryNamespace("acme/x.3d"))
+	assert.Equal(t, "_3d", RegistryNamespace("acme/x.-3d"))
+}
+
+func TestNamespacedFuncName(t *testing.T) {
+	assert.Equal(t, "strings_toUpper", namespacedFuncName("strings", "toUpper"))
+	assert.Equal(t, "toUpper", namespacedFuncName("", "toUpper"))
+	assert.Empty(t, namespacedFuncName("strings", ""))
+}
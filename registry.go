@@ -38,6 +38,28 @@ type RegistryWithNotice interface {
 	RegisterNotices(notices *[]FunctionNotice) error
 }
 
+type RegistryWithInit interface {
+	// Init initializes the registry once all registries are linked to the
+	// Handler, right before the function map is built for the first time.
+	// This method is called by an Handler to let a registry prepare the
+	// resources it owns (caches, resolvers, key stores, ...).
+	Init() error
+}
+
+type RegistryWithClose interface {
+	// Close releases the resources owned by the registry.
+	// This method is called by an Handler when the handler itself is closed,
+	// registries are closed in the reverse order of their registration.
+	Close() error
+}
+
+type RegistryWithConfig interface {
+	// Configure applies the provided options to the registry.
+	// This method is called by NewFromConfig, before the registry is added to
+	// the Handler, when options are configured for the registry.
+	Configure(options map[string]any) error
+}
+
 // AddFunction adds a new function under the specified name to the given registry.
 // If the function name already exists in the registry, this method does nothing to
 // prevent accidental overwriting of existing registered functions.
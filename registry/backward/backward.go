@@ -34,6 +34,10 @@ func NewRegistry() *BackwardCompatibilityRegistry {
 	return &BackwardCompatibilityRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (bcr *BackwardCompatibilityRegistry) UID() string {
 	return "go-sprout/sprout.backwardcompatibilitywithsprig"
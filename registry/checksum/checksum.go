@@ -11,6 +11,10 @@ func NewRegistry() *ChecksumRegistry {
 	return &ChecksumRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (cr *ChecksumRegistry) UID() string {
 	return "go-sprout/sprout.checksum"
@@ -11,6 +11,10 @@ func NewRegistry() *ConversionRegistry {
 	return &ConversionRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (or *ConversionRegistry) UID() string {
 	return "go-sprout/sprout.conversion"
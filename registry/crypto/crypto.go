@@ -56,6 +56,10 @@ func NewRegistry() *CryptoRegistry {
 	return &CryptoRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the crypto handler.
 func (ch *CryptoRegistry) UID() string {
 	return "go-sprout/sprout.crypto"
@@ -0,0 +1,46 @@
+// Package decimal provides exact decimal arithmetic backed by math/big, for
+// the templates where float64 rounding errors are not acceptable, like
+// invoices. Decimal values keep their scale, so "12.30" is printed "12.30",
+// and they are accepted by the functions of the numeric registry.
+package decimal
+
+import (
+	"github.com/go-sprout/sprout"
+)
+
+type DecimalRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+}
+
+// NewRegistry creates a new instance of decimal registry.
+func NewRegistry() *DecimalRegistry {
+	return &DecimalRegistry{}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (dr *DecimalRegistry) UID() string {
+	return "go-sprout/sprout.decimal"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (dr *DecimalRegistry) LinkHandler(fh sprout.Handler) error {
+	dr.handler = fh
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (dr *DecimalRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "decimal", dr.Decimal)
+	sprout.AddFunction(funcsMap, "decAdd", dr.DecAdd)
+	sprout.AddFunction(funcsMap, "decSub", dr.DecSub)
+	sprout.AddFunction(funcsMap, "decMul", dr.DecMul)
+	sprout.AddFunction(funcsMap, "decDiv", dr.DecDiv)
+	sprout.AddFunction(funcsMap, "decRound", dr.DecRound)
+	sprout.AddFunction(funcsMap, "decCmp", dr.DecCmp)
+	sprout.AddFunction(funcsMap, "decFormat", dr.DecFormat)
+	return nil
+}
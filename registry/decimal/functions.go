@@ -0,0 +1,242 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Decimal converts a value to an exact decimal number. Numeric strings keep
+// their scale, "12.30" having two decimals, and floats are converted from
+// their shortest representation, so 0.1 is exactly 0.1.
+//
+// Parameters:
+//
+//	value any - the number to convert: a numeric string, an integer, a float or a decimal.
+//
+// Returns:
+//
+//	Decimal - the decimal number.
+//	error - an error if the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decimal].
+//
+// [Sprout Documentation: decimal]: https://docs.atom.codes/sprout/registries/decimal#decimal
+func (dr *DecimalRegistry) Decimal(value any) (Decimal, error) {
+	return toDecimal(value)
+}
+
+// DecAdd returns the exact sum of the values, with the largest of their
+// scales.
+//
+// Parameters:
+//
+//	values ...any - the numbers to add.
+//
+// Returns:
+//
+//	Decimal - the sum of the values, 0 without values.
+//	error - an error if a value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decAdd].
+//
+// [Sprout Documentation: decAdd]: https://docs.atom.codes/sprout/registries/decimal#decadd
+func (dr *DecimalRegistry) DecAdd(values ...any) (Decimal, error) {
+	decimals, err := toDecimals(values)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	var result Decimal
+	for _, d := range decimals {
+		result = add(result, d)
+	}
+	return result, nil
+}
+
+// DecSub subtracts the following values from the first one, exactly and
+// with the largest of their scales.
+//
+// Parameters:
+//
+//	values ...any - the number to subtract from, followed by the numbers to subtract.
+//
+// Returns:
+//
+//	Decimal - the difference, 0 without values.
+//	error - an error if a value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decSub].
+//
+// [Sprout Documentation: decSub]: https://docs.atom.codes/sprout/registries/decimal#decsub
+func (dr *DecimalRegistry) DecSub(values ...any) (Decimal, error) {
+	decimals, err := toDecimals(values)
+	if err != nil || len(decimals) == 0 {
+		return Decimal{}, err
+	}
+
+	result := decimals[0]
+	for _, d := range decimals[1:] {
+		result = sub(result, d)
+	}
+	return result, nil
+}
+
+// DecMul returns the exact product of the values, whose scale is the sum of
+// their scales: 1.10 × 1.10 is 1.2100. Use `decRound` to bring the result
+// back to the wanted scale.
+//
+// Parameters:
+//
+//	values ...any - the numbers to multiply.
+//
+// Returns:
+//
+//	Decimal - the product of the values, 1 without values.
+//	error - an error if a value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decMul].
+//
+// [Sprout Documentation: decMul]: https://docs.atom.codes/sprout/registries/decimal#decmul
+func (dr *DecimalRegistry) DecMul(values ...any) (Decimal, error) {
+	decimals, err := toDecimals(values)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	result := Decimal{unscaled: big.NewInt(1)}
+	for _, d := range decimals {
+		result = mul(result, d)
+	}
+	return result, nil
+}
+
+// DecDiv divides the dividend by the divisor, rounding the quotient half up
+// at the given scale, as a division is rarely exact.
+//
+// Parameters:
+//
+//	scale int - the number of decimals of the quotient.
+//	dividend any - the number to divide.
+//	divisor any - the number to divide by.
+//
+// Returns:
+//
+//	Decimal - the rounded quotient.
+//	error - an error if a value is not a number, the divisor is zero, or the scale is out of range.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decDiv].
+//
+// [Sprout Documentation: decDiv]: https://docs.atom.codes/sprout/registries/decimal#decdiv
+func (dr *DecimalRegistry) DecDiv(scale int, dividend, divisor any) (Decimal, error) {
+	a, err := toDecimal(dividend)
+	if err != nil {
+		return Decimal{}, err
+	}
+	b, err := toDecimal(divisor)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return quo(a, b, scale, RoundHalfUp)
+}
+
+// DecRound rounds a number at the given scale, half up by default. The
+// rounding mode can be given before the number: "halfUp", "halfDown",
+// "halfEven" (or "bankers"), "up", "down", "ceiling" or "floor". A negative
+// scale rounds to a power of ten, and a scale larger than the one of the
+// number pads it with zeros.
+//
+// Parameters:
+//
+//	scale int - the number of decimals to keep.
+//	args ...any - the number to round, optionally preceded by the rounding mode.
+//
+// Returns:
+//
+//	Decimal - the rounded number.
+//	error - an error if the arguments are invalid, the scale is out of range, or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decRound].
+//
+// [Sprout Documentation: decRound]: https://docs.atom.codes/sprout/registries/decimal#decround
+func (dr *DecimalRegistry) DecRound(scale int, args ...any) (Decimal, error) {
+	mode := RoundHalfUp
+	switch len(args) {
+	case 1:
+	case 2:
+		name, ok := args[0].(string)
+		if !ok {
+			return Decimal{}, fmt.Errorf("rounding mode must be a string, got %T", args[0])
+		}
+		var err error
+		if mode, err = toRoundingMode(name); err != nil {
+			return Decimal{}, err
+		}
+	default:
+		return Decimal{}, fmt.Errorf("expected a number, optionally preceded by a rounding mode, got %d arguments", len(args))
+	}
+
+	d, err := toDecimal(args[len(args)-1])
+	if err != nil {
+		return Decimal{}, err
+	}
+	return rescale(d, scale, mode)
+}
+
+// DecCmp compares two numbers exactly, whatever their scales: 1.5 and 1.50
+// are equal.
+//
+// Parameters:
+//
+//	a any - the first number.
+//	b any - the second number.
+//
+// Returns:
+//
+//	int - -1 if a < b, 0 if a == b, and 1 if a > b.
+//	error - an error if a value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decCmp].
+//
+// [Sprout Documentation: decCmp]: https://docs.atom.codes/sprout/registries/decimal#deccmp
+func (dr *DecimalRegistry) DecCmp(a, b any) (int, error) {
+	x, err := toDecimal(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toDecimal(b)
+	if err != nil {
+		return 0, err
+	}
+	return cmp(x, y), nil
+}
+
+// DecFormat formats a number in plain notation with exactly the given number
+// of decimals, rounding it half up or padding it with zeros.
+//
+// Parameters:
+//
+//	scale int - the number of decimals, at least 0.
+//	value any - the number to format.
+//
+// Returns:
+//
+//	string - the formatted number.
+//	error - an error if the scale is negative or out of range, or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: decFormat].
+//
+// [Sprout Documentation: decFormat]: https://docs.atom.codes/sprout/registries/decimal#decformat
+func (dr *DecimalRegistry) DecFormat(scale int, value any) (string, error) {
+	if scale < 0 {
+		return "", fmt.Errorf("scale must not be negative, got %d", scale)
+	}
+	d, err := toDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	result, err := rescale(d, scale, RoundHalfUp)
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
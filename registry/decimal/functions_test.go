@@ -0,0 +1,126 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/decimal"
+)
+
+func TestDecimal(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestString", Input: `{{ decimal "12.30" }}`, ExpectedOutput: "12.30"},
+		{Name: "TestNegative", Input: `{{ decimal "-0.05" }}`, ExpectedOutput: "-0.05"},
+		{Name: "TestExponent", Input: `{{ decimal "1.5e3" }}`, ExpectedOutput: "1500"},
+		{Name: "TestNegativeExponent", Input: `{{ decimal "15e-3" }}`, ExpectedOutput: "0.015"},
+		{Name: "TestInt", Input: `{{ decimal 42 }}`, ExpectedOutput: "42"},
+		{Name: "TestFloat", Input: `{{ decimal 0.1 }}`, ExpectedOutput: "0.1"},
+		{Name: "TestJSONNumber", Input: `{{ decimal .V }}`, Data: map[string]any{"V": json.Number("19.99")}, ExpectedOutput: "19.99"},
+		{Name: "TestDecimal", Input: `{{ decimal "1.50" | decimal }}`, ExpectedOutput: "1.50"},
+		{Name: "TestInvalid", Input: `{{ decimal "12,30" }}`, ExpectedErr: "failed to convert: 12,30 to decimal: invalid decimal \"12,30\""},
+		{Name: "TestInvalidExponent", Input: `{{ decimal "1e" }}`, ExpectedErr: "invalid decimal \"1e\""},
+		{Name: "TestExponentOutOfRange", Input: `{{ decimal "1e999999999" }}`, ExpectedErr: "exponent of decimal \"1e999999999\" out of range, expected between -10000 and 10000"},
+		{Name: "TestUnsupported", Input: `{{ decimal true }}`, ExpectedErr: "unsupported type bool"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecAdd(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestExact", Input: `{{ decAdd "0.1" "0.2" }}`, ExpectedOutput: "0.3"},
+		{Name: "TestLargestScale", Input: `{{ decAdd "12.30" 1 "0.005" }}`, ExpectedOutput: "13.305"},
+		{Name: "TestFloats", Input: `{{ decAdd 0.1 0.2 }}`, ExpectedOutput: "0.3"},
+		{Name: "TestEmpty", Input: `{{ decAdd }}`, ExpectedOutput: "0"},
+		{Name: "TestInvalid", Input: `{{ decAdd 1 "one" }}`, ExpectedErr: "invalid decimal \"one\""},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecSub(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestExact", Input: `{{ decSub "1.00" "0.99" }}`, ExpectedOutput: "0.01"},
+		{Name: "TestMany", Input: `{{ decSub 10 "2.5" "0.25" }}`, ExpectedOutput: "7.25"},
+		{Name: "TestNegative", Input: `{{ decSub "0.1" "0.3" }}`, ExpectedOutput: "-0.2"},
+		{Name: "TestEmpty", Input: `{{ decSub }}`, ExpectedOutput: "0"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecMul(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestScale", Input: `{{ decMul "1.10" "1.10" }}`, ExpectedOutput: "1.2100"},
+		{Name: "TestQuantity", Input: `{{ decMul "19.99" 3 }}`, ExpectedOutput: "59.97"},
+		{Name: "TestLarge", Input: `{{ decMul "99999999999999999999" "99999999999999999999" }}`, ExpectedOutput: "9999999999999999999800000000000000000001"},
+		{Name: "TestEmpty", Input: `{{ decMul }}`, ExpectedOutput: "1"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecDiv(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestRounded", Input: `{{ decDiv 2 10 3 }}`, ExpectedOutput: "3.33"},
+		{Name: "TestHalfUp", Input: `{{ decDiv 2 "0.125" 1 }}`, ExpectedOutput: "0.13"},
+		{Name: "TestNegative", Input: `{{ decDiv 2 -2 3 }}`, ExpectedOutput: "-0.67"},
+		{Name: "TestPadded", Input: `{{ decDiv 3 "7.5" "2.5" }}`, ExpectedOutput: "3.000"},
+		{Name: "TestZeroScale", Input: `{{ decDiv 0 "100.00" 8 }}`, ExpectedOutput: "13"},
+		{Name: "TestByZero", Input: `{{ decDiv 2 1 "0.00" }}`, ExpectedErr: "cannot divide by zero"},
+		{Name: "TestScaleOutOfRange", Input: `{{ decDiv 1000000000 1 3 }}`, ExpectedErr: "scale 1000000000 out of range, expected between -10000 and 10000"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecRound(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestDefault", Input: `{{ decRound 2 "2.345" }}`, ExpectedOutput: "2.35"},
+		{Name: "TestHalfUpNegative", Input: `{{ decRound 2 "-2.345" }}`, ExpectedOutput: "-2.35"},
+		{Name: "TestHalfEven", Input: `{{ decRound 2 "halfEven" "2.345" }}`, ExpectedOutput: "2.34"},
+		{Name: "TestHalfEvenOdd", Input: `{{ decRound 2 "halfEven" "2.355" }}`, ExpectedOutput: "2.36"},
+		{Name: "TestBankers", Input: `{{ decRound 0 "bankers" "2.5" }}`, ExpectedOutput: "2"},
+		{Name: "TestHalfDown", Input: `{{ decRound 2 "halfDown" "2.345" }}`, ExpectedOutput: "2.34"},
+		{Name: "TestHalfDownAbove", Input: `{{ decRound 2 "halfDown" "2.3451" }}`, ExpectedOutput: "2.35"},
+		{Name: "TestUp", Input: `{{ decRound 1 "up" "-2.01" }}`, ExpectedOutput: "-2.1"},
+		{Name: "TestDown", Input: `{{ decRound 1 "down" "-2.09" }}`, ExpectedOutput: "-2.0"},
+		{Name: "TestCeiling", Input: `{{ decRound 1 "ceiling" "-2.09" }}`, ExpectedOutput: "-2.0"},
+		{Name: "TestFloor", Input: `{{ decRound 1 "floor" "2.01" }}`, ExpectedOutput: "2.0"},
+		{Name: "TestPadded", Input: `{{ decRound 4 "1.5" }}`, ExpectedOutput: "1.5000"},
+		{Name: "TestNegativeScale", Input: `{{ decRound -2 "1250" }}`, ExpectedOutput: "1300"},
+		{Name: "TestPipeline", Input: `{{ decMul "1.10" "1.15" | decRound 2 }}`, ExpectedOutput: "1.27"},
+		{Name: "TestUnknownMode", Input: `{{ decRound 2 "nearest" "1.5" }}`, ExpectedErr: "unknown rounding mode \"nearest\""},
+		{Name: "TestModeType", Input: `{{ decRound 2 1 "1.5" }}`, ExpectedErr: "rounding mode must be a string, got int"},
+		{Name: "TestMissingValue", Input: `{{ decRound 2 }}`, ExpectedErr: "expected a number, optionally preceded by a rounding mode, got 0 arguments"},
+		{Name: "TestScaleOutOfRange", Input: `{{ decRound -10001 "1" }}`, ExpectedErr: "scale -10001 out of range, expected between -10000 and 10000"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecCmp(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEqualScales", Input: `{{ decCmp "1.5" "1.50" }}`, ExpectedOutput: "0"},
+		{Name: "TestLower", Input: `{{ decCmp "0.1" 0.2 }}`, ExpectedOutput: "-1"},
+		{Name: "TestGreater", Input: `{{ decCmp 10 "9.999" }}`, ExpectedOutput: "1"},
+		{Name: "TestNegative", Input: `{{ decCmp "-1" "-2" }}`, ExpectedOutput: "1"},
+		{Name: "TestInvalid", Input: `{{ decCmp "a" 1 }}`, ExpectedErr: "invalid decimal \"a\""},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
+
+func TestDecFormat(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestPadded", Input: `{{ decFormat 2 "12.3" }}`, ExpectedOutput: "12.30"},
+		{Name: "TestRounded", Input: `{{ decFormat 2 "0.005" }}`, ExpectedOutput: "0.01"},
+		{Name: "TestInteger", Input: `{{ decFormat 0 "99.5" }}`, ExpectedOutput: "100"},
+		{Name: "TestSmall", Input: `{{ decFormat 3 "-0.0004" }}`, ExpectedOutput: "0.000"},
+		{Name: "TestNegativeScale", Input: `{{ decFormat -1 1 }}`, ExpectedErr: "scale must not be negative, got -1"},
+		{Name: "TestScaleOutOfRange", Input: `{{ decFormat 10001 1 }}`, ExpectedErr: "scale 10001 out of range, expected between -10000 and 10000"},
+	}
+
+	pesticide.RunTestCases(t, decimal.NewRegistry(), tc)
+}
@@ -0,0 +1,326 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-sprout/sprout"
+)
+
+// Decimal is an exact decimal number, made of an arbitrary-precision integer
+// and a scale, the number of digits after the decimal point: 12.30 is 1230
+// with a scale of 2. The scale is kept by the operations, so trailing zeros
+// are significant like on an invoice. The zero value is 0.
+//
+// Decimal values are immutable, and are printed in plain notation by
+// templates. They implement Float64, so the functions of the numeric registry
+// accept them like any other number.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// RoundingMode selects how a decimal is rounded when digits are dropped.
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds to the nearest neighbor, ties away from zero.
+	RoundHalfUp RoundingMode = "halfUp"
+	// RoundHalfDown rounds to the nearest neighbor, ties towards zero.
+	RoundHalfDown RoundingMode = "halfDown"
+	// RoundHalfEven rounds to the nearest neighbor, ties to the even
+	// neighbor, also known as banker's rounding.
+	RoundHalfEven RoundingMode = "halfEven"
+	// RoundUp rounds away from zero.
+	RoundUp RoundingMode = "up"
+	// RoundDown rounds towards zero, truncating the dropped digits.
+	RoundDown RoundingMode = "down"
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling RoundingMode = "ceiling"
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor RoundingMode = "floor"
+)
+
+// maxScale bounds the exponents of parsed decimals and the scales of the
+// divisions and roundings, so a short input like "1e999999999" cannot make
+// the arbitrary-precision integers grow without limit.
+const maxScale = 10000
+
+// errDivisionByZero is returned when dividing by a zero decimal.
+var errDivisionByZero = errors.New("cannot divide by zero")
+
+// String returns the decimal in plain notation, with exactly scale digits
+// after the decimal point.
+func (d Decimal) String() string {
+	digits := d.int().String()
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 returns the nearest float64 value of the decimal. The error is
+// always nil, it is there to be recognized as a number by conversion
+// functions.
+func (d Decimal) Float64() (float64, error) {
+	value, _ := new(big.Rat).SetFrac(d.int(), pow10(d.scale)).Float64()
+	return value, nil
+}
+
+// MarshalJSON encodes the decimal as a JSON number, without loss of precision.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// int returns the unscaled integer of the decimal, 0 for the zero value.
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// pow10 returns 10 to the power of n, n being non-negative.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// newDecimal returns the decimal unscaled × 10^-scale, normalizing a negative
+// scale to 0 so the scale of a decimal is never negative.
+func newDecimal(unscaled *big.Int, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(unscaled, pow10(-scale))}
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// parseDecimal parses a decimal number in plain or scientific notation, like
+// "12.30", "-0.5" or "1.5e3". The scale is the number of digits written after
+// the decimal point, minus the exponent, which is at most maxScale in
+// absolute value.
+func parseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if exp < -maxScale || exp > maxScale {
+			return Decimal{}, fmt.Errorf("exponent of decimal %q out of range, expected between -%d and %d", s, maxScale, maxScale)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	digits := integer + fraction
+	if digits == "" || strings.TrimFunc(digits, isDigit) != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	return newDecimal(unscaled, len(fraction)-exponent), nil
+}
+
+// isDigit reports whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// toDecimal converts a value to a decimal. Decimals, numeric strings,
+// json.Number, integers and floats are accepted; floats are converted from
+// their shortest representation, so 0.1 is exactly 0.1.
+//
+// Parameters:
+//
+//	value any - the value to convert.
+//
+// Returns:
+//
+//	Decimal - the converted decimal.
+//	error - an error if the value is not a number.
+func toDecimal(value any) (Decimal, error) {
+	switch v := value.(type) {
+	case Decimal:
+		return v, nil
+	case *Decimal:
+		if v != nil {
+			return *v, nil
+		}
+	case *big.Int:
+		if v != nil {
+			return Decimal{unscaled: new(big.Int).Set(v)}, nil
+		}
+	case string:
+		d, err := parseDecimal(v)
+		if err != nil {
+			return Decimal{}, sprout.NewErrConvertFailed("decimal", value, err)
+		}
+		return d, nil
+	case json.Number:
+		return toDecimal(string(v))
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Decimal{unscaled: big.NewInt(rv.Int())}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Decimal{unscaled: new(big.Int).SetUint64(rv.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return Decimal{}, sprout.NewErrConvertFailed("decimal", value, errors.New("not a finite number"))
+		}
+		bits := 64
+		if rv.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return parseDecimal(strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return Decimal{}, sprout.NewErrConvertFailed("decimal", value, fmt.Errorf("unsupported type %T", value))
+}
+
+// toDecimals converts a list of values with toDecimal.
+func toDecimals(values []any) ([]Decimal, error) {
+	decimals := make([]Decimal, len(values))
+	for i, value := range values {
+		d, err := toDecimal(value)
+		if err != nil {
+			return nil, err
+		}
+		decimals[i] = d
+	}
+	return decimals, nil
+}
+
+// toRoundingMode validates the name of a rounding mode, "bankers" being
+// accepted for RoundHalfEven.
+func toRoundingMode(mode string) (RoundingMode, error) {
+	switch RoundingMode(mode) {
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundUp, RoundDown, RoundCeiling, RoundFloor:
+		return RoundingMode(mode), nil
+	case "bankers":
+		return RoundHalfEven, nil
+	}
+	return "", fmt.Errorf("unknown rounding mode %q", mode)
+}
+
+// aligned returns the unscaled integers of a and b at the largest of their
+// scales, and that scale.
+func aligned(a, b Decimal) (*big.Int, *big.Int, int) {
+	x, y := a.int(), b.int()
+	switch {
+	case a.scale < b.scale:
+		x = new(big.Int).Mul(x, pow10(b.scale-a.scale))
+	case a.scale > b.scale:
+		y = new(big.Int).Mul(y, pow10(a.scale-b.scale))
+	}
+	return x, y, max(a.scale, b.scale)
+}
+
+// add returns a + b, at the largest of their scales.
+func add(a, b Decimal) Decimal {
+	x, y, scale := aligned(a, b)
+	return Decimal{unscaled: new(big.Int).Add(x, y), scale: scale}
+}
+
+// sub returns a - b, at the largest of their scales.
+func sub(a, b Decimal) Decimal {
+	x, y, scale := aligned(a, b)
+	return Decimal{unscaled: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// mul returns a × b, at the sum of their scales.
+func mul(a, b Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(a.int(), b.int()), scale: a.scale + b.scale}
+}
+
+// cmp compares a and b, returning -1, 0 or +1.
+func cmp(a, b Decimal) int {
+	x, y, _ := aligned(a, b)
+	return x.Cmp(y)
+}
+
+// quo returns a / b rounded at the given scale with the given mode. A
+// negative scale rounds to a power of ten, quo(1234, 1, -2) being 1200. The
+// scale is at most maxScale in absolute value.
+func quo(a, b Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if scale < -maxScale || scale > maxScale {
+		return Decimal{}, fmt.Errorf("scale %d out of range, expected between -%d and %d", scale, maxScale, maxScale)
+	}
+	if b.int().Sign() == 0 {
+		return Decimal{}, errDivisionByZero
+	}
+
+	// a / b × 10^scale = a.unscaled × 10^(b.scale + scale - a.scale) / b.unscaled
+	num, den := new(big.Int).Set(a.int()), new(big.Int).Set(b.int())
+	if exp := b.scale + scale - a.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return newDecimal(roundQuotient(num, den, mode), scale), nil
+}
+
+// rescale returns d rounded at the given scale with the given mode, or padded
+// with zeros when the scale is larger than the one of d. The scale is bounded
+// like the one of quo.
+func rescale(d Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	return quo(d, Decimal{unscaled: big.NewInt(1)}, scale, mode)
+}
+
+// roundQuotient returns num / den rounded to an integer with the given mode.
+func roundQuotient(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// The sign of the exact quotient, and how the remainder compares to the
+	// half of the divisor.
+	sign := num.Sign() * den.Sign()
+	twice := new(big.Int).Abs(r)
+	half := twice.Lsh(twice, 1).Cmp(new(big.Int).Abs(den))
+
+	var awayFromZero bool
+	switch mode {
+	case RoundUp:
+		awayFromZero = true
+	case RoundDown:
+		awayFromZero = false
+	case RoundCeiling:
+		awayFromZero = sign > 0
+	case RoundFloor:
+		awayFromZero = sign < 0
+	case RoundHalfDown:
+		awayFromZero = half > 0
+	case RoundHalfEven:
+		awayFromZero = half > 0 || (half == 0 && q.Bit(0) == 1)
+	default:
+		awayFromZero = half >= 0
+	}
+
+	if awayFromZero {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
@@ -0,0 +1,146 @@
+package decimal
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		unscaled string
+		scale    int
+	}{
+		{"12.30", "1230", 2},
+		{"-0.05", "-5", 2},
+		{"+7", "7", 0},
+		{".5", "5", 1},
+		{"5.", "5", 0},
+		{" 1.0 ", "10", 1},
+		{"1.5e3", "1500", 0},
+		{"1.5E-3", "15", 4},
+	}
+
+	for _, test := range tests {
+		d, err := parseDecimal(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.unscaled, d.int().String(), test.input)
+		assert.Equal(t, test.scale, d.scale, test.input)
+	}
+
+	for _, input := range []string{"", "-", ".", "1.2.3", "1a", "1,5", "NaN", "1e1.5"} {
+		_, err := parseDecimal(input)
+		assert.ErrorContains(t, err, "invalid decimal", input)
+	}
+
+	d, err := parseDecimal("1e-10000")
+	require.NoError(t, err)
+	assert.Equal(t, maxScale, d.scale)
+	for _, input := range []string{"1e10001", "1e-10001", "1e999999999", "1e99999999999999999999"} {
+		_, err := parseDecimal(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, "0.001", Decimal{unscaled: big.NewInt(1), scale: 3}.String())
+	assert.Equal(t, "-0.010", Decimal{unscaled: big.NewInt(-10), scale: 3}.String())
+	assert.Equal(t, "123.4", Decimal{unscaled: big.NewInt(1234), scale: 1}.String())
+}
+
+func TestDecimalFloat64(t *testing.T) {
+	value, err := Decimal{unscaled: big.NewInt(1230), scale: 2}.Float64()
+	require.NoError(t, err)
+	assert.InDelta(t, 12.3, value, 1e-12)
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	d, err := parseDecimal("12.30")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]any{"total": d})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 12.30}`, string(data))
+}
+
+func TestToDecimal(t *testing.T) {
+	for _, value := range []any{int8(-3), uint64(18446744073709551615), float32(0.1), 1e21, big.NewInt(5), json.Number("1.5")} {
+		_, err := toDecimal(value)
+		require.NoError(t, err, value)
+	}
+
+	d, err := toDecimal(float32(0.1))
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", d.String())
+
+	d, err = toDecimal(uint64(18446744073709551615))
+	require.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", d.String())
+
+	_, err = toDecimal(nil)
+	require.ErrorContains(t, err, "unsupported type <nil>")
+
+	_, err = toDecimal((*Decimal)(nil))
+	require.ErrorContains(t, err, "unsupported type *decimal.Decimal")
+}
+
+func TestRoundQuotient(t *testing.T) {
+	tests := []struct {
+		mode     RoundingMode
+		expected []int64 // for -2.5, -1.5, -1.2, 1.2, 1.5, 2.5
+	}{
+		{RoundHalfUp, []int64{-3, -2, -1, 1, 2, 3}},
+		{RoundHalfDown, []int64{-2, -1, -1, 1, 1, 2}},
+		{RoundHalfEven, []int64{-2, -2, -1, 1, 2, 2}},
+		{RoundUp, []int64{-3, -2, -2, 2, 2, 3}},
+		{RoundDown, []int64{-2, -1, -1, 1, 1, 2}},
+		{RoundCeiling, []int64{-2, -1, -1, 2, 2, 3}},
+		{RoundFloor, []int64{-3, -2, -2, 1, 1, 2}},
+	}
+
+	for _, test := range tests {
+		for i, num := range []int64{-25, -15, -12, 12, 15, 25} {
+			q := roundQuotient(big.NewInt(num), big.NewInt(10), test.mode)
+			assert.Equal(t, test.expected[i], q.Int64(), "%s(%d/10)", test.mode, num)
+		}
+	}
+}
+
+func TestQuo(t *testing.T) {
+	a, _ := parseDecimal("1234")
+	b, _ := parseDecimal("0.5")
+
+	d, err := quo(a, b, -2, RoundHalfUp)
+	require.NoError(t, err)
+	assert.Equal(t, "2500", d.String())
+
+	d, err = quo(b, a, 6, RoundHalfUp)
+	require.NoError(t, err)
+	assert.Equal(t, "0.000405", d.String())
+
+	_, err = quo(a, Decimal{}, 2, RoundHalfUp)
+	require.ErrorIs(t, err, errDivisionByZero)
+
+	_, err = quo(a, b, maxScale+1, RoundHalfUp)
+	require.ErrorContains(t, err, "scale 10001 out of range")
+	_, err = quo(a, b, -maxScale-1, RoundHalfUp)
+	require.ErrorContains(t, err, "scale -10001 out of range")
+}
+
+func TestOperationsDoNotMutate(t *testing.T) {
+	a, _ := parseDecimal("1.5")
+	b, _ := parseDecimal("2.25")
+
+	_ = add(a, b)
+	_ = sub(a, b)
+	_ = mul(a, b)
+	_, _ = rescale(a, 0, RoundUp)
+
+	assert.Equal(t, "1.5", a.String())
+	assert.Equal(t, "2.25", b.String())
+}
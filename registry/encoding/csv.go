@@ -0,0 +1,299 @@
+package encoding
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// csvOptions are the options of the CSV decoder and encoder.
+type csvOptions struct {
+	delimiter        rune
+	comment          rune
+	header           bool
+	columns          []string
+	lazyQuotes       bool
+	trimLeadingSpace bool
+	crlf             bool
+}
+
+// defaultCSVOptions returns the default options, using the given delimiter.
+func defaultCSVOptions(delimiter rune) csvOptions {
+	return csvOptions{delimiter: delimiter, header: true}
+}
+
+// parseCSVOptions reads the options of the CSV decoder and encoder from a map,
+// on top of the given defaults.
+func parseCSVOptions(options map[string]any, opts csvOptions) (csvOptions, error) {
+	for key, value := range options {
+		var err error
+		switch key {
+		case "delimiter":
+			opts.delimiter, err = csvRuneOption(key, value)
+		case "comment":
+			opts.comment, err = csvRuneOption(key, value)
+		case "header":
+			opts.header, err = csvBoolOption(key, value)
+		case "lazyQuotes":
+			opts.lazyQuotes, err = csvBoolOption(key, value)
+		case "trimLeadingSpace":
+			opts.trimLeadingSpace, err = csvBoolOption(key, value)
+		case "crlf":
+			opts.crlf, err = csvBoolOption(key, value)
+		case "columns":
+			opts.columns, err = csvColumnsOption(key, value)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// csvRuneOption reads an option holding a single character.
+func csvRuneOption(key string, value any) (rune, error) {
+	s, ok := value.(string)
+	if !ok || utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("option %q must be a single character, got %v", key, value)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+// csvBoolOption reads a boolean option.
+func csvBoolOption(key string, value any) (bool, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %q must be a bool, got %T", key, value)
+	}
+	return b, nil
+}
+
+// csvColumnsOption reads an option holding a list of column names.
+func csvColumnsOption(key string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []any:
+		columns := make([]string, len(v))
+		for i, column := range v {
+			s, ok := column.(string)
+			if !ok {
+				return nil, fmt.Errorf("option %q must be a list of strings, got %T item", key, column)
+			}
+			columns[i] = s
+		}
+		return columns, nil
+	default:
+		return nil, fmt.Errorf("option %q must be a list of strings, got %T", key, value)
+	}
+}
+
+// decodeCSV reads the records of a CSV document one at a time. With a header
+// row or the columns option, records are returned as maps keyed by column
+// name, otherwise as lists of fields.
+func decodeCSV(r io.Reader, opts csvOptions) ([]any, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.delimiter
+	reader.Comment = opts.comment
+	reader.LazyQuotes = opts.lazyQuotes
+	reader.TrimLeadingSpace = opts.trimLeadingSpace
+	reader.ReuseRecord = true
+	if opts.columns != nil {
+		// The number of fields is checked against the columns option
+		reader.FieldsPerRecord = -1
+	}
+
+	columns := opts.columns
+	if opts.header {
+		header, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return []any{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if columns == nil {
+			columns = slices.Clone(header)
+		}
+	}
+	for i, column := range columns {
+		if slices.Contains(columns[:i], column) {
+			return nil, fmt.Errorf("duplicate column %q", column)
+		}
+	}
+
+	records := []any{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if columns == nil {
+			fields := make([]any, len(record))
+			for i, field := range record {
+				fields[i] = field
+			}
+			records = append(records, fields)
+			continue
+		}
+
+		if len(record) != len(columns) {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("record on line %d: expected %d fields, got %d", line, len(columns), len(record))
+		}
+		m := make(map[string]any, len(columns))
+		for i, column := range columns {
+			m[column] = record[i]
+		}
+		records = append(records, m)
+	}
+}
+
+// encodeCSV writes a list of maps or a list of lists as a CSV document, one
+// record at a time. The columns of maps are the sorted union of their keys,
+// unless the columns option sets their order. A header row is written when
+// the columns are known, unless the header option is disabled. The line
+// terminator of the last record is removed, as for the other encoders.
+func encodeCSV(value any, opts csvOptions) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "csv")
+	if err != nil {
+		return "", err
+	}
+	rows, ok := normalized.([]any)
+	if !ok && normalized != nil {
+		return "", errors.New("value must be a list of maps or a list of lists")
+	}
+
+	columns := opts.columns
+	if columns == nil {
+		columns = csvColumns(rows)
+	}
+
+	var sb strings.Builder
+	writer := csv.NewWriter(&sb)
+	writer.Comma = opts.delimiter
+	writer.UseCRLF = opts.crlf
+
+	if opts.header && len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return "", err
+		}
+	}
+
+	record := make([]string, 0, len(columns))
+	for i, row := range rows {
+		record = record[:0]
+		switch r := row.(type) {
+		case *orderedMap:
+			if len(columns) == 0 {
+				continue
+			}
+			for _, column := range columns {
+				field, err := csvField(r.values[column])
+				if err != nil {
+					return "", fmt.Errorf("row %d, column %q: %w", i, column, err)
+				}
+				record = append(record, field)
+			}
+		case []any:
+			for j, item := range r {
+				field, err := csvField(item)
+				if err != nil {
+					return "", fmt.Errorf("row %d, column %d: %w", i, j, err)
+				}
+				record = append(record, field)
+			}
+		default:
+			return "", fmt.Errorf("row %d must be a map or a list, got %T", i, row)
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	out := strings.TrimSuffix(sb.String(), "\n")
+	return strings.TrimSuffix(out, "\r"), nil
+}
+
+// csvColumns returns the sorted union of the keys of the maps of the list.
+func csvColumns(rows []any) []string {
+	var columns []string
+	seen := make(map[string]struct{})
+	for _, row := range rows {
+		if m, ok := row.(*orderedMap); ok {
+			for _, key := range m.keys {
+				if _, ok := seen[key]; !ok {
+					seen[key] = struct{}{}
+					columns = append(columns, key)
+				}
+			}
+		}
+	}
+	slices.Sort(columns)
+	return columns
+}
+
+// csvField formats a value as a CSV field. Nil values are written as empty
+// fields.
+func csvField(value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	return formatScalar(value)
+}
+
+// fromDelimited decodes the document given as the last argument, using the
+// options given before it on top of the default delimiter.
+func fromDelimited(args []any, delimiter rune) ([]any, error) {
+	options, value, err := splitOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := parseCSVOptions(options, defaultCSVOptions(delimiter))
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return decodeCSV(strings.NewReader(v), opts)
+	case io.Reader:
+		return decodeCSV(v, opts)
+	default:
+		return nil, fmt.Errorf("document must be a string or an io.Reader, got %T", value)
+	}
+}
+
+// toDelimited encodes the value given as the last argument, using the options
+// given before it on top of the default delimiter.
+func toDelimited(args []any, delimiter rune) (string, error) {
+	options, value, err := splitOptions(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := parseCSVOptions(options, defaultCSVOptions(delimiter))
+	if err != nil {
+		return "", err
+	}
+
+	return encodeCSV(value, opts)
+}
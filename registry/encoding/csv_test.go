@@ -0,0 +1,169 @@
+package encoding
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	input := "name,comment\nalice,\"Hello, \"\"world\"\"\"\nbob,\"multi\nline\"\n"
+
+	records, err := decodeCSV(strings.NewReader(input), defaultCSVOptions(','))
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "alice", "comment": `Hello, "world"`},
+		map[string]any{"name": "bob", "comment": "multi\nline"},
+	}, records)
+}
+
+func TestDecodeCSVOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     csvOptions
+		expected []any
+	}{
+		{"Empty", "", defaultCSVOptions(','), []any{}},
+		{"HeaderOnly", "a,b\n", defaultCSVOptions(','), []any{}},
+		{"NoHeader", "1,2\n3,4", csvOptions{delimiter: ','}, []any{[]any{"1", "2"}, []any{"3", "4"}}},
+		{"Columns", "1,2\n3,4", csvOptions{delimiter: ',', columns: []string{"x", "y"}}, []any{
+			map[string]any{"x": "1", "y": "2"},
+			map[string]any{"x": "3", "y": "4"},
+		}},
+		{"ColumnsReplaceHeader", "a,b,c\n1,2", csvOptions{delimiter: ',', header: true, columns: []string{"x", "y"}}, []any{
+			map[string]any{"x": "1", "y": "2"},
+		}},
+		{"Semicolon", "a;b\n1;2", defaultCSVOptions(';'), []any{map[string]any{"a": "1", "b": "2"}}},
+		{"Tab", "a\tb\n1\t2", defaultCSVOptions('\t'), []any{map[string]any{"a": "1", "b": "2"}}},
+		{"Comment", "a\n# skipped\n1", csvOptions{delimiter: ',', header: true, comment: '#'}, []any{map[string]any{"a": "1"}}},
+		{"LazyQuotes", `a` + "\n" + `x "y" z`, csvOptions{delimiter: ',', header: true, lazyQuotes: true}, []any{map[string]any{"a": `x "y" z`}}},
+		{"TrimLeadingSpace", "a, b\n1,  2", csvOptions{delimiter: ',', header: true, trimLeadingSpace: true}, []any{map[string]any{"a": "1", "b": "2"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := decodeCSV(strings.NewReader(test.input), test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, records)
+		})
+	}
+}
+
+func TestDecodeCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  csvOptions
+		err   string
+	}{
+		{"WrongNumberOfFields", "a,b\n1", defaultCSVOptions(','), "wrong number of fields"},
+		{"WrongNumberOfColumns", "1,2\n3", csvOptions{delimiter: ',', columns: []string{"x", "y"}}, "record on line 2: expected 2 fields, got 1"},
+		{"DuplicateColumn", "a,a\n1,2", defaultCSVOptions(','), `duplicate column "a"`},
+		{"BareQuote", "a\nx \"y\"", defaultCSVOptions(','), `bare " in non-quoted-field`},
+		{"UnterminatedQuote", "a\n\"x", defaultCSVOptions(','), `extraneous or missing " in quoted-field`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeCSV(strings.NewReader(test.input), test.opts)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestEncodeCSV(t *testing.T) {
+	type user struct {
+		Name    string    `csv:"name"`
+		Created time.Time `csv:"created"`
+		Secret  string    `csv:"-"`
+	}
+
+	tests := []struct {
+		name     string
+		value    any
+		opts     csvOptions
+		expected string
+	}{
+		{"Maps", []map[string]any{{"name": "alice", "age": 30}, {"name": "bob", "admin": true}}, defaultCSVOptions(','), "admin,age,name\n,30,alice\ntrue,,bob"},
+		{"Columns", []map[string]any{{"name": "alice", "age": 30}}, csvOptions{delimiter: ',', header: true, columns: []string{"name", "age"}}, "name,age\nalice,30"},
+		{"NoHeader", []map[string]any{{"name": "alice", "age": 30}}, csvOptions{delimiter: ','}, "30,alice"},
+		{"Lists", [][]any{{"a", 1.5}, {"b", nil}}, defaultCSVOptions(','), "a,1.5\nb,"},
+		{"ListsWithColumns", [][]any{{"a", 1}}, csvOptions{delimiter: ',', header: true, columns: []string{"x", "y"}}, "x,y\na,1"},
+		{"Quoting", []any{[]any{"a,b", `say "hi"`, "multi\nline"}}, defaultCSVOptions(','), "\"a,b\",\"say \"\"hi\"\"\",\"multi\nline\""},
+		{"Tab", []map[string]any{{"a": "1", "b": "2"}}, defaultCSVOptions('\t'), "a\tb\n1\t2"},
+		{"CRLF", [][]string{{"a"}, {"b"}}, csvOptions{delimiter: ',', crlf: true}, "a\r\nb"},
+		{"Structs", []user{{Name: "alice", Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Secret: "x"}}, defaultCSVOptions(','), "created,name\n2024-01-02T03:04:05Z,alice"},
+		{"Empty", []any{}, defaultCSVOptions(','), ""},
+		{"Nil", nil, defaultCSVOptions(','), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := encodeCSV(test.value, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
+
+func TestEncodeCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		opts  csvOptions
+		err   string
+	}{
+		{"NotAList", map[string]any{"a": 1}, defaultCSVOptions(','), "value must be a list of maps or a list of lists"},
+		{"ScalarRow", []any{1}, defaultCSVOptions(','), "row 0 must be a map or a list, got int64"},
+		{"NestedMap", []any{map[string]any{"a": map[string]any{}}}, defaultCSVOptions(','), `row 0, column "a": cannot encode a value of type *encoding.orderedMap as text`},
+		{"NestedList", []any{[]any{[]any{1}}}, defaultCSVOptions(','), "row 0, column 0: cannot encode a value of type []interface {} as text"},
+		{"InvalidDelimiter", [][]string{{"a"}}, csvOptions{delimiter: '\n'}, "invalid field or comment delimiter"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encodeCSV(test.value, test.opts)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestParseCSVOptions(t *testing.T) {
+	opts, err := parseCSVOptions(map[string]any{
+		"delimiter":        ";",
+		"comment":          "#",
+		"header":           false,
+		"columns":          []any{"a", "b"},
+		"lazyQuotes":       true,
+		"trimLeadingSpace": true,
+		"crlf":             true,
+	}, defaultCSVOptions(','))
+	require.NoError(t, err)
+	assert.Equal(t, csvOptions{
+		delimiter:        ';',
+		comment:          '#',
+		columns:          []string{"a", "b"},
+		lazyQuotes:       true,
+		trimLeadingSpace: true,
+		crlf:             true,
+	}, opts)
+
+	opts, err = parseCSVOptions(nil, defaultCSVOptions('\t'))
+	require.NoError(t, err)
+	assert.Equal(t, csvOptions{delimiter: '\t', header: true}, opts)
+
+	_, err = parseCSVOptions(map[string]any{"delimiter": ";;"}, defaultCSVOptions(','))
+	require.ErrorContains(t, err, `option "delimiter" must be a single character`)
+	_, err = parseCSVOptions(map[string]any{"header": "yes"}, defaultCSVOptions(','))
+	require.ErrorContains(t, err, `option "header" must be a bool`)
+	_, err = parseCSVOptions(map[string]any{"columns": "a"}, defaultCSVOptions(','))
+	require.ErrorContains(t, err, `option "columns" must be a list of strings`)
+	_, err = parseCSVOptions(map[string]any{"columns": []any{1}}, defaultCSVOptions(','))
+	require.ErrorContains(t, err, `option "columns" must be a list of strings`)
+	_, err = parseCSVOptions(map[string]any{"unknown": 1}, defaultCSVOptions(','))
+	require.ErrorContains(t, err, `unknown option "unknown"`)
+}
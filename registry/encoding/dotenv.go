@@ -0,0 +1,191 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	dotenvKeyRegex       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	dotenvUnquotedRegex  = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+	dotenvDoubleEscaper  = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "$", `\$`)
+	errUnterminatedQuote = errors.New("unterminated quoted value")
+)
+
+// decodeDotenv parses a dotenv document. Lines hold `KEY=value` assignments,
+// optionally prefixed by `export`. Single-quoted values are literal,
+// double-quoted values support backslash escapes and can span several lines,
+// and unquoted values end at an inline comment starting with " #". Variables
+// are not expanded. All values are strings.
+func decodeDotenv(input string) (map[string]any, error) {
+	result := make(map[string]any)
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	line := 1
+
+	for len(input) > 0 {
+		var current string
+		current, input, _ = strings.Cut(input, "\n")
+		startLine := line
+		line++
+
+		current = strings.TrimSpace(current)
+		if current == "" || current[0] == '#' {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(current, "export "); ok {
+			current = strings.TrimLeft(rest, " \t")
+		}
+
+		key, raw, ok := strings.Cut(current, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected '=' after key", startLine)
+		}
+		key = strings.TrimSpace(key)
+		if !dotenvKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q", startLine, key)
+		}
+		raw = strings.TrimLeft(raw, " \t")
+
+		// Double-quoted values can span several lines
+		if strings.HasPrefix(raw, `"`) {
+			for !hasDotenvClosingQuote(raw) && len(input) > 0 {
+				var next string
+				next, input, _ = strings.Cut(input, "\n")
+				raw += "\n" + next
+				line++
+			}
+		}
+
+		value, err := parseDotenvValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", startLine, err)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// hasDotenvClosingQuote reports whether the double-quoted value has an
+// unescaped closing quote.
+func hasDotenvClosingQuote(raw string) bool {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return true
+		}
+	}
+	return false
+}
+
+// parseDotenvValue parses a single-quoted, double-quoted or unquoted value.
+func parseDotenvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	var value, rest string
+	switch raw[0] {
+	case '"':
+		var sb strings.Builder
+		i := 1
+		for ; i < len(raw) && raw[i] != '"'; i++ {
+			if raw[i] != '\\' || i+1 == len(raw) {
+				sb.WriteByte(raw[i])
+				continue
+			}
+			i++
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '$', '\'':
+				sb.WriteByte(raw[i])
+			default:
+				// Unknown escape sequences are kept as is
+				sb.WriteByte('\\')
+				sb.WriteByte(raw[i])
+			}
+		}
+		if i >= len(raw) {
+			return "", errUnterminatedQuote
+		}
+		value, rest = sb.String(), raw[i+1:]
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", errUnterminatedQuote
+		}
+		value, rest = raw[1:end+1], raw[end+2:]
+	default:
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = raw[:i]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest != "" && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected content %q after quoted value", rest)
+	}
+	return value, nil
+}
+
+// encodeDotenv writes a flat map as a dotenv document. Keys are sorted and
+// nil values are omitted. Values are written unquoted when they only hold
+// safe characters, single-quoted when they hold no single quote nor line
+// break, and double-quoted with escapes otherwise.
+func encodeDotenv(value any) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "env")
+	if err != nil {
+		return "", err
+	}
+
+	root, ok := normalized.(*orderedMap)
+	if !ok {
+		return "", fmt.Errorf("top-level value must be a map or a struct, got %T", value)
+	}
+
+	var sb strings.Builder
+	for _, key := range root.keys {
+		v := root.values[key]
+		if v == nil {
+			continue
+		}
+		if !dotenvKeyRegex.MatchString(key) {
+			return "", fmt.Errorf("invalid key %q", key)
+		}
+		switch v.(type) {
+		case *orderedMap, []any:
+			return "", fmt.Errorf("key %q: cannot encode a nested value in a dotenv document", key)
+		}
+
+		s, err := formatScalar(v)
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		sb.WriteString(key + "=" + quoteDotenvValue(s) + "\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// quoteDotenvValue quotes a value as needed to be read back as is.
+func quoteDotenvValue(s string) string {
+	switch {
+	case dotenvUnquotedRegex.MatchString(s):
+		return s
+	case !strings.ContainsAny(s, "'\r\n"):
+		return "'" + s + "'"
+	default:
+		return `"` + dotenvDoubleEscaper.Replace(s) + `"`
+	}
+}
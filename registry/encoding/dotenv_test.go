@@ -0,0 +1,118 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeDotenv(t *testing.T) {
+	doc := `# database settings
+DB_HOST=localhost
+export DB_PORT = 5432
+EMPTY=
+URL=http://example.com/#anchor # comment
+SINGLE='literal \n $HOME' # comment
+DOUBLE="line1\nline2 \"quoted\" \$HOME"
+MULTILINE="first
+second"
+UNKNOWN_ESCAPE="a\qb"
+app.name=sprout
+`
+
+	m, err := decodeDotenv(doc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"DB_HOST":        "localhost",
+		"DB_PORT":        "5432",
+		"EMPTY":          "",
+		"URL":            "http://example.com/#anchor",
+		"SINGLE":         `literal \n $HOME`,
+		"DOUBLE":         "line1\nline2 \"quoted\" $HOME",
+		"MULTILINE":      "first\nsecond",
+		"UNKNOWN_ESCAPE": `a\qb`,
+		"app.name":       "sprout",
+	}, m)
+}
+
+func TestDecodeDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"MissingEquals", "A=1\nB", "line 2: expected '=' after key"},
+		{"InvalidKey", "1A=1", `line 1: invalid key "1A"`},
+		{"UnterminatedDoubleQuote", "A=\"abc\nB=1", "line 1: unterminated quoted value"},
+		{"UnterminatedSingleQuote", "A='abc", "line 1: unterminated quoted value"},
+		{"TrailingContent", `A="abc" def`, `line 1: unexpected content "def" after quoted value`},
+		{"LineAfterMultiline", "A=\"a\nb\"\nC", "line 3: expected '=' after key"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeDotenv(test.input)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestEncodeDotenv(t *testing.T) {
+	type config struct {
+		Host  string `env:"DB_HOST"`
+		Port  int    `env:"DB_PORT"`
+		Debug bool   `env:"DEBUG,omitempty"`
+	}
+
+	out, err := encodeDotenv(config{Host: "localhost", Port: 5432})
+	require.NoError(t, err)
+	assert.Equal(t, "DB_HOST=localhost\nDB_PORT=5432", out)
+
+	value := map[string]any{
+		"PLAIN":    "postgres://user@host:5432/db?x=1",
+		"SPACES":   "hello world",
+		"DOLLAR":   "$HOME",
+		"QUOTE":    "it's",
+		"LINES":    "a\nb \"c\" $d \\",
+		"EMPTY":    "",
+		"NOTHING":  nil,
+		"app.name": "sprout",
+	}
+
+	out, err = encodeDotenv(value)
+	require.NoError(t, err)
+	assert.Equal(t, `DOLLAR='$HOME'
+EMPTY=
+LINES="a\nb \"c\" \$d \\"
+PLAIN='postgres://user@host:5432/db?x=1'
+QUOTE="it's"
+SPACES='hello world'
+app.name=sprout`, out)
+
+	decoded, err := decodeDotenv(out)
+	require.NoError(t, err)
+	delete(value, "NOTHING")
+	assert.Equal(t, value, decoded)
+}
+
+func TestEncodeDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{"NotAMap", "value", "top-level value must be a map or a struct"},
+		{"InvalidKey", map[string]any{"A B": 1}, `invalid key "A B"`},
+		{"NestedMap", map[string]any{"A": map[string]any{}}, `key "A": cannot encode a nested value in a dotenv document`},
+		{"List", map[string]any{"A": []int{1}}, `key "A": cannot encode a nested value in a dotenv document`},
+		{"InvalidValue", map[string]any{"A": make(chan int)}, "cannot encode a value of type chan int"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encodeDotenv(test.value)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -11,6 +11,10 @@ func NewRegistry() *EncodingRegistry {
 	return &EncodingRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (or *EncodingRegistry) UID() string {
 	return "go-sprout/sprout.encoding"
@@ -34,6 +38,22 @@ func (er *EncodingRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error
 	sprout.AddFunction(funcsMap, "fromYAML", er.FromYAML)
 	sprout.AddFunction(funcsMap, "toYAML", er.ToYAML)
 	sprout.AddFunction(funcsMap, "toIndentYAML", er.ToIndentYAML)
+	sprout.AddFunction(funcsMap, "fromTOML", er.FromTOML)
+	sprout.AddFunction(funcsMap, "toTOML", er.ToTOML)
+	sprout.AddFunction(funcsMap, "toPrettyTOML", er.ToPrettyTOML)
+	sprout.AddFunction(funcsMap, "fromXML", er.FromXML)
+	sprout.AddFunction(funcsMap, "toXML", er.ToXML)
+	sprout.AddFunction(funcsMap, "xpath", er.XPath)
+	sprout.AddFunction(funcsMap, "fromCSV", er.FromCSV)
+	sprout.AddFunction(funcsMap, "toCSV", er.ToCSV)
+	sprout.AddFunction(funcsMap, "fromTSV", er.FromTSV)
+	sprout.AddFunction(funcsMap, "toTSV", er.ToTSV)
+	sprout.AddFunction(funcsMap, "fromINI", er.FromINI)
+	sprout.AddFunction(funcsMap, "toINI", er.ToINI)
+	sprout.AddFunction(funcsMap, "fromProperties", er.FromProperties)
+	sprout.AddFunction(funcsMap, "toProperties", er.ToProperties)
+	sprout.AddFunction(funcsMap, "fromDotenv", er.FromDotenv)
+	sprout.AddFunction(funcsMap, "toDotenv", er.ToDotenv)
 	return nil
 }
 
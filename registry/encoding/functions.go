@@ -266,3 +266,461 @@ func (er *EncodingRegistry) ToIndentYAML(indent int, value any) (out string, err
 
 	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
+
+// FromTOML deserializes a TOML document into a Go map. Offset date-times are
+// decoded as time.Time values, local date-times, dates and times as time.Time
+// values in the TOMLLocalDateTime, TOMLLocalDate and TOMLLocalTime locations.
+//
+// Parameters:
+//
+//	value string - the TOML document to deserialize.
+//
+// Returns:
+//
+//	any - a map representing the TOML data.
+//	error - an error message if the TOML content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromTOML].
+//
+// [Sprout Documentation: fromTOML]: https://docs.atom.codes/sprout/registries/encoding#fromtoml
+func (er *EncodingRegistry) FromTOML(value string) (any, error) {
+	m, err := decodeTOML(value)
+	if err != nil {
+		return nil, fmt.Errorf("toml decode error: %w", err)
+	}
+
+	return m, nil
+}
+
+// ToTOML serializes a Go map or struct to a TOML document. Map keys are
+// sorted, nested maps are written as tables and lists of maps as arrays of
+// tables. Nil values are omitted as TOML has no null value.
+//
+// Parameters:
+//
+//	value any - the map or struct to serialize.
+//
+// Returns:
+//
+//	string - the TOML document.
+//	error - error if the serialization fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toTOML].
+//
+// [Sprout Documentation: toTOML]: https://docs.atom.codes/sprout/registries/encoding#totoml
+func (er *EncodingRegistry) ToTOML(value any) (string, error) {
+	out, err := encodeTOML(value, "")
+	if err != nil {
+		return "", fmt.Errorf("toml encode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// ToPrettyTOML serializes a Go map or struct to a TOML document like ToTOML,
+// indenting the content of nested tables with two spaces per level.
+//
+// Parameters:
+//
+//	value any - the map or struct to serialize.
+//
+// Returns:
+//
+//	string - the indented TOML document.
+//	error - error if the serialization fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toPrettyTOML].
+//
+// [Sprout Documentation: toPrettyTOML]: https://docs.atom.codes/sprout/registries/encoding#toprettytoml
+func (er *EncodingRegistry) ToPrettyTOML(value any) (string, error) {
+	out, err := encodeTOML(value, "  ")
+	if err != nil {
+		return "", fmt.Errorf("toml encode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// FromXML deserializes an XML document into a Go map holding the root
+// element. An element without attribute nor child element is converted to its
+// trimmed text. Otherwise, it is converted to a map where attributes are
+// stored under keys prefixed by "@", child elements under their name, as a
+// list when repeated, and the text under "#text". Names are kept with their
+// namespace prefix, like `android:name`.
+//
+// Parameters:
+//
+//	value string - the XML document to deserialize.
+//
+// Returns:
+//
+//	any - a map representing the XML document.
+//	error - an error message if the XML content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromXML].
+//
+// [Sprout Documentation: fromXML]: https://docs.atom.codes/sprout/registries/encoding#fromxml
+func (er *EncodingRegistry) FromXML(value string) (any, error) {
+	doc, err := parseXML(value)
+	if err != nil {
+		return nil, fmt.Errorf("xml decode error: %w", err)
+	}
+
+	root := doc.documentElement()
+	return map[string]any{root.name: xmlNodeToValue(root)}, nil
+}
+
+// ToXML serializes a Go data structure to an XML document, using the map
+// representation of FromXML. The value is given last so the function can be
+// used in a pipeline, optionally preceded by a map of options:
+//
+//   - "root" (string): the name of the root element wrapping the value, by
+//     default the value must be a map holding a single element;
+//   - "indent" (int or string): the indentation, in spaces or as a string,
+//     the document is written on a single line by default;
+//   - "header" (bool): whether to write the XML declaration.
+//
+// Parameters:
+//
+//	args ...any - the optional options map, followed by the data structure to serialize.
+//
+// Returns:
+//
+//	string - the XML document.
+//	error - error if the options are invalid or the serialization fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toXML].
+//
+// [Sprout Documentation: toXML]: https://docs.atom.codes/sprout/registries/encoding#toxml
+func (er *EncodingRegistry) ToXML(args ...any) (string, error) {
+	options, value, err := splitOptions(args)
+	if err != nil {
+		return "", fmt.Errorf("xml encode error: %w", err)
+	}
+
+	opts, err := parseXMLOptions(options)
+	if err != nil {
+		return "", fmt.Errorf("xml encode error: %w", err)
+	}
+
+	out, err := encodeXML(value, opts)
+	if err != nil {
+		return "", fmt.Errorf("xml encode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// XPath evaluates an XPath 1.0 expression against an XML document, given as a
+// string or as the map representation returned by FromXML. Names are matched
+// with their namespace prefix as written in the document.
+//
+// Parameters:
+//
+//	expression string - the XPath 1.0 expression to evaluate.
+//	document any - the XML document, as a string or a map.
+//
+// Returns:
+//
+//	any - the string-values of the selected nodes as a []string for node-sets, otherwise a string, a float64 or a bool.
+//	error - error if the document or the expression is invalid.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: xpath].
+//
+// [Sprout Documentation: xpath]: https://docs.atom.codes/sprout/registries/encoding#xpath
+func (er *EncodingRegistry) XPath(expression string, document any) (any, error) {
+	source, ok := document.(string)
+	if !ok {
+		var err error
+		if source, err = encodeXML(document, xmlOptions{}); err != nil {
+			return nil, fmt.Errorf("xpath error: invalid document: %w", err)
+		}
+	}
+
+	doc, err := parseXML(source)
+	if err != nil {
+		return nil, fmt.Errorf("xpath error: invalid document: %w", err)
+	}
+
+	result, err := evaluateXPath(expression, doc)
+	if err != nil {
+		return nil, fmt.Errorf("xpath error: %w", err)
+	}
+
+	if nodes, ok := result.(xpathNodeSet); ok {
+		values := make([]string, len(nodes))
+		for i, node := range nodes {
+			values[i] = node.stringValue()
+		}
+		return values, nil
+	}
+	return result, nil
+}
+
+// FromCSV deserializes a CSV document into a list of records, reading one
+// record at a time. By default, the first row is the header and records are
+// returned as maps keyed by column name. The document is given last so the
+// function can be used in a pipeline, as a string or an io.Reader, optionally
+// preceded by a map of options:
+//
+//   - "delimiter" (string): the field delimiter, "," by default;
+//   - "header" (bool): whether the first row is the header, true by default,
+//     records are returned as lists of fields without header;
+//   - "columns" (list of strings): the column names, replacing the header;
+//   - "comment" (string): the character starting comment lines;
+//   - "lazyQuotes" (bool): whether to accept quotes in unquoted fields;
+//   - "trimLeadingSpace" (bool): whether to ignore the leading spaces of fields.
+//
+// Parameters:
+//
+//	args ...any - the optional options map, followed by the CSV document.
+//
+// Returns:
+//
+//	[]any - the records, as maps or lists of strings.
+//	error - error if the options are invalid or the CSV content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromCSV].
+//
+// [Sprout Documentation: fromCSV]: https://docs.atom.codes/sprout/registries/encoding#fromcsv
+func (er *EncodingRegistry) FromCSV(args ...any) ([]any, error) {
+	records, err := fromDelimited(args, ',')
+	if err != nil {
+		return nil, fmt.Errorf("csv decode error: %w", err)
+	}
+	return records, nil
+}
+
+// ToCSV serializes a list of maps or a list of lists into a CSV document,
+// writing one record at a time. The columns of maps are the sorted union of
+// their keys and are written as the header row. The value is given last so
+// the function can be used in a pipeline, optionally preceded by a map of
+// options:
+//
+//   - "columns" (list of strings): the columns to write, in this order;
+//   - "header" (bool): whether to write the header row, true by default;
+//   - "delimiter" (string): the field delimiter, "," by default;
+//   - "crlf" (bool): whether to end lines with \r\n instead of \n.
+//
+// Parameters:
+//
+//	args ...any - the optional options map, followed by the list to serialize.
+//
+// Returns:
+//
+//	string - the CSV document.
+//	error - error if the options are invalid or the serialization fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toCSV].
+//
+// [Sprout Documentation: toCSV]: https://docs.atom.codes/sprout/registries/encoding#tocsv
+func (er *EncodingRegistry) ToCSV(args ...any) (string, error) {
+	out, err := toDelimited(args, ',')
+	if err != nil {
+		return "", fmt.Errorf("csv encode error: %w", err)
+	}
+	return out, nil
+}
+
+// FromTSV deserializes a TSV document into a list of records, like FromCSV
+// with a tab delimiter.
+//
+// Parameters:
+//
+//	args ...any - the optional options map, followed by the TSV document.
+//
+// Returns:
+//
+//	[]any - the records, as maps or lists of strings.
+//	error - error if the options are invalid or the TSV content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromTSV].
+//
+// [Sprout Documentation: fromTSV]: https://docs.atom.codes/sprout/registries/encoding#fromtsv
+func (er *EncodingRegistry) FromTSV(args ...any) ([]any, error) {
+	records, err := fromDelimited(args, '\t')
+	if err != nil {
+		return nil, fmt.Errorf("tsv decode error: %w", err)
+	}
+	return records, nil
+}
+
+// ToTSV serializes a list of maps or a list of lists into a TSV document, like
+// ToCSV with a tab delimiter.
+//
+// Parameters:
+//
+//	args ...any - the optional options map, followed by the list to serialize.
+//
+// Returns:
+//
+//	string - the TSV document.
+//	error - error if the options are invalid or the serialization fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toTSV].
+//
+// [Sprout Documentation: toTSV]: https://docs.atom.codes/sprout/registries/encoding#totsv
+func (er *EncodingRegistry) ToTSV(args ...any) (string, error) {
+	out, err := toDelimited(args, '\t')
+	if err != nil {
+		return "", fmt.Errorf("tsv encode error: %w", err)
+	}
+	return out, nil
+}
+
+// FromINI deserializes an INI document into a Go map. Keys defined before the
+// first section are stored at the top level and the keys of each section in a
+// nested map. Values are strings, can be double-quoted with backslash escapes
+// or single-quoted, and unquoted values end at an inline comment starting
+// with whitespace followed by ';' or '#'.
+//
+// Parameters:
+//
+//	value string - the INI document to deserialize.
+//
+// Returns:
+//
+//	any - a map representing the INI document.
+//	error - an error message if the INI content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromINI].
+//
+// [Sprout Documentation: fromINI]: https://docs.atom.codes/sprout/registries/encoding#fromini
+func (er *EncodingRegistry) FromINI(value string) (any, error) {
+	out, err := decodeINI(value)
+	if err != nil {
+		return nil, fmt.Errorf("ini decode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// ToINI serializes a Go map or struct into an INI document. Scalar values are
+// written first, then nested maps as sections. Keys are sorted, nil values are
+// omitted and values are double-quoted when needed to be read back as is.
+//
+// Parameters:
+//
+//	value any - the map or struct to serialize.
+//
+// Returns:
+//
+//	string - the INI document.
+//	error - error if the value cannot be serialized, like maps nested in sections.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toINI].
+//
+// [Sprout Documentation: toINI]: https://docs.atom.codes/sprout/registries/encoding#toini
+func (er *EncodingRegistry) ToINI(value any) (string, error) {
+	out, err := encodeINI(value)
+	if err != nil {
+		return "", fmt.Errorf("ini encode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// FromProperties deserializes a Java .properties document into a flat Go map,
+// following the rules of java.util.Properties for comments, line
+// continuations, separators and escape sequences.
+//
+// Parameters:
+//
+//	value string - the .properties document to deserialize.
+//
+// Returns:
+//
+//	any - a map of the properties.
+//	error - an error message if the .properties content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromProperties].
+//
+// [Sprout Documentation: fromProperties]: https://docs.atom.codes/sprout/registries/encoding#fromproperties
+func (er *EncodingRegistry) FromProperties(value string) (any, error) {
+	out, err := decodeProperties(value)
+	if err != nil {
+		return nil, fmt.Errorf("properties decode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// ToProperties serializes a Go map or struct into a Java .properties document.
+// Nested maps are flattened with dotted keys and lists with indexed keys, like
+// `servers[0].host`. Keys are sorted, nil values are omitted and keys and
+// values are escaped like java.util.Properties.store, with non-ASCII
+// characters written as \uXXXX escape sequences.
+//
+// Parameters:
+//
+//	value any - the map or struct to serialize.
+//
+// Returns:
+//
+//	string - the .properties document.
+//	error - error if the value cannot be serialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toProperties].
+//
+// [Sprout Documentation: toProperties]: https://docs.atom.codes/sprout/registries/encoding#toproperties
+func (er *EncodingRegistry) ToProperties(value any) (string, error) {
+	out, err := encodeProperties(value)
+	if err != nil {
+		return "", fmt.Errorf("properties encode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// FromDotenv deserializes a dotenv document into a flat Go map. Lines hold
+// `KEY=value` assignments, optionally prefixed by `export`. Single-quoted
+// values are literal, double-quoted values support backslash escapes and can
+// span several lines, and unquoted values end at an inline comment starting
+// with " #". Variables are not expanded.
+//
+// Parameters:
+//
+//	value string - the dotenv document to deserialize.
+//
+// Returns:
+//
+//	any - a map of the variables.
+//	error - an error message if the dotenv content cannot be deserialized.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fromDotenv].
+//
+// [Sprout Documentation: fromDotenv]: https://docs.atom.codes/sprout/registries/encoding#fromdotenv
+func (er *EncodingRegistry) FromDotenv(value string) (any, error) {
+	out, err := decodeDotenv(value)
+	if err != nil {
+		return nil, fmt.Errorf("dotenv decode error: %w", err)
+	}
+
+	return out, nil
+}
+
+// ToDotenv serializes a flat Go map or struct into a dotenv document. Keys are
+// sorted and nil values are omitted. Values are written unquoted when they
+// only hold safe characters, single-quoted when they hold no single quote nor
+// line break, and double-quoted with backslash escapes otherwise.
+//
+// Parameters:
+//
+//	value any - the map or struct to serialize.
+//
+// Returns:
+//
+//	string - the dotenv document.
+//	error - error if the value cannot be serialized, like nested maps or invalid keys.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: toDotenv].
+//
+// [Sprout Documentation: toDotenv]: https://docs.atom.codes/sprout/registries/encoding#todotenv
+func (er *EncodingRegistry) ToDotenv(value any) (string, error) {
+	out, err := encodeDotenv(value)
+	if err != nil {
+		return "", fmt.Errorf("dotenv encode error: %w", err)
+	}
+
+	return out, nil
+}
@@ -1,6 +1,7 @@
 package encoding_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-sprout/sprout/pesticide"
@@ -118,6 +119,198 @@ func TestToIndentYAML(t *testing.T) {
 	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
 }
 
+func TestFromTOML(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromTOML }}`, ExpectedOutput: "map[]"},
+		{Name: "TestVariableInput", Input: `{{ .V | fromTOML }}`, ExpectedOutput: "map[bar:map[baz:1] foo:55]", Data: map[string]any{"V": "foo = 55\n[bar]\nbaz = 1\n"}},
+		{Name: "TestAccessField", Input: `{{ (.V | fromTOML).bar.baz }}`, ExpectedOutput: "1", Data: map[string]any{"V": "foo = 55\n[bar]\nbaz = 1\n"}},
+		{Name: "TestDateTime", Input: `{{ (.V | fromTOML).date.Year }}`, ExpectedOutput: "1979", Data: map[string]any{"V": "date = 1979-05-27T07:32:00Z"}},
+		{Name: "TestInvalidInput", Input: "{{ .V | fromTOML }}", ExpectedErr: "toml decode error", Data: map[string]any{"V": "foo = = baz"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToTOML(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toTOML }}`, ExpectedOutput: "bar = \"baz\"\nfoo = 55", Data: map[string]any{"V": map[string]any{"foo": 55, "bar": "baz"}}},
+		{Name: "TestNestedInput", Input: `{{ .V | toTOML }}`, ExpectedOutput: "bar = \"baz\"\n\n[foo]\nbaz = \"bar\"", Data: map[string]any{"V": map[string]any{"foo": map[string]any{"baz": "bar"}, "bar": "baz"}}},
+		{Name: "TestRoundTrip", Input: `{{ .V | fromTOML | toTOML }}`, ExpectedOutput: "date = 1979-05-27\n\n[[bar]]\nbaz = 1", Data: map[string]any{"V": "date = 1979-05-27\n[[bar]]\nbaz = 1"}},
+		{Name: "TestNotATable", Input: `{{ "" | toTOML }}`, ExpectedErr: "toml encode error"},
+		{Name: "TestInvalidInput", Input: `{{ .V | toTOML }}`, ExpectedErr: "toml encode error", Data: map[string]any{"V": map[string]any{"foo": make(chan int)}}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToPrettyTOML(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toPrettyTOML }}`, ExpectedOutput: "bar = \"baz\"\n\n[foo]\n  baz = \"bar\"", Data: map[string]any{"V": map[string]any{"foo": map[string]any{"baz": "bar"}, "bar": "baz"}}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toPrettyTOML }}`, ExpectedErr: "toml encode error", Data: map[string]any{"V": make(chan int)}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromXML(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromXML }}`, ExpectedErr: "xml decode error: no root element"},
+		{Name: "TestVariableInput", Input: `{{ (.V | fromXML).project.version }}`, ExpectedOutput: "1.2.3", Data: map[string]any{"V": `<project><version>1.2.3</version></project>`}},
+		{Name: "TestAttributes", Input: `{{ (.V | fromXML).dependency }}`, ExpectedOutput: "map[#text:junit @scope:test]", Data: map[string]any{"V": `<dependency scope="test">junit</dependency>`}},
+		{Name: "TestRepeatedElements", Input: `{{ (.V | fromXML).list.item }}`, ExpectedOutput: "[a b]", Data: map[string]any{"V": `<list><item>a</item><item>b</item></list>`}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromXML }}`, ExpectedErr: "xml decode error", Data: map[string]any{"V": "<a><b></a>"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToXML(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toXML }}`, ExpectedOutput: `<project version="1"><name>sprout</name></project>`, Data: map[string]any{"V": map[string]any{"project": map[string]any{"@version": 1, "name": "sprout"}}}},
+		{Name: "TestWithOptions", Input: `{{ .V | toXML (dict "root" "item" "indent" 2 "header" true) }}`, ExpectedOutput: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<item>\n  <name>a</name>\n</item>", Data: map[string]any{"V": map[string]any{"name": "a"}}},
+		{Name: "TestRepeatedElements", Input: `{{ .V | toXML (dict "root" "list") }}`, ExpectedOutput: "<list><item>a</item><item>b</item></list>", Data: map[string]any{"V": map[string]any{"item": []string{"a", "b"}}}},
+		{Name: "TestRootList", Input: `{{ list 1 2 | toXML (dict "root" "r") }}`, ExpectedErr: "xml encode error: root element <r> cannot hold a list"},
+		{Name: "TestRootListInMap", Input: `{{ .V | toXML }}`, ExpectedErr: "xml encode error: root element <r> cannot hold a list", Data: map[string]any{"V": map[string]any{"r": []int{1, 2}}}},
+		{Name: "TestMissingRoot", Input: `{{ .V | toXML }}`, ExpectedErr: "xml encode error: value must be a map with a single root element", Data: map[string]any{"V": map[string]any{"a": 1, "b": 2}}},
+		{Name: "TestInvalidOptions", Input: `{{ .V | toXML "root" }}`, ExpectedErr: "xml encode error: options must be a map, got string", Data: map[string]any{"V": map[string]any{"a": 1}}},
+		{Name: "TestUnknownOption", Input: `{{ .V | toXML (dict "pretty" true) }}`, ExpectedErr: `xml encode error: unknown option "pretty"`, Data: map[string]any{"V": map[string]any{"a": 1}}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestXPath(t *testing.T) {
+	pom := `<project><version>1.2.3</version><dependencies><dependency scope="test">junit</dependency><dependency>guava</dependency></dependencies></project>`
+
+	tc := []pesticide.TestCase{
+		{Name: "TestString", Input: `{{ .V | xpath "string(/project/version)" }}`, ExpectedOutput: "1.2.3", Data: map[string]any{"V": pom}},
+		{Name: "TestNodeSet", Input: `{{ .V | xpath "//dependency" }}`, ExpectedOutput: "[junit guava]", Data: map[string]any{"V": pom}},
+		{Name: "TestAttribute", Input: `{{ .V | xpath "//dependency[@scope = 'test']" }}`, ExpectedOutput: "[junit]", Data: map[string]any{"V": pom}},
+		{Name: "TestNumber", Input: `{{ .V | xpath "count(//dependency)" }}`, ExpectedOutput: "2", Data: map[string]any{"V": pom}},
+		{Name: "TestBoolean", Input: `{{ .V | xpath "boolean(//parent)" }}`, ExpectedOutput: "false", Data: map[string]any{"V": pom}},
+		{Name: "TestParsedDocument", Input: `{{ .V | fromXML | xpath "string(//dependency[2])" }}`, ExpectedOutput: "guava", Data: map[string]any{"V": pom}},
+		{Name: "TestInvalidDocument", Input: `{{ .V | xpath "/a" }}`, ExpectedErr: "xpath error: invalid document", Data: map[string]any{"V": "<a>"}},
+		{Name: "TestInvalidExpression", Input: `{{ .V | xpath "//[" }}`, ExpectedErr: "xpath error", Data: map[string]any{"V": pom}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromCSV(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromCSV }}`, ExpectedOutput: "[]"},
+		{Name: "TestVariableInput", Input: `{{ range .V | fromCSV }}{{ .name }}={{ .age }};{{ end }}`, ExpectedOutput: "alice=30;bob=25;", Data: map[string]any{"V": "name,age\nalice,30\nbob,25"}},
+		{Name: "TestReader", Input: `{{ (index (.V | fromCSV) 0).name }}`, ExpectedOutput: "alice", Data: map[string]any{"V": strings.NewReader("name\nalice")}},
+		{Name: "TestOptions", Input: `{{ .V | fromCSV (dict "delimiter" ";" "header" false) }}`, ExpectedOutput: "[[a b] [1 2]]", Data: map[string]any{"V": "a;b\n1;2"}},
+		{Name: "TestColumns", Input: `{{ .V | fromCSV (dict "header" false "columns" (list "x" "y")) }}`, ExpectedOutput: "[map[x:1 y:2]]", Data: map[string]any{"V": "1,2"}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromCSV }}`, ExpectedErr: "csv decode error: record on line 2: wrong number of fields", Data: map[string]any{"V": "a,b\n1"}},
+		{Name: "TestInvalidDocument", Input: `{{ .V | fromCSV }}`, ExpectedErr: "csv decode error: document must be a string or an io.Reader, got int", Data: map[string]any{"V": 1}},
+		{Name: "TestInvalidOptions", Input: `{{ .V | fromCSV (dict "delimiter" 1) }}`, ExpectedErr: `csv decode error: option "delimiter" must be a single character`, Data: map[string]any{"V": "a"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToCSV(t *testing.T) {
+	users := []map[string]any{{"name": "alice", "age": 30}, {"name": "bob, jr", "age": 25}}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toCSV }}`, ExpectedOutput: "age,name\n30,alice\n25,\"bob, jr\"", Data: map[string]any{"V": users}},
+		{Name: "TestColumns", Input: `{{ .V | toCSV (dict "columns" (list "name" "age")) }}`, ExpectedOutput: "name,age\nalice,30\n\"bob, jr\",25", Data: map[string]any{"V": users}},
+		{Name: "TestLists", Input: `{{ .V | toCSV (dict "delimiter" ";") }}`, ExpectedOutput: "a;1\nb;2", Data: map[string]any{"V": [][]any{{"a", 1}, {"b", 2}}}},
+		{Name: "TestNoHeader", Input: `{{ .V | toCSV (dict "header" false) }}`, ExpectedOutput: "30,alice\n25,\"bob, jr\"", Data: map[string]any{"V": users}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toCSV }}`, ExpectedErr: "csv encode error: value must be a list of maps or a list of lists", Data: map[string]any{"V": "a,b"}},
+		{Name: "TestUnknownOption", Input: `{{ .V | toCSV (dict "pretty" true) }}`, ExpectedErr: `csv encode error: unknown option "pretty"`, Data: map[string]any{"V": users}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromTSV(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ range .V | fromTSV }}{{ .name }}={{ .city }};{{ end }}`, ExpectedOutput: "alice=Paris, France;", Data: map[string]any{"V": "name\tcity\nalice\tParis, France"}},
+		{Name: "TestOptions", Input: `{{ .V | fromTSV (dict "header" false) }}`, ExpectedOutput: "[[a b]]", Data: map[string]any{"V": "a\tb"}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromTSV }}`, ExpectedErr: "tsv decode error", Data: map[string]any{"V": "a\tb\n1"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToTSV(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toTSV }}`, ExpectedOutput: "city\tname\nParis, France\talice", Data: map[string]any{"V": []map[string]any{{"name": "alice", "city": "Paris, France"}}}},
+		{Name: "TestColumns", Input: `{{ .V | toTSV (dict "columns" (list "name")) }}`, ExpectedOutput: "name\nalice", Data: map[string]any{"V": []map[string]any{{"name": "alice", "city": "Paris"}}}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toTSV }}`, ExpectedErr: "tsv encode error", Data: map[string]any{"V": []any{1}}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromINI(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromINI }}`, ExpectedOutput: "map[]"},
+		{Name: "TestVariableInput", Input: `{{ .V | fromINI }}`, ExpectedOutput: "map[database:map[host:localhost port:5432] name:sprout]", Data: map[string]any{"V": "name = sprout\n[database]\nhost = localhost\nport = 5432"}},
+		{Name: "TestAccessField", Input: `{{ (.V | fromINI).database.password }}`, ExpectedOutput: "p; w", Data: map[string]any{"V": "[database]\npassword = \"p; w\" ; comment"}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromINI }}`, ExpectedErr: "ini decode error", Data: map[string]any{"V": "[database"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToINI(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toINI }}`, ExpectedOutput: "name = sprout\n\n[database]\nhost = localhost\nport = 5432", Data: map[string]any{"V": map[string]any{"name": "sprout", "database": map[string]any{"host": "localhost", "port": 5432}}}},
+		{Name: "TestQuoting", Input: `{{ .V | toINI }}`, ExpectedOutput: "password = \"p; \\\"w\\\"\"", Data: map[string]any{"V": map[string]any{"password": `p; "w"`}}},
+		{Name: "TestRoundTrip", Input: `{{ (.V | toINI | fromINI).password }}`, ExpectedOutput: "a\nb", Data: map[string]any{"V": map[string]any{"password": "a\nb"}}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toINI }}`, ExpectedErr: "ini encode error", Data: map[string]any{"V": map[string]any{"a": map[string]any{"b": map[string]any{}}}}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromProperties(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromProperties }}`, ExpectedOutput: "map[]"},
+		{Name: "TestVariableInput", Input: `{{ .V | fromProperties }}`, ExpectedOutput: "map[server.host:localhost server.port:8080]", Data: map[string]any{"V": "# server\nserver.port=8080\nserver.host : localhost"}},
+		{Name: "TestEscapes", Input: `{{ index (.V | fromProperties) "greeting" }}`, ExpectedOutput: "café=ok", Data: map[string]any{"V": `greeting = café\=ok`}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromProperties }}`, ExpectedErr: "properties decode error", Data: map[string]any{"V": `a=\uZZZZ`}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToProperties(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toProperties }}`, ExpectedOutput: "name=sprout\nserver.hosts[0]=a\nserver.hosts[1]=b\nserver.port=8080", Data: map[string]any{"V": map[string]any{"name": "sprout", "server": map[string]any{"port": 8080, "hosts": []string{"a", "b"}}}}},
+		{Name: "TestEscapes", Input: `{{ .V | toProperties }}`, ExpectedOutput: `greeting=caf\u00E9\=ok`, Data: map[string]any{"V": map[string]any{"greeting": "café=ok"}}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toProperties }}`, ExpectedErr: "properties encode error", Data: map[string]any{"V": "value"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestFromDotenv(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEmptyInput", Input: `{{ "" | fromDotenv }}`, ExpectedOutput: "map[]"},
+		{Name: "TestVariableInput", Input: `{{ .V | fromDotenv }}`, ExpectedOutput: "map[DB_HOST:localhost DB_PORT:5432]", Data: map[string]any{"V": "# database\nDB_HOST=localhost\nexport DB_PORT=5432 # comment"}},
+		{Name: "TestQuotedValues", Input: `{{ (.V | fromDotenv).GREETING }}`, ExpectedOutput: "hello\nworld", Data: map[string]any{"V": `GREETING="hello\nworld"`}},
+		{Name: "TestInvalidInput", Input: `{{ .V | fromDotenv }}`, ExpectedErr: "dotenv decode error", Data: map[string]any{"V": "DB HOST=localhost"}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
+func TestToDotenv(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestVariableInput", Input: `{{ .V | toDotenv }}`, ExpectedOutput: "DB_HOST=localhost\nDB_PORT=5432\nGREETING='hello world'", Data: map[string]any{"V": map[string]any{"DB_HOST": "localhost", "DB_PORT": 5432, "GREETING": "hello world"}}},
+		{Name: "TestEscapes", Input: `{{ .V | toDotenv }}`, ExpectedOutput: `MESSAGE="it's\n\$HOME"`, Data: map[string]any{"V": map[string]any{"MESSAGE": "it's\n$HOME"}}},
+		{Name: "TestRoundTrip", Input: `{{ (.V | toDotenv | fromDotenv).MESSAGE }}`, ExpectedOutput: "it's \"quoted\"", Data: map[string]any{"V": map[string]any{"MESSAGE": `it's "quoted"`}}},
+		{Name: "TestInvalidInput", Input: `{{ .V | toDotenv }}`, ExpectedErr: "dotenv encode error", Data: map[string]any{"V": map[string]any{"DB": map[string]any{"HOST": "localhost"}}}},
+	}
+
+	pesticide.RunTestCases(t, encoding.NewRegistry(), tc)
+}
+
 func TestMustFromJson(t *testing.T) {
 	tc := []pesticide.TestCase{
 		{
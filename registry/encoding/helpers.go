@@ -0,0 +1,155 @@
+package encoding
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// orderedMap is a map with ordered keys, used by the encoders to write maps
+// with sorted keys and structs with their fields order.
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// normalizeValue converts a value into ordered maps, slices, strings, bools,
+// int64, float64 and time.Time values, so encoders only deal with these types.
+// Nil pointers, interfaces, maps and slices are returned as nil. The fields of
+// structs are renamed or omitted according to the given struct tag.
+func normalizeValue(v reflect.Value, tag string) (any, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return t, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("integer %d overflows an int64", v.Uint())
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			item, err := normalizeValue(v.Index(i), tag)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		m := &orderedMap{values: make(map[string]any, v.Len())}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			item, err := normalizeValue(iter.Value(), tag)
+			if err != nil {
+				return nil, err
+			}
+			m.keys = append(m.keys, key)
+			m.values[key] = item
+		}
+		slices.Sort(m.keys)
+		return m, nil
+	case reflect.Struct:
+		return normalizeStruct(v, tag)
+	default:
+		return nil, fmt.Errorf("cannot encode a value of type %s", v.Type())
+	}
+}
+
+// normalizeStruct converts the exported fields of a struct into an ordered
+// map. The given struct tag can rename a field, omit it with "-" or omit its
+// zero value with the "omitempty" option.
+func normalizeStruct(v reflect.Value, tag string) (*orderedMap, error) {
+	m := &orderedMap{values: make(map[string]any)}
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if options == "omitempty" && v.Field(i).IsZero() {
+			continue
+		}
+
+		item, err := normalizeValue(v.Field(i), tag)
+		if err != nil {
+			return nil, err
+		}
+		m.keys = append(m.keys, name)
+		m.values[name] = item
+	}
+	return m, nil
+}
+
+// formatScalar formats a normalized scalar value as text.
+func formatScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return "", fmt.Errorf("cannot encode a value of type %T as text", value)
+	}
+}
+
+// splitOptions splits the arguments of a function taking an optional map of
+// options followed by a value, so the value can be given through a pipeline.
+func splitOptions(args []any) (map[string]any, any, error) {
+	switch len(args) {
+	case 1:
+		return nil, args[0], nil
+	case 2:
+		options, ok := args[0].(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("options must be a map, got %T", args[0])
+		}
+		return options, args[1], nil
+	default:
+		return nil, nil, fmt.Errorf("expected 1 or 2 arguments, got %d", len(args))
+	}
+}
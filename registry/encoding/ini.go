@@ -0,0 +1,221 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeINI parses an INI document. Keys defined before the first section are
+// stored at the top level and the keys of each section in a nested map. All
+// values are strings.
+func decodeINI(input string) (map[string]any, error) {
+	root := make(map[string]any)
+	current := root
+
+	for i, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: expected \"]\" to close the section header", i+1)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty section name", i+1)
+			}
+			switch section := root[name].(type) {
+			case nil:
+				current = make(map[string]any)
+				root[name] = current
+			case map[string]any:
+				current = section
+			default:
+				return nil, fmt.Errorf("line %d: section %q conflicts with a key", i+1, name)
+			}
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			return nil, fmt.Errorf("line %d: expected '=' or ':' after key", i+1)
+		}
+		key := strings.TrimSpace(line[:sep])
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", i+1)
+		}
+
+		value, err := parseINIValue(strings.TrimSpace(line[sep+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		current[key] = value
+	}
+
+	return root, nil
+}
+
+// parseINIValue parses a value, which can be double-quoted with backslash
+// escapes, single-quoted without escapes or unquoted. An unquoted value ends
+// at an inline comment, starting with whitespace followed by ';' or '#'.
+func parseINIValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	var value, rest string
+	switch raw[0] {
+	case '"':
+		var sb strings.Builder
+		i := 1
+		for ; i < len(raw) && raw[i] != '"'; i++ {
+			if raw[i] != '\\' {
+				sb.WriteByte(raw[i])
+				continue
+			}
+			i++
+			if i == len(raw) {
+				break
+			}
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '\'', ';', '#':
+				sb.WriteByte(raw[i])
+			default:
+				return "", fmt.Errorf("invalid escape sequence \\%c", raw[i])
+			}
+		}
+		if i >= len(raw) {
+			return "", errors.New("unterminated quoted value")
+		}
+		value, rest = sb.String(), raw[i+1:]
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		value, rest = raw[1:end+1], raw[end+2:]
+	default:
+		for i := 1; i < len(raw); i++ {
+			if (raw[i] == ';' || raw[i] == '#') && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+				return strings.TrimSpace(raw[:i]), nil
+			}
+		}
+		return raw, nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest != "" && rest[0] != ';' && rest[0] != '#' {
+		return "", fmt.Errorf("unexpected content %q after quoted value", rest)
+	}
+	return value, nil
+}
+
+// encodeINI writes a map as an INI document. Scalar values are written first,
+// then nested maps as sections. Keys are sorted and nil values are omitted.
+func encodeINI(value any) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "ini")
+	if err != nil {
+		return "", err
+	}
+
+	root, ok := normalized.(*orderedMap)
+	if !ok {
+		return "", fmt.Errorf("top-level value must be a map or a struct, got %T", value)
+	}
+
+	var sb strings.Builder
+	var sections []string
+	for _, key := range root.keys {
+		if _, isSection := root.values[key].(*orderedMap); isSection {
+			sections = append(sections, key)
+			continue
+		}
+		if err := writeINIKeyValue(&sb, key, root.values[key]); err != nil {
+			return "", err
+		}
+	}
+
+	for _, name := range sections {
+		if name == "" || strings.ContainsAny(name, "[]\r\n") || strings.TrimSpace(name) != name {
+			return "", fmt.Errorf("invalid section name %q", name)
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("[" + name + "]\n")
+
+		section := root.values[name].(*orderedMap)
+		for _, key := range section.keys {
+			if err := writeINIKeyValue(&sb, key, section.values[key]); err != nil {
+				return "", fmt.Errorf("section %q: %w", name, err)
+			}
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// writeINIKeyValue writes a key and its scalar value, quoting the value when
+// it would not be read back as is.
+func writeINIKeyValue(sb *strings.Builder, key string, value any) error {
+	if value == nil {
+		return nil
+	}
+	if key == "" || strings.ContainsAny(key, "=:\r\n") || strings.ContainsAny(key[:1], "[;#") || strings.TrimSpace(key) != key {
+		return fmt.Errorf("invalid key %q", key)
+	}
+
+	switch value.(type) {
+	case *orderedMap, []any:
+		return fmt.Errorf("key %q: cannot encode a nested value in an INI document", key)
+	}
+	s, err := formatScalar(value)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", key, err)
+	}
+
+	sb.WriteString(key + " =")
+	if s != "" {
+		sb.WriteString(" " + quoteINIValue(s))
+	}
+	sb.WriteByte('\n')
+	return nil
+}
+
+// quoteINIValue double-quotes a value holding line breaks, comment characters,
+// leading or trailing whitespace, or starting with a quote.
+func quoteINIValue(s string) string {
+	if !strings.ContainsAny(s, "\r\n;#") && strings.TrimSpace(s) == s && s[0] != '"' && s[0] != '\'' {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
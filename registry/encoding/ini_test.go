@@ -0,0 +1,150 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeINI(t *testing.T) {
+	doc := `; global settings
+name = sprout
+path = C:\Program Files\sprout
+empty =
+
+[database]
+host: localhost
+port = 5432 ; inline comment
+url = http://localhost/#anchor
+password = "p@ss; \"word\"\n" # comment
+literal = 'a\nb'
+
+# merged into the first section
+[ database ]
+user = admin
+
+[server]
+`
+
+	m, err := decodeINI(doc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":  "sprout",
+		"path":  `C:\Program Files\sprout`,
+		"empty": "",
+		"database": map[string]any{
+			"host":     "localhost",
+			"port":     "5432",
+			"url":      "http://localhost/#anchor",
+			"password": "p@ss; \"word\"\n",
+			"literal":  `a\nb`,
+			"user":     "admin",
+		},
+		"server": map[string]any{},
+	}, m)
+}
+
+func TestDecodeINIErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"UnclosedSection", "[a", `line 1: expected "]" to close the section header`},
+		{"EmptySection", "[ ]", "line 1: empty section name"},
+		{"SectionOverKey", "a = 1\n[a]", `line 2: section "a" conflicts with a key`},
+		{"MissingSeparator", "a", "line 1: expected '=' or ':' after key"},
+		{"EmptyKey", "= 1", "line 1: empty key"},
+		{"UnterminatedQuote", `a = "abc`, "line 1: unterminated quoted value"},
+		{"UnterminatedSingleQuote", `a = 'abc`, "line 1: unterminated quoted value"},
+		{"InvalidEscape", `a = "\q"`, `line 1: invalid escape sequence \q`},
+		{"TrailingContent", `a = "abc" def`, `line 1: unexpected content "def" after quoted value`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeINI(test.input)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestEncodeINI(t *testing.T) {
+	type database struct {
+		Host     string `ini:"host"`
+		Port     int    `ini:"port"`
+		Password string `ini:"password,omitempty"`
+	}
+
+	value := map[string]any{
+		"name":     "sprout",
+		"path":     `C:\sprout`,
+		"debug":    false,
+		"nothing":  nil,
+		"database": database{Host: "localhost", Port: 5432},
+		"quoting": map[string]any{
+			"comment":  "a ; b",
+			"spaces":   " padded ",
+			"lines":    "a\nb",
+			"quote":    `"quoted" \ value`,
+			"embedded": `say "hi"`,
+			"empty":    "",
+		},
+		"empty": map[string]any{},
+	}
+
+	out, err := encodeINI(value)
+	require.NoError(t, err)
+	assert.Equal(t, `debug = false
+name = sprout
+path = C:\sprout
+
+[database]
+host = localhost
+port = 5432
+
+[empty]
+
+[quoting]
+comment = "a ; b"
+embedded = say "hi"
+empty =
+lines = "a\nb"
+quote = "\"quoted\" \\ value"
+spaces = " padded "`, out)
+
+	decoded, err := decodeINI(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"comment":  "a ; b",
+		"spaces":   " padded ",
+		"lines":    "a\nb",
+		"quote":    `"quoted" \ value`,
+		"embedded": `say "hi"`,
+		"empty":    "",
+	}, decoded["quoting"])
+}
+
+func TestEncodeINIErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{"NotAMap", []int{1}, "top-level value must be a map or a struct"},
+		{"NestedSection", map[string]any{"a": map[string]any{"b": map[string]any{}}}, `section "a": key "b": cannot encode a nested value in an INI document`},
+		{"List", map[string]any{"a": []int{1}}, `key "a": cannot encode a nested value in an INI document`},
+		{"InvalidKey", map[string]any{"a=b": 1}, `invalid key "a=b"`},
+		{"CommentKey", map[string]any{"#a": 1}, `invalid key "#a"`},
+		{"InvalidSection", map[string]any{"a]": map[string]any{}}, `invalid section name "a]"`},
+		{"InvalidValue", map[string]any{"a": make(chan int)}, "cannot encode a value of type chan int"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encodeINI(test.value)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
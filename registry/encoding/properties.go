@@ -0,0 +1,227 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// decodeProperties parses a Java .properties document, following the rules
+// of java.util.Properties: comments start with '#' or '!', lines ending with
+// an odd number of backslashes continue on the next line, and keys end at the
+// first unescaped '=', ':' or whitespace. All values are strings.
+func decodeProperties(input string) (map[string]any, error) {
+	result := make(map[string]any)
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Join the continuation lines into a single logical line
+		for isContinuedPropertiesLine(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if isContinuedPropertiesLine(line) {
+			line = line[:len(line)-1]
+		}
+
+		keyEnd := len(line)
+		for j := 0; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+				continue
+			}
+			if strings.IndexByte("=: \t\f", line[j]) >= 0 {
+				keyEnd = j
+				break
+			}
+		}
+
+		valueStart := keyEnd
+		for valueStart < len(line) && strings.IndexByte(" \t\f", line[valueStart]) >= 0 {
+			valueStart++
+		}
+		if valueStart < len(line) && (line[valueStart] == '=' || line[valueStart] == ':') {
+			valueStart++
+			for valueStart < len(line) && strings.IndexByte(" \t\f", line[valueStart]) >= 0 {
+				valueStart++
+			}
+		}
+
+		key, err := unescapeProperties(line[:keyEnd])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		value, err := unescapeProperties(line[valueStart:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// isContinuedPropertiesLine reports whether the line ends with an odd number
+// of backslashes.
+func isContinuedPropertiesLine(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// unescapeProperties resolves the escape sequences of a key or a value.
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", errors.New("invalid unicode escape sequence")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape sequence \\u%s", s[i+1:i+5])
+			}
+			i += 4
+			r := rune(code)
+			// Characters outside the BMP are written as surrogate pairs
+			if utf16.IsSurrogate(r) && i+6 < len(s) && s[i+1] == '\\' && s[i+2] == 'u' {
+				if low, err := strconv.ParseUint(s[i+3:i+7], 16, 16); err == nil {
+					if pair := utf16.DecodeRune(r, rune(low)); pair != unicode.ReplacementChar {
+						r = pair
+						i += 6
+					}
+				}
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// encodeProperties writes a map as a Java .properties document. Nested maps
+// are flattened with dotted keys and lists with indexed keys, like
+// `servers[0].host`. Keys are sorted and nil values are omitted.
+func encodeProperties(value any) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "properties")
+	if err != nil {
+		return "", err
+	}
+
+	root, ok := normalized.(*orderedMap)
+	if !ok {
+		return "", fmt.Errorf("top-level value must be a map or a struct, got %T", value)
+	}
+
+	var sb strings.Builder
+	if err := writeProperties(&sb, "", root); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+// writeProperties writes the entries of a value under the given key prefix.
+func writeProperties(sb *strings.Builder, key string, value any) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *orderedMap:
+		for _, k := range v.keys {
+			if k == "" {
+				return errors.New("cannot encode an empty key")
+			}
+			childKey := k
+			if key != "" {
+				childKey = key + "." + k
+			}
+			if err := writeProperties(sb, childKey, v.values[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for i, item := range v {
+			if err := writeProperties(sb, key+"["+strconv.Itoa(i)+"]", item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s, err := formatScalar(value)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", key, err)
+	}
+	sb.WriteString(escapeProperties(key, true))
+	sb.WriteByte('=')
+	sb.WriteString(escapeProperties(s, false))
+	sb.WriteByte('\n')
+	return nil
+}
+
+// escapeProperties escapes a key or a value like java.util.Properties.store:
+// separators and comment characters are escaped with a backslash, as well as
+// spaces in keys and the leading space of values, and characters outside of
+// the printable ASCII range are written as \uXXXX escape sequences, as
+// .properties files are read as ISO-8859-1 by default.
+func escapeProperties(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch {
+		case r == ' ':
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\f':
+			sb.WriteString(`\f`)
+		case strings.ContainsRune(`\=:#!`, r):
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&sb, `\u%04X`, unit)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeProperties(t *testing.T) {
+	doc := "# comment\n" +
+		"! another comment\n" +
+		"server.port=8080\n" +
+		"server.host : localhost\n" +
+		"greeting Hello World\n" +
+		"  indented = value  \n" +
+		"empty\n" +
+		"fruits = apple, banana, \\\n" +
+		"         pear\n" +
+		"path=C:\\\\temp\\\\file\n" +
+		"key\\ with\\ spaces = a\\=b\\:c\n" +
+		"unicode=caf\\u00e9 \\uD83D\\uDE00\n" +
+		"escapes=tab\\there\\nnew line\r\n" +
+		"trailing=\\\n"
+
+	m, err := decodeProperties(doc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"server.port":     "8080",
+		"server.host":     "localhost",
+		"greeting":        "Hello World",
+		"indented":        "value  ",
+		"empty":           "",
+		"fruits":          "apple, banana, pear",
+		"path":            `C:\temp\file`,
+		"key with spaces": "a=b:c",
+		"unicode":         "café 😀",
+		"escapes":         "tab\there\nnew line",
+		"trailing":        "",
+	}, m)
+}
+
+func TestDecodePropertiesErrors(t *testing.T) {
+	_, err := decodeProperties(`a=\u00`)
+	require.ErrorContains(t, err, "line 1: invalid unicode escape sequence")
+
+	_, err = decodeProperties("a=1\nb=\\uZZZZ")
+	require.ErrorContains(t, err, `line 2: invalid unicode escape sequence \uZZZZ`)
+}
+
+func TestEncodeProperties(t *testing.T) {
+	type server struct {
+		Host string `properties:"host"`
+		Port int    `properties:"port"`
+	}
+
+	value := map[string]any{
+		"server":  server{Host: "localhost", Port: 8080},
+		"servers": []any{map[string]any{"host": "a"}, map[string]any{"host": "b"}},
+		"tags":    []string{"x", "y"},
+		"nothing": nil,
+		"special": map[string]any{
+			"key with spaces": " leading space",
+			"separators":      "a=b:c#d!e",
+			"path":            `C:\temp`,
+			"lines":           "a\nb\tc",
+			"unicode":         "café 😀",
+		},
+	}
+
+	out, err := encodeProperties(value)
+	require.NoError(t, err)
+	assert.Equal(t, `server.host=localhost
+server.port=8080
+servers[0].host=a
+servers[1].host=b
+special.key\ with\ spaces=\ leading space
+special.lines=a\nb\tc
+special.path=C\:\\temp
+special.separators=a\=b\:c\#d\!e
+special.unicode=caf\u00E9 \uD83D\uDE00
+tags[0]=x
+tags[1]=y`, out)
+
+	decoded, err := decodeProperties(out)
+	require.NoError(t, err)
+	assert.Equal(t, " leading space", decoded["special.key with spaces"])
+	assert.Equal(t, "a=b:c#d!e", decoded["special.separators"])
+	assert.Equal(t, `C:\temp`, decoded["special.path"])
+	assert.Equal(t, "a\nb\tc", decoded["special.lines"])
+	assert.Equal(t, "café 😀", decoded["special.unicode"])
+}
+
+func TestEncodePropertiesErrors(t *testing.T) {
+	_, err := encodeProperties("value")
+	require.ErrorContains(t, err, "top-level value must be a map or a struct")
+
+	_, err = encodeProperties(map[string]any{"": 1})
+	require.ErrorContains(t, err, "cannot encode an empty key")
+
+	_, err = encodeProperties(map[string]any{"a": make(chan int)})
+	require.ErrorContains(t, err, "cannot encode a value of type chan int")
+}
@@ -0,0 +1,939 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Locations of the TOML local date-time values. TOML local date-times, dates
+// and times have no offset, they are decoded as time.Time values in one of
+// these locations so they are encoded back with their original type.
+var (
+	TOMLLocalDateTime = time.FixedZone("toml-local-datetime", 0)
+	TOMLLocalDate     = time.FixedZone("toml-local-date", 0)
+	TOMLLocalTime     = time.FixedZone("toml-local-time", 0)
+)
+
+// tomlKeySeparator separates the keys of a table path, it cannot appear in a
+// TOML key.
+const tomlKeySeparator = "\x00"
+
+var (
+	tomlBareKeyRegex    = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	tomlDecimalRegex    = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	tomlHexRegex        = regexp.MustCompile(`^0x[0-9A-Fa-f](_?[0-9A-Fa-f])*$`)
+	tomlOctalRegex      = regexp.MustCompile(`^0o[0-7](_?[0-7])*$`)
+	tomlBinaryRegex     = regexp.MustCompile(`^0b[01](_?[01])*$`)
+	tomlFloatRegex      = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	tomlDateTimeRegex   = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}[Tt ][0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?([Zz]|[+-][0-9]{2}:[0-9]{2})?$`)
+	tomlLocalDateRegex  = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	tomlLocalTimeRegex  = regexp.MustCompile(`^[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?$`)
+	tomlDatePrefixRegex = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+)
+
+// tomlParser decodes a TOML document into maps, slices and scalar values.
+type tomlParser struct {
+	input string
+	pos   int
+
+	root        map[string]any
+	current     map[string]any
+	currentPath string
+
+	explicit  map[string]bool // tables defined by a [table] header
+	dotted    map[string]bool // tables defined by dotted keys
+	frozen    map[string]bool // inline tables and arrays, which cannot be extended
+	arrays    map[string]bool // arrays of tables defined by [[table]] headers
+	inlineSeq int
+}
+
+// decodeTOML decodes a TOML document into a map.
+func decodeTOML(input string) (map[string]any, error) {
+	root := make(map[string]any)
+	p := &tomlParser{
+		input:    input,
+		root:     root,
+		current:  root,
+		explicit: make(map[string]bool),
+		dotted:   make(map[string]bool),
+		frozen:   make(map[string]bool),
+		arrays:   make(map[string]bool),
+	}
+
+	if err := p.parseDocument(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// errorf returns an error located at the current line of the document.
+func (p *tomlParser) errorf(format string, args ...any) error {
+	line := strings.Count(p.input[:min(p.pos, len(p.input))], "\n") + 1
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *tomlParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.input[p.pos:], prefix)
+}
+
+// skipWhitespace skips spaces and tabs.
+func (p *tomlParser) skipWhitespace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to the end of the line.
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// skipNewline skips a LF or CRLF line ending and reports whether one was found.
+func (p *tomlParser) skipNewline() bool {
+	switch {
+	case p.hasPrefix("\n"):
+		p.pos++
+		return true
+	case p.hasPrefix("\r\n"):
+		p.pos += 2
+		return true
+	}
+	return false
+}
+
+// skipBlank skips whitespace, comments and line endings.
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipWhitespace()
+		p.skipComment()
+		if !p.skipNewline() {
+			return
+		}
+	}
+}
+
+// expectLineEnd checks nothing but a comment follows on the current line.
+func (p *tomlParser) expectLineEnd() error {
+	p.skipWhitespace()
+	p.skipComment()
+	if !p.eof() && !p.skipNewline() {
+		return p.errorf("expected a new line, found %q", p.peek())
+	}
+	return nil
+}
+
+func (p *tomlParser) parseDocument() error {
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		var err error
+		switch {
+		case p.hasPrefix("[["):
+			p.pos += 2
+			err = p.parseTableHeader(true)
+		case p.hasPrefix("["):
+			p.pos++
+			err = p.parseTableHeader(false)
+		default:
+			err = p.parseKeyValue(p.current, p.currentPath)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := p.expectLineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseTableHeader parses a [table] or [[array.of.tables]] header, the
+// opening brackets being already consumed, and makes it the current table.
+func (p *tomlParser) parseTableHeader(array bool) error {
+	p.skipWhitespace()
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipWhitespace()
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !p.hasPrefix(closing) {
+		return p.errorf("expected %q to close the table header", closing)
+	}
+	p.pos += len(closing)
+
+	table, path := p.root, ""
+	for _, key := range keys[:len(keys)-1] {
+		if table, path, err = p.descend(table, path, key, false); err != nil {
+			return err
+		}
+	}
+
+	last := keys[len(keys)-1]
+	childPath := path + tomlKeySeparator + last
+	existing, exists := table[last]
+
+	if array {
+		var list []any
+		if exists {
+			var isList bool
+			list, isList = existing.([]any)
+			if !isList || !p.arrays[childPath] {
+				return p.errorf("key %q is already defined and is not an array of tables", last)
+			}
+		}
+		child := make(map[string]any)
+		table[last] = append(list, child)
+		p.arrays[childPath] = true
+		p.current, p.currentPath = child, childPath+tomlKeySeparator+strconv.Itoa(len(list))
+		return nil
+	}
+
+	var child map[string]any
+	if exists {
+		var isTable bool
+		child, isTable = existing.(map[string]any)
+		if !isTable || p.explicit[childPath] || p.dotted[childPath] || p.frozen[childPath] {
+			return p.errorf("table %q is already defined", strings.Join(keys, "."))
+		}
+	} else {
+		child = make(map[string]any)
+		table[last] = child
+	}
+	p.explicit[childPath] = true
+	p.current, p.currentPath = child, childPath
+	return nil
+}
+
+// descend returns the subtable of the table for the given key, creating it
+// when it does not exist. The last table of an array of tables is returned
+// when the key targets such an array, unless dotted is set as dotted keys
+// cannot extend arrays nor tables defined by a header.
+func (p *tomlParser) descend(table map[string]any, path, key string, dotted bool) (map[string]any, string, error) {
+	childPath := path + tomlKeySeparator + key
+
+	existing, exists := table[key]
+	if !exists {
+		child := make(map[string]any)
+		table[key] = child
+		if dotted {
+			p.dotted[childPath] = true
+		}
+		return child, childPath, nil
+	}
+
+	switch child := existing.(type) {
+	case map[string]any:
+		if p.frozen[childPath] {
+			return nil, "", p.errorf("inline table %q cannot be extended", key)
+		}
+		if dotted && p.explicit[childPath] {
+			return nil, "", p.errorf("table %q is already defined", key)
+		}
+		return child, childPath, nil
+	case []any:
+		if dotted || !p.arrays[childPath] || len(child) == 0 {
+			return nil, "", p.errorf("array %q cannot be extended", key)
+		}
+		last, _ := child[len(child)-1].(map[string]any)
+		return last, childPath + tomlKeySeparator + strconv.Itoa(len(child)-1), nil
+	default:
+		return nil, "", p.errorf("key %q is already defined as a value", key)
+	}
+}
+
+// parseKeyValue parses a `key = value` pair into the table.
+func (p *tomlParser) parseKeyValue(table map[string]any, path string) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	p.skipWhitespace()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key %q", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipWhitespace()
+
+	for _, key := range keys[:len(keys)-1] {
+		if table, path, err = p.descend(table, path, key, true); err != nil {
+			return err
+		}
+	}
+
+	last := keys[len(keys)-1]
+	if _, exists := table[last]; exists {
+		return p.errorf("key %q is already defined", strings.Join(keys, "."))
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	table[last] = value
+	switch value.(type) {
+	case map[string]any, []any:
+		p.frozen[path+tomlKeySeparator+last] = true
+	}
+	return nil
+}
+
+// parseKey parses a key made of bare or quoted parts separated by dots.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		var (
+			key string
+			err error
+		)
+
+		switch p.peek() {
+		case '"':
+			key, err = p.parseBasicString()
+		case '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && isTOMLBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("expected a key, found %q", p.peek())
+			}
+			key = p.input[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipWhitespace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+		p.skipWhitespace()
+	}
+}
+
+// parseValue parses any TOML value.
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineString(`"`)
+	case p.hasPrefix(`'''`):
+		return p.parseMultilineString(`'`)
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	case p.hasPrefix("true"):
+		p.pos += len("true")
+		return true, nil
+	case p.hasPrefix("false"):
+		p.pos += len("false")
+		return false, nil
+	}
+
+	start := p.pos
+	for !p.eof() && isTOMLScalarChar(p.peek()) {
+		p.pos++
+	}
+	// A space can separate the date and the time of a date-time
+	if tomlDatePrefixRegex.MatchString(p.input[start:p.pos]) && p.pos+3 < len(p.input) &&
+		p.input[p.pos] == ' ' && isDigit(p.input[p.pos+1]) && isDigit(p.input[p.pos+2]) && p.input[p.pos+3] == ':' {
+		p.pos++
+		for !p.eof() && isTOMLScalarChar(p.peek()) {
+			p.pos++
+		}
+	}
+
+	token := p.input[start:p.pos]
+	if token == "" {
+		return nil, p.errorf("expected a value, found %q", p.peek())
+	}
+
+	value, err := parseTOMLScalar(token)
+	if err != nil {
+		return nil, p.errorf("%s", err)
+	}
+	return value, nil
+}
+
+// parseBasicString parses a double-quoted string with escape sequences.
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // opening quote
+
+	var sb strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return sb.String(), nil
+		case '\\':
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseLiteralString parses a single-quoted string, without escaping.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // opening quote
+
+	end := strings.IndexAny(p.input[p.pos:], "'\n")
+	if end == -1 || p.input[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+
+	value := p.input[p.pos : p.pos+end]
+	p.pos += end + 1
+	return value, nil
+}
+
+// parseMultilineString parses a multi-line basic string when quote is `"`, or
+// a multi-line literal string when quote is `'`.
+func (p *tomlParser) parseMultilineString(quote string) (string, error) {
+	delimiter := strings.Repeat(quote, 3)
+	p.pos += len(delimiter)
+	// A newline immediately following the opening delimiter is trimmed
+	p.skipNewline()
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multi-line string")
+		}
+
+		if p.hasPrefix(delimiter) {
+			// Up to two quotes are allowed right before the closing delimiter
+			quotes := 0
+			for p.pos+quotes < len(p.input) && p.input[p.pos+quotes] == quote[0] {
+				quotes++
+			}
+			if quotes > 5 {
+				return "", p.errorf("too many quotes at the end of a multi-line string")
+			}
+			sb.WriteString(strings.Repeat(quote, quotes-3))
+			p.pos += quotes
+			return sb.String(), nil
+		}
+
+		c := p.peek()
+		if c == '\\' && quote == `"` {
+			if p.isLineEndingBackslash() {
+				p.pos++
+				for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+					p.pos++
+				}
+				continue
+			}
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		sb.WriteByte(c)
+		p.pos++
+	}
+}
+
+// isLineEndingBackslash reports whether the backslash at the current position
+// is only followed by whitespace up to the end of the line.
+func (p *tomlParser) isLineEndingBackslash() bool {
+	i := p.pos + 1
+	for i < len(p.input) && (p.input[i] == ' ' || p.input[i] == '\t') {
+		i++
+	}
+	return i < len(p.input) && (p.input[i] == '\n' || strings.HasPrefix(p.input[i:], "\r\n"))
+}
+
+// parseEscape parses an escape sequence of a basic string into sb.
+func (p *tomlParser) parseEscape(sb *strings.Builder) error {
+	p.pos++ // backslash
+	if p.eof() {
+		return p.errorf("unterminated escape sequence")
+	}
+
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		sb.WriteByte('\b')
+	case 't':
+		sb.WriteByte('\t')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'r':
+		sb.WriteByte('\r')
+	case 'e':
+		sb.WriteByte('\x1b')
+	case '"':
+		sb.WriteByte('"')
+	case '\\':
+		sb.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.input) {
+			return p.errorf("invalid unicode escape sequence")
+		}
+		code, err := strconv.ParseUint(p.input[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape sequence %q", p.input[p.pos-2:p.pos+size])
+		}
+		sb.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return p.errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// parseArray parses an array, which can span multiple lines.
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.pos++ // opening bracket
+
+	list := make([]any, 0)
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return list, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return list, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' in array, found %q", p.peek())
+		}
+	}
+}
+
+// parseInlineTable parses an inline table, which must fit on a single line.
+func (p *tomlParser) parseInlineTable() (map[string]any, error) {
+	p.pos++ // opening brace
+
+	table := make(map[string]any)
+	p.inlineSeq++
+	path := "\x01" + strconv.Itoa(p.inlineSeq)
+
+	p.skipWhitespace()
+	if p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+
+	for {
+		p.skipWhitespace()
+		if err := p.parseKeyValue(table, path); err != nil {
+			return nil, err
+		}
+
+		p.skipWhitespace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table, found %q", p.peek())
+		}
+	}
+}
+
+// parseTOMLScalar parses a number, a boolean or a date-time value.
+func parseTOMLScalar(token string) (any, error) {
+	switch {
+	case tomlDateTimeRegex.MatchString(token):
+		return parseTOMLDateTime(token)
+	case tomlLocalDateRegex.MatchString(token):
+		t, err := time.ParseInLocation(time.DateOnly, token, TOMLLocalDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", token)
+		}
+		return t, nil
+	case tomlLocalTimeRegex.MatchString(token):
+		t, err := time.ParseInLocation(time.TimeOnly, token, TOMLLocalTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", token)
+		}
+		return t, nil
+	}
+
+	switch token {
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	clean := strings.ReplaceAll(token, "_", "")
+	switch {
+	case tomlHexRegex.MatchString(token):
+		return parseTOMLInteger(token, clean[2:], 16)
+	case tomlOctalRegex.MatchString(token):
+		return parseTOMLInteger(token, clean[2:], 8)
+	case tomlBinaryRegex.MatchString(token):
+		return parseTOMLInteger(token, clean[2:], 2)
+	case tomlDecimalRegex.MatchString(token):
+		return parseTOMLInteger(token, clean, 10)
+	case tomlFloatRegex.MatchString(token):
+		f, err := strconv.ParseFloat(clean, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %q", token)
+		}
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %q", token)
+}
+
+// parseTOMLInteger parses a 64-bit integer in the given base.
+func parseTOMLInteger(token, digits string, base int) (int64, error) {
+	i, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", token)
+	}
+	return i, nil
+}
+
+// parseTOMLDateTime parses an offset date-time, or a local date-time when the
+// offset is missing.
+func parseTOMLDateTime(token string) (time.Time, error) {
+	normalized := []byte(token)
+	normalized[10] = 'T'
+	if last := len(normalized) - 1; normalized[last] == 'z' {
+		normalized[last] = 'Z'
+	}
+
+	layout, loc := time.RFC3339Nano, time.UTC
+	if !strings.ContainsAny(token[10:], "Zz+-") {
+		layout, loc = "2006-01-02T15:04:05.999999999", TOMLLocalDateTime
+	}
+
+	t, err := time.ParseInLocation(layout, string(normalized), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date-time %q", token)
+	}
+	return t, nil
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isTOMLScalarChar(c byte) bool {
+	return isTOMLBareKeyChar(c) || c == '+' || c == '.' || c == ':'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// tomlEncoder encodes Go values into a TOML document.
+type tomlEncoder struct {
+	sb     strings.Builder
+	indent string
+}
+
+// encodeTOML encodes the value, which must be a map or a struct, into a TOML
+// document. Nested tables are indented with the given indentation.
+func encodeTOML(value any, indent string) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "toml")
+	if err != nil {
+		return "", err
+	}
+
+	table, ok := normalized.(*orderedMap)
+	if !ok {
+		return "", fmt.Errorf("top-level value must be a map or a struct, got %T", value)
+	}
+
+	enc := &tomlEncoder{indent: indent}
+	if err := enc.writeTable(table, nil); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(enc.sb.String(), "\n"), nil
+}
+
+// isTOMLArrayOfTables reports whether the value is a non-empty list of tables.
+func isTOMLArrayOfTables(value any) bool {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, isTable := item.(*orderedMap); !isTable {
+			return false
+		}
+	}
+	return true
+}
+
+// writeTable writes the values of the table, then its subtables and arrays of
+// tables as sections, path being the keys of the table.
+func (e *tomlEncoder) writeTable(table *orderedMap, path []string) error {
+	indent := strings.Repeat(e.indent, len(path))
+
+	for _, key := range table.keys {
+		value := table.values[key]
+		if _, isTable := value.(*orderedMap); value == nil || isTable || isTOMLArrayOfTables(value) {
+			continue
+		}
+
+		inline, err := e.inlineValue(value)
+		if err != nil {
+			return err
+		}
+		e.sb.WriteString(indent + formatTOMLKey(key) + " = " + inline + "\n")
+	}
+
+	for _, key := range table.keys {
+		childPath := append(slices.Clone(path), key)
+
+		switch value := table.values[key].(type) {
+		case *orderedMap:
+			// A table holding only tables does not need its own header
+			if len(value.keys) > 0 && !hasTOMLValues(value) {
+				if err := e.writeTable(value, childPath); err != nil {
+					return err
+				}
+				continue
+			}
+			e.writeHeader("["+formatTOMLPath(childPath)+"]", indent)
+			if err := e.writeTable(value, childPath); err != nil {
+				return err
+			}
+		case []any:
+			if !isTOMLArrayOfTables(value) {
+				continue
+			}
+			for _, item := range value {
+				e.writeHeader("[["+formatTOMLPath(childPath)+"]]", indent)
+				if err := e.writeTable(item.(*orderedMap), childPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeHeader writes a table header, separated from the previous section by
+// an empty line.
+func (e *tomlEncoder) writeHeader(header, indent string) {
+	if e.sb.Len() > 0 {
+		e.sb.WriteString("\n")
+	}
+	e.sb.WriteString(indent + header + "\n")
+}
+
+// hasTOMLValues reports whether the table holds values written as key/value
+// pairs, and not only tables.
+func hasTOMLValues(table *orderedMap) bool {
+	for _, value := range table.values {
+		if _, isTable := value.(*orderedMap); value != nil && !isTable && !isTOMLArrayOfTables(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// inlineValue formats a value on a single line, tables being formatted as
+// inline tables.
+func (e *tomlEncoder) inlineValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quoteTOMLString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return formatTOMLFloat(v), nil
+	case time.Time:
+		return formatTOMLTime(v), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			if item == nil {
+				return "", errors.New("cannot encode a nil value in an array")
+			}
+			inline, err := e.inlineValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = inline
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case *orderedMap:
+		items := make([]string, 0, len(v.keys))
+		for _, key := range v.keys {
+			if v.values[key] == nil {
+				continue
+			}
+			inline, err := e.inlineValue(v.values[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatTOMLKey(key)+" = "+inline)
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("cannot encode a value of type %T", value)
+	}
+}
+
+// formatTOMLKey formats a key, quoting it when it is not a valid bare key.
+func formatTOMLKey(key string) string {
+	if tomlBareKeyRegex.MatchString(key) {
+		return key
+	}
+	return quoteTOMLString(key)
+}
+
+// formatTOMLPath formats the keys of a table header.
+func formatTOMLPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = formatTOMLKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// quoteTOMLString formats a basic string, escaping quotes, backslashes and
+// control characters.
+func quoteTOMLString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// formatTOMLFloat formats a float, always with a fractional part or an
+// exponent so it is decoded back as a float.
+func formatTOMLFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// formatTOMLTime formats a time according to its location: local date-time,
+// local date, local time, or offset date-time for any other location.
+func formatTOMLTime(t time.Time) string {
+	switch t.Location() {
+	case TOMLLocalDateTime:
+		return t.Format("2006-01-02T15:04:05.999999999")
+	case TOMLLocalDate:
+		return t.Format(time.DateOnly)
+	case TOMLLocalTime:
+		return t.Format("15:04:05.999999999")
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
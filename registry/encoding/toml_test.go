@@ -0,0 +1,237 @@
+package encoding
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	doc := `
+# A comment
+title = "TOML \"example\"" # trailing comment
+literal = 'C:\Users\nodejs'
+multiline = """
+Roses are red \
+  violets are blue"""
+rawMultiline = '''
+first line
+second line'''
+ints = [ 1_000, 0xff, 0o17, 0b101, -3 ]
+floats = [ 3.14, -1e3, 6.626e-34, inf, -inf ]
+bools = [true, false]
+"quoted key" = 1
+site."google.com" = true
+
+[owner]
+name = "Tom"
+dob = 1979-05-27T07:32:00-08:00
+utc = 1979-05-27 07:32:00.5Z
+local = 1979-05-27T07:32:00
+date = 1979-05-27
+time = 07:32:00
+
+[servers.alpha]
+ip = "10.0.0.1"
+point = { x = 1, y = 2 }
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+name = "Nail"
+[products.size]
+length = 3
+
+[fruit]
+apple.color = "red"
+apple.taste.sweet = true
+`
+
+	m, err := decodeTOML(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, `TOML "example"`, m["title"])
+	assert.Equal(t, `C:\Users\nodejs`, m["literal"])
+	assert.Equal(t, "Roses are red violets are blue", m["multiline"])
+	assert.Equal(t, "first line\nsecond line", m["rawMultiline"])
+	assert.Equal(t, []any{int64(1000), int64(255), int64(15), int64(5), int64(-3)}, m["ints"])
+	assert.Equal(t, []any{3.14, -1000.0, 6.626e-34, math.Inf(1), math.Inf(-1)}, m["floats"])
+	assert.Equal(t, []any{true, false}, m["bools"])
+	assert.Equal(t, int64(1), m["quoted key"])
+	assert.Equal(t, map[string]any{"google.com": true}, m["site"])
+
+	owner := m["owner"].(map[string]any)
+	assert.Equal(t, time.Date(1979, 5, 27, 15, 32, 0, 0, time.UTC), owner["dob"].(time.Time).UTC())
+	assert.Equal(t, time.Date(1979, 5, 27, 7, 32, 0, 500000000, time.UTC), owner["utc"])
+	assert.Equal(t, time.Date(1979, 5, 27, 7, 32, 0, 0, TOMLLocalDateTime), owner["local"])
+	assert.Equal(t, time.Date(1979, 5, 27, 0, 0, 0, 0, TOMLLocalDate), owner["date"])
+	assert.Equal(t, time.Date(0, 1, 1, 7, 32, 0, 0, TOMLLocalTime), owner["time"])
+
+	assert.Equal(t, map[string]any{"alpha": map[string]any{
+		"ip":    "10.0.0.1",
+		"point": map[string]any{"x": int64(1), "y": int64(2)},
+	}}, m["servers"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "Hammer"},
+		map[string]any{"name": "Nail", "size": map[string]any{"length": int64(3)}},
+	}, m["products"])
+	assert.Equal(t, map[string]any{"apple": map[string]any{"color": "red", "taste": map[string]any{"sweet": true}}}, m["fruit"])
+}
+
+func TestDecodeTOMLNaN(t *testing.T) {
+	m, err := decodeTOML("a = nan")
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(m["a"].(float64)))
+}
+
+func TestDecodeTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"DuplicateKey", "a = 1\na = 2", `line 2: key "a" is already defined`},
+		{"DuplicateTable", "[a]\n[a]", `line 2: table "a" is already defined`},
+		{"TableOverValue", "a = 1\n[a]", `table "a" is already defined`},
+		{"ExtendInlineTable", "a = { b = 1 }\n[a.c]", `inline table "a" cannot be extended`},
+		{"ExtendStaticArray", "a = [1]\n[[a]]", `key "a" is already defined and is not an array of tables`},
+		{"DottedKeyOverTable", "[a.b]\n[a]\nb.c = 1", `table "b" is already defined`},
+		{"TableOverDottedKey", "[a]\nb.c = 1\n[a.b]", `table "a.b" is already defined`},
+		{"MissingValue", "a = ", "expected a value"},
+		{"MissingEquals", "a 1", "expected '=' after key"},
+		{"InvalidNumber", "a = 01", `invalid value "01"`},
+		{"InvalidUnderscore", "a = 1__0", `invalid value "1__0"`},
+		{"IntegerOverflow", "a = 9223372036854775808", "invalid integer"},
+		{"InvalidDate", "a = 2021-13-01", "invalid date"},
+		{"UnterminatedString", `a = "abc`, "unterminated string"},
+		{"UnterminatedMultilineString", `a = """abc`, "unterminated multi-line string"},
+		{"InvalidEscape", `a = "\q"`, `invalid escape sequence \q`},
+		{"InvalidUnicodeEscape", `a = "\uD800"`, "invalid unicode escape sequence"},
+		{"UnterminatedArray", "a = [1, 2", "expected ',' or ']' in array"},
+		{"UnterminatedInlineTable", "a = { b = 1", "expected ',' or '}' in inline table"},
+		{"TrailingContent", "a = 1 b", "expected a new line"},
+		{"UnclosedHeader", "[a", `expected "]" to close the table header`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeTOML(test.input)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestEncodeTOML(t *testing.T) {
+	type server struct {
+		Host    string `toml:"host"`
+		Port    int    `toml:"port"`
+		Comment string `toml:"comment,omitempty"`
+		Secret  string `toml:"-"`
+	}
+
+	value := map[string]any{
+		"title":   "Example \"quoted\"\n",
+		"ratio":   2.0,
+		"enabled": true,
+		"nothing": nil,
+		"tags":    []string{"a", "b"},
+		"date":    time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+		"local":   time.Date(1979, 5, 27, 0, 0, 0, 0, TOMLLocalDate),
+		"server":  server{Host: "localhost", Port: 8080, Secret: "hidden"},
+		"owner": map[string]any{
+			"name": "Tom",
+			"points": []map[string]int{
+				{"x": 1},
+			},
+		},
+		"products":   []any{map[string]any{"name": "Hammer"}, map[string]any{"name": "Nail"}},
+		"nested":     map[string]any{"deeper": map[string]any{"key": "value"}},
+		"empty":      map[string]any{},
+		"quoted key": 1,
+	}
+
+	expected := `date = 1979-05-27T07:32:00Z
+enabled = true
+local = 1979-05-27
+"quoted key" = 1
+ratio = 2.0
+tags = ["a", "b"]
+title = "Example \"quoted\"\n"
+
+[empty]
+
+[nested.deeper]
+key = "value"
+
+[owner]
+name = "Tom"
+
+[[owner.points]]
+x = 1
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+name = "Nail"
+
+[server]
+host = "localhost"
+port = 8080`
+
+	out, err := encodeTOML(value, "")
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	decoded, err := decodeTOML(out)
+	require.NoError(t, err)
+	assert.Equal(t, "Tom", decoded["owner"].(map[string]any)["name"])
+	assert.Equal(t, time.Date(1979, 5, 27, 0, 0, 0, 0, TOMLLocalDate), decoded["local"])
+}
+
+func TestEncodeTOMLIndent(t *testing.T) {
+	value := map[string]any{
+		"a": map[string]any{"b": 1, "c": map[string]any{"d": 2}},
+	}
+
+	out, err := encodeTOML(value, "  ")
+	require.NoError(t, err)
+	assert.Equal(t, "[a]\n  b = 1\n\n  [a.c]\n    d = 2", out)
+}
+
+func TestEncodeTOMLInlineValues(t *testing.T) {
+	value := map[string]any{
+		"matrix": [][]any{{1, 2}, {map[string]any{"a": 1, "b": nil}}},
+		"floats": []float64{1e21, math.Inf(-1), math.NaN(), 0.5},
+		"times": []time.Time{
+			time.Date(1979, 5, 27, 7, 32, 0, 0, TOMLLocalDateTime),
+			time.Date(0, 1, 1, 7, 32, 0, 0, TOMLLocalTime),
+		},
+		"control": "\x01\t",
+	}
+
+	out, err := encodeTOML(value, "")
+	require.NoError(t, err)
+	assert.Equal(t, `control = "\u0001\t"
+floats = [1e+21, -inf, nan, 0.5]
+matrix = [[1, 2], [{ a = 1 }]]
+times = [1979-05-27T07:32:00, 07:32:00]`, out)
+}
+
+func TestEncodeTOMLErrors(t *testing.T) {
+	_, err := encodeTOML([]int{1}, "")
+	require.ErrorContains(t, err, "top-level value must be a map or a struct")
+
+	_, err = encodeTOML(map[string]any{"a": []any{nil}}, "")
+	require.ErrorContains(t, err, "cannot encode a nil value in an array")
+
+	_, err = encodeTOML(map[string]any{"a": make(chan int)}, "")
+	require.ErrorContains(t, err, "cannot encode a value of type chan int")
+
+	_, err = encodeTOML(map[string]any{"a": uint64(math.MaxUint64)}, "")
+	require.ErrorContains(t, err, "overflows an int64")
+}
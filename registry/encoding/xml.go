@@ -0,0 +1,378 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// xmlAttributePrefix prefixes the keys holding attributes in the map
+	// representation of an XML element.
+	xmlAttributePrefix = "@"
+	// xmlTextKey is the key holding the text of an XML element having
+	// attributes or children in its map representation.
+	xmlTextKey = "#text"
+)
+
+var xmlNameRegex = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_.:-]*$`)
+
+// xmlNodeKind is the kind of a node of an XML document, as defined by the
+// XPath data model.
+type xmlNodeKind int
+
+const (
+	xmlRootNode xmlNodeKind = iota
+	xmlElementNode
+	xmlAttributeNode
+	xmlTextNode
+	xmlCommentNode
+	xmlProcInstNode
+)
+
+// xmlNode is a node of a parsed XML document. Names are kept qualified as
+// written in the document, like `android:name`.
+type xmlNode struct {
+	kind     xmlNodeKind
+	name     string // element and attribute name, processing instruction target
+	value    string // attribute value, text, comment and processing instruction data
+	parent   *xmlNode
+	children []*xmlNode
+	attrs    []*xmlNode
+	order    int // position of the node in document order
+}
+
+// stringValue returns the string-value of the node: the concatenation of the
+// descendant texts for the root and elements, the value for other nodes.
+func (n *xmlNode) stringValue() string {
+	if n.kind != xmlRootNode && n.kind != xmlElementNode {
+		return n.value
+	}
+
+	var sb strings.Builder
+	var walk func(*xmlNode)
+	walk = func(node *xmlNode) {
+		for _, child := range node.children {
+			switch child.kind {
+			case xmlTextNode:
+				sb.WriteString(child.value)
+			case xmlElementNode:
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// documentElement returns the root element of the document.
+func (n *xmlNode) documentElement() *xmlNode {
+	for _, child := range n.children {
+		if child.kind == xmlElementNode {
+			return child
+		}
+	}
+	return nil
+}
+
+// parseXML parses an XML document into a tree of nodes.
+func parseXML(input string) (*xmlNode, error) {
+	dec := xml.NewDecoder(strings.NewReader(input))
+	root := &xmlNode{kind: xmlRootNode}
+	current := root
+	order := 0
+
+	appendNode := func(parent, node *xmlNode) {
+		order++
+		node.order = order
+		node.parent = parent
+		parent.children = append(parent.children, node)
+	}
+
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if current == root && root.documentElement() != nil {
+				return nil, fmt.Errorf("line %d: multiple root elements", lineOf(dec, input))
+			}
+			element := &xmlNode{kind: xmlElementNode, name: qualifiedXMLName(t.Name)}
+			appendNode(current, element)
+			for _, attr := range t.Attr {
+				order++
+				element.attrs = append(element.attrs, &xmlNode{
+					kind:   xmlAttributeNode,
+					name:   qualifiedXMLName(attr.Name),
+					value:  attr.Value,
+					parent: element,
+					order:  order,
+				})
+			}
+			current = element
+		case xml.EndElement:
+			if current == root || qualifiedXMLName(t.Name) != current.name {
+				return nil, fmt.Errorf("line %d: unexpected end element </%s>", lineOf(dec, input), qualifiedXMLName(t.Name))
+			}
+			current = current.parent
+		case xml.CharData:
+			if current == root {
+				if strings.TrimSpace(string(t)) != "" {
+					return nil, fmt.Errorf("line %d: text outside of the root element", lineOf(dec, input))
+				}
+				continue
+			}
+			if last := len(current.children) - 1; last >= 0 && current.children[last].kind == xmlTextNode {
+				current.children[last].value += string(t)
+				continue
+			}
+			appendNode(current, &xmlNode{kind: xmlTextNode, value: string(t)})
+		case xml.Comment:
+			appendNode(current, &xmlNode{kind: xmlCommentNode, value: string(t)})
+		case xml.ProcInst:
+			if t.Target != "xml" {
+				appendNode(current, &xmlNode{kind: xmlProcInstNode, name: t.Target, value: string(t.Inst)})
+			}
+		}
+	}
+
+	if current != root {
+		return nil, fmt.Errorf("unexpected end of document, element <%s> is not closed", current.name)
+	}
+	if root.documentElement() == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+// qualifiedXMLName returns the name as written in the document, with its
+// prefix when it has one.
+func qualifiedXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// lineOf returns the line of the current position of the decoder.
+func lineOf(dec *xml.Decoder, input string) int {
+	offset := min(int(dec.InputOffset()), len(input))
+	return strings.Count(input[:offset], "\n") + 1
+}
+
+// xmlNodeToValue converts an element to its map representation. An element
+// without attribute nor child element is converted to its trimmed text.
+// Otherwise, attributes are stored under keys prefixed by "@", children under
+// their name, as a list when repeated, and the text under "#text".
+func xmlNodeToValue(n *xmlNode) any {
+	var (
+		text     strings.Builder
+		elements []*xmlNode
+	)
+	for _, child := range n.children {
+		switch child.kind {
+		case xmlTextNode:
+			text.WriteString(child.value)
+		case xmlElementNode:
+			elements = append(elements, child)
+		}
+	}
+
+	trimmed := strings.TrimSpace(text.String())
+	if len(n.attrs) == 0 && len(elements) == 0 {
+		return trimmed
+	}
+
+	m := make(map[string]any, len(n.attrs)+len(elements)+1)
+	for _, attr := range n.attrs {
+		m[xmlAttributePrefix+attr.name] = attr.value
+	}
+	for _, element := range elements {
+		value := xmlNodeToValue(element)
+		switch existing := m[element.name].(type) {
+		case nil:
+			m[element.name] = value
+		case []any:
+			m[element.name] = append(existing, value)
+		default:
+			m[element.name] = []any{existing, value}
+		}
+	}
+	if trimmed != "" {
+		m[xmlTextKey] = trimmed
+	}
+	return m
+}
+
+// xmlOptions are the options of the XML encoder.
+type xmlOptions struct {
+	root   string
+	indent string
+	header bool
+}
+
+// parseXMLOptions reads the options of the XML encoder from a map.
+func parseXMLOptions(options map[string]any) (xmlOptions, error) {
+	var opts xmlOptions
+	for key, value := range options {
+		switch key {
+		case "root":
+			root, ok := value.(string)
+			if !ok {
+				return opts, fmt.Errorf("option %q must be a string, got %T", key, value)
+			}
+			opts.root = root
+		case "indent":
+			switch indent := value.(type) {
+			case string:
+				opts.indent = indent
+			case int:
+				opts.indent = strings.Repeat(" ", max(indent, 0))
+			default:
+				return opts, fmt.Errorf("option %q must be an int or a string, got %T", key, value)
+			}
+		case "header":
+			header, ok := value.(bool)
+			if !ok {
+				return opts, fmt.Errorf("option %q must be a bool, got %T", key, value)
+			}
+			opts.header = header
+		default:
+			return opts, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// encodeXML encodes the value into an XML document, using the map
+// representation of fromXML. Without root option, the value must be a map
+// holding a single element. The root element cannot hold a list, since a
+// document has a single root element.
+func encodeXML(value any, opts xmlOptions) (string, error) {
+	normalized, err := normalizeValue(reflect.ValueOf(value), "xml")
+	if err != nil {
+		return "", err
+	}
+
+	root := opts.root
+	if root == "" {
+		m, ok := normalized.(*orderedMap)
+		if !ok || len(m.keys) != 1 || isXMLSpecialKey(m.keys[0]) {
+			return "", errors.New("value must be a map with a single root element, or set the root option")
+		}
+		root, normalized = m.keys[0], m.values[m.keys[0]]
+	}
+	if _, isList := normalized.([]any); isList {
+		// A list would be written as repeated root elements, an invalid document
+		return "", fmt.Errorf("root element <%s> cannot hold a list, wrap the list in a map", root)
+	}
+
+	var buf bytes.Buffer
+	if opts.header {
+		buf.WriteString(xml.Header)
+		if opts.indent == "" {
+			buf.Truncate(buf.Len() - 1) // remove the trailing newline of the header
+		}
+	}
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", opts.indent)
+	if err := writeXMLElement(enc, root, normalized); err != nil {
+		return "", err
+	}
+	if err := enc.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeXMLElement writes an element with the given name and content. Lists
+// are written as repeated elements.
+func writeXMLElement(enc *xml.Encoder, name string, value any) error {
+	if !xmlNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid element name %q", name)
+	}
+
+	if list, ok := value.([]any); ok {
+		for _, item := range list {
+			if _, nested := item.([]any); nested {
+				return fmt.Errorf("cannot encode nested lists in element <%s>", name)
+			}
+			if err := writeXMLElement(enc, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	m, isMap := value.(*orderedMap)
+	if isMap {
+		for _, key := range m.keys {
+			attrName, isAttr := strings.CutPrefix(key, xmlAttributePrefix)
+			if !isAttr || m.values[key] == nil {
+				continue
+			}
+			if !xmlNameRegex.MatchString(attrName) {
+				return fmt.Errorf("invalid attribute name %q", attrName)
+			}
+			attrValue, err := formatScalar(m.values[key])
+			if err != nil {
+				return fmt.Errorf("attribute %q: %w", attrName, err)
+			}
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: attrValue})
+		}
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch {
+	case isMap:
+		if text, ok := m.values[xmlTextKey]; ok && text != nil {
+			chars, err := formatScalar(text)
+			if err != nil {
+				return fmt.Errorf("text of element <%s>: %w", name, err)
+			}
+			if err := enc.EncodeToken(xml.CharData(chars)); err != nil {
+				return err
+			}
+		}
+		for _, key := range m.keys {
+			if isXMLSpecialKey(key) {
+				continue
+			}
+			if err := writeXMLElement(enc, key, m.values[key]); err != nil {
+				return err
+			}
+		}
+	case value != nil:
+		chars, err := formatScalar(value)
+		if err != nil {
+			return fmt.Errorf("element <%s>: %w", name, err)
+		}
+		if err := enc.EncodeToken(xml.CharData(chars)); err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// isXMLSpecialKey reports whether the key holds an attribute or the text of an
+// element in its map representation.
+func isXMLSpecialKey(key string) bool {
+	return strings.HasPrefix(key, xmlAttributePrefix) || key == xmlTextKey
+}
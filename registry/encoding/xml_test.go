@@ -0,0 +1,180 @@
+package encoding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pomXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Maven project -->
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>io.sprout</groupId>
+  <version>1.2.3</version>
+  <dependencies>
+    <dependency scope="test">
+      <artifactId>junit</artifactId>
+    </dependency>
+    <dependency>
+      <artifactId>guava</artifactId>
+    </dependency>
+  </dependencies>
+  <description lang="en">A <![CDATA[<templating>]]> library &amp; more</description>
+  <empty/>
+</project>`
+
+func TestParseXMLToValue(t *testing.T) {
+	doc, err := parseXML(pomXML)
+	require.NoError(t, err)
+
+	root := doc.documentElement()
+	require.NotNil(t, root)
+	assert.Equal(t, "project", root.name)
+	assert.Equal(t, map[string]any{
+		"@xmlns":  "http://maven.apache.org/POM/4.0.0",
+		"groupId": "io.sprout",
+		"version": "1.2.3",
+		"dependencies": map[string]any{
+			"dependency": []any{
+				map[string]any{"@scope": "test", "artifactId": "junit"},
+				map[string]any{"artifactId": "guava"},
+			},
+		},
+		"description": map[string]any{"@lang": "en", "#text": "A <templating> library & more"},
+		"empty":       "",
+	}, xmlNodeToValue(root))
+}
+
+func TestParseXMLPrefixes(t *testing.T) {
+	doc, err := parseXML(`<manifest xmlns:android="http://schemas.android.com/apk/res/android"><application android:label="App"/></manifest>`)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"@xmlns:android": "http://schemas.android.com/apk/res/android",
+		"application":    map[string]any{"@android:label": "App"},
+	}, xmlNodeToValue(doc.documentElement()))
+}
+
+func TestParseXMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"Empty", "", "no root element"},
+		{"MultipleRoots", "<a/><b/>", "multiple root elements"},
+		{"MismatchedTags", "<a><b></a></b>", "unexpected end element </a>"},
+		{"UnclosedElement", "<a><b/>", "element <a> is not closed"},
+		{"TextOutsideRoot", "text<a/>", "text outside of the root element"},
+		{"InvalidSyntax", "<a", "unexpected EOF"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseXML(test.input)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestEncodeXML(t *testing.T) {
+	value := map[string]any{
+		"project": map[string]any{
+			"@xmlns":  "http://maven.apache.org/POM/4.0.0",
+			"version": "1.2.3",
+			"dependencies": map[string]any{
+				"dependency": []any{
+					map[string]any{"@scope": "test", "artifactId": "junit"},
+					map[string]any{"artifactId": "guava", "optional": true},
+				},
+			},
+			"description": map[string]any{"@lang": "en", "#text": "A <templating> library"},
+			"empty":       nil,
+		},
+	}
+
+	out, err := encodeXML(value, xmlOptions{indent: "  ", header: true})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <dependencies>
+    <dependency scope="test">
+      <artifactId>junit</artifactId>
+    </dependency>
+    <dependency>
+      <artifactId>guava</artifactId>
+      <optional>true</optional>
+    </dependency>
+  </dependencies>
+  <description lang="en">A &lt;templating&gt; library</description>
+  <empty></empty>
+  <version>1.2.3</version>
+</project>`, out)
+
+	doc, err := parseXML(out)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", xmlNodeToValue(doc.documentElement()).(map[string]any)["version"])
+}
+
+func TestEncodeXMLWithRoot(t *testing.T) {
+	type item struct {
+		Name  string    `xml:"name"`
+		Price float64   `xml:"price"`
+		Date  time.Time `xml:"date"`
+		Skip  string    `xml:"-"`
+	}
+
+	out, err := encodeXML(item{Name: "a", Price: 1.5, Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, xmlOptions{root: "item"})
+	require.NoError(t, err)
+	assert.Equal(t, `<item><name>a</name><price>1.5</price><date>2024-01-02T03:04:05Z</date></item>`, out)
+
+	out, err = encodeXML("text", xmlOptions{root: "value"})
+	require.NoError(t, err)
+	assert.Equal(t, `<value>text</value>`, out)
+}
+
+func TestEncodeXMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		opts  xmlOptions
+		err   string
+	}{
+		{"NoRoot", map[string]any{"a": 1, "b": 2}, xmlOptions{}, "value must be a map with a single root element"},
+		{"ScalarWithoutRoot", "text", xmlOptions{}, "value must be a map with a single root element"},
+		{"InvalidElementName", map[string]any{"a b": 1}, xmlOptions{}, `invalid element name "a b"`},
+		{"InvalidAttributeName", map[string]any{"a": map[string]any{"@b c": 1}}, xmlOptions{}, `invalid attribute name "b c"`},
+		{"RootList", []any{1, 2}, xmlOptions{root: "a"}, "root element <a> cannot hold a list"},
+		{"NestedLists", map[string]any{"a": map[string]any{"b": []any{[]any{1}}}}, xmlOptions{}, "cannot encode nested lists in element <b>"},
+		{"MapAttribute", map[string]any{"a": map[string]any{"@b": map[string]any{}}}, xmlOptions{}, `attribute "b": cannot encode a value of type *encoding.orderedMap as text`},
+		{"InvalidValue", map[string]any{"a": make(chan int)}, xmlOptions{}, "cannot encode a value of type chan int"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := encodeXML(test.value, test.opts)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestParseXMLOptions(t *testing.T) {
+	opts, err := parseXMLOptions(map[string]any{"root": "project", "indent": 2, "header": true})
+	require.NoError(t, err)
+	assert.Equal(t, xmlOptions{root: "project", indent: "  ", header: true}, opts)
+
+	opts, err = parseXMLOptions(map[string]any{"indent": "\t"})
+	require.NoError(t, err)
+	assert.Equal(t, "\t", opts.indent)
+
+	_, err = parseXMLOptions(map[string]any{"root": 1})
+	require.ErrorContains(t, err, `option "root" must be a string`)
+	_, err = parseXMLOptions(map[string]any{"indent": true})
+	require.ErrorContains(t, err, `option "indent" must be an int or a string`)
+	_, err = parseXMLOptions(map[string]any{"header": "yes"})
+	require.ErrorContains(t, err, `option "header" must be a bool`)
+	_, err = parseXMLOptions(map[string]any{"unknown": 1})
+	require.ErrorContains(t, err, `unknown option "unknown"`)
+}
@@ -0,0 +1,1233 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// xpathNumberRegex matches the strings convertible to an XPath number.
+var xpathNumberRegex = regexp.MustCompile(`^-?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// xpathNodeSet is a set of nodes, kept in document order without duplicates.
+type xpathNodeSet []*xmlNode
+
+// xpathContext is the evaluation context of an expression.
+type xpathContext struct {
+	node     *xmlNode
+	position int
+	size     int
+}
+
+// xpathExpr is a compiled XPath expression. Its evaluation returns an
+// xpathNodeSet, a string, a float64 or a bool.
+type xpathExpr interface {
+	eval(ctx xpathContext) (any, error)
+}
+
+// evaluateXPath compiles and evaluates the expression against the document.
+func evaluateXPath(expression string, doc *xmlNode) (any, error) {
+	expr, err := compileXPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	return expr.eval(xpathContext{node: doc, position: 1, size: 1})
+}
+
+// compileXPath parses an XPath 1.0 expression.
+func compileXPath(expression string) (xpathExpr, error) {
+	tokens, err := tokenizeXPath(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &xpathParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.at(xpathEOF, "") {
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+	return expr, nil
+}
+
+// xpathTokenKind is the kind of a token of an XPath expression.
+type xpathTokenKind int
+
+const (
+	xpathEOF xpathTokenKind = iota
+	xpathNumber
+	xpathLiteral
+	xpathName     // QName, NCName:* or *, used as a name test or a function name
+	xpathOperator // operators, including the operator names and, or, mod and div
+	xpathSymbol   // ( ) [ ] . .. @ , ::
+	xpathVariable
+)
+
+type xpathToken struct {
+	kind xpathTokenKind
+	text string
+}
+
+// tokenizeXPath splits an expression into tokens, applying the disambiguation
+// rules of XPath 1.0 for `*` and operator names.
+func tokenizeXPath(expression string) ([]xpathToken, error) {
+	var tokens []xpathToken
+
+	// precedesOperator reports whether `*` and names are operators at this
+	// point, i.e. when there is a preceding token which is not one of
+	// @, ::, (, [, , or an operator.
+	precedesOperator := func() bool {
+		if len(tokens) == 0 {
+			return false
+		}
+		last := tokens[len(tokens)-1]
+		if last.kind == xpathOperator {
+			return false
+		}
+		return !(last.kind == xpathSymbol && slices.Contains([]string{"@", "::", "(", "[", ","}, last.text))
+	}
+
+	i := 0
+	for i < len(expression) {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expression[i+1:], c)
+			if end == -1 {
+				return nil, errors.New("unterminated string literal")
+			}
+			tokens = append(tokens, xpathToken{xpathLiteral, expression[i+1 : i+1+end]})
+			i += end + 2
+		case isDigit(c) || (c == '.' && i+1 < len(expression) && isDigit(expression[i+1])):
+			start := i
+			for i < len(expression) && (isDigit(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, xpathToken{xpathNumber, expression[start:i]})
+		case strings.HasPrefix(expression[i:], ".."):
+			tokens = append(tokens, xpathToken{xpathSymbol, ".."})
+			i += 2
+		case strings.HasPrefix(expression[i:], "::"):
+			tokens = append(tokens, xpathToken{xpathSymbol, "::"})
+			i += 2
+		case strings.ContainsRune("()[].@,", rune(c)):
+			tokens = append(tokens, xpathToken{xpathSymbol, string(c)})
+			i++
+		case strings.HasPrefix(expression[i:], "//"), strings.HasPrefix(expression[i:], "!="),
+			strings.HasPrefix(expression[i:], "<="), strings.HasPrefix(expression[i:], ">="):
+			tokens = append(tokens, xpathToken{xpathOperator, expression[i : i+2]})
+			i += 2
+		case strings.ContainsRune("/|+-=<>", rune(c)):
+			tokens = append(tokens, xpathToken{xpathOperator, string(c)})
+			i++
+		case c == '*':
+			kind := xpathName
+			if precedesOperator() {
+				kind = xpathOperator
+			}
+			tokens = append(tokens, xpathToken{kind, "*"})
+			i++
+		case c == '$':
+			name, size := scanXPathName(expression[i+1:])
+			if name == "" {
+				return nil, errors.New("invalid variable reference")
+			}
+			tokens = append(tokens, xpathToken{xpathVariable, name})
+			i += size + 1
+		default:
+			name, size := scanXPathName(expression[i:])
+			if name == "" {
+				r, _ := utf8.DecodeRuneInString(expression[i:])
+				return nil, fmt.Errorf("unexpected character %q", r)
+			}
+			i += size
+			// A name followed by ":*" is a name test on a prefix
+			if strings.HasPrefix(expression[i:], ":*") {
+				name += ":*"
+				i += 2
+			}
+			kind := xpathName
+			if precedesOperator() && slices.Contains([]string{"and", "or", "mod", "div"}, name) {
+				kind = xpathOperator
+			}
+			tokens = append(tokens, xpathToken{kind, name})
+		}
+	}
+
+	return append(tokens, xpathToken{xpathEOF, ""}), nil
+}
+
+// scanXPathName scans a QName at the start of s and returns it with its size.
+func scanXPathName(s string) (string, int) {
+	size := scanNCName(s)
+	if size == 0 {
+		return "", 0
+	}
+	// A single colon followed by a NCName makes a QName
+	if size < len(s)-1 && s[size] == ':' && s[size+1] != ':' {
+		if local := scanNCName(s[size+1:]); local > 0 {
+			size += local + 1
+		}
+	}
+	return s[:size], size
+}
+
+// scanNCName returns the size of the NCName at the start of s.
+func scanNCName(s string) int {
+	size := 0
+	for size < len(s) {
+		r, width := utf8.DecodeRuneInString(s[size:])
+		valid := unicode.IsLetter(r) || r == '_'
+		if size > 0 {
+			valid = valid || unicode.IsDigit(r) || r == '-' || r == '.'
+		}
+		if !valid {
+			break
+		}
+		size += width
+	}
+	return size
+}
+
+// xpathParser is a recursive descent parser of XPath 1.0 expressions.
+type xpathParser struct {
+	tokens []xpathToken
+	pos    int
+}
+
+func (p *xpathParser) peek() xpathToken {
+	return p.tokens[p.pos]
+}
+
+func (p *xpathParser) peekAt(offset int) xpathToken {
+	if p.pos+offset >= len(p.tokens) {
+		return xpathToken{kind: xpathEOF}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *xpathParser) next() xpathToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != xpathEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// at reports whether the current token has the given kind and text, any text
+// matching when text is empty.
+func (p *xpathParser) at(kind xpathTokenKind, text string) bool {
+	tok := p.peek()
+	return tok.kind == kind && (text == "" || tok.text == text)
+}
+
+func (p *xpathParser) expect(kind xpathTokenKind, text string) error {
+	if !p.at(kind, text) {
+		if p.peek().kind == xpathEOF {
+			return fmt.Errorf("expected %q, found end of expression", text)
+		}
+		return fmt.Errorf("expected %q, found %q", text, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+// parseBinary parses a left-associative sequence of operands separated by the
+// given operators.
+func (p *xpathParser) parseBinary(operand func() (xpathExpr, error), operators ...string) (xpathExpr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.at(xpathOperator, "") && slices.Contains(operators, p.peek().text) {
+		op := p.next().text
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &xpathBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *xpathParser) parseOr() (xpathExpr, error) {
+	return p.parseBinary(p.parseAnd, "or")
+}
+
+func (p *xpathParser) parseAnd() (xpathExpr, error) {
+	return p.parseBinary(p.parseEquality, "and")
+}
+
+func (p *xpathParser) parseEquality() (xpathExpr, error) {
+	return p.parseBinary(p.parseRelational, "=", "!=")
+}
+
+func (p *xpathParser) parseRelational() (xpathExpr, error) {
+	return p.parseBinary(p.parseAdditive, "<", "<=", ">", ">=")
+}
+
+func (p *xpathParser) parseAdditive() (xpathExpr, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *xpathParser) parseMultiplicative() (xpathExpr, error) {
+	return p.parseBinary(p.parseUnary, "*", "div", "mod")
+}
+
+func (p *xpathParser) parseUnary() (xpathExpr, error) {
+	if p.at(xpathOperator, "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &xpathNegate{operand: operand}, nil
+	}
+	return p.parseBinary(p.parsePath, "|")
+}
+
+// parsePath parses a location path, or a filter expression optionally
+// followed by a relative location path.
+func (p *xpathParser) parsePath() (xpathExpr, error) {
+	tok := p.peek()
+	isFilter := tok.kind == xpathNumber || tok.kind == xpathLiteral || tok.kind == xpathVariable ||
+		(tok.kind == xpathSymbol && tok.text == "(") ||
+		(tok.kind == xpathName && p.peekAt(1).text == "(" && !isXPathNodeType(tok.text))
+	if !isFilter {
+		return p.parseLocationPath()
+	}
+
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	predicates, err := p.parsePredicates()
+	if err != nil {
+		return nil, err
+	}
+
+	path := &xpathPath{filter: primary, filterPredicates: predicates}
+	if !p.at(xpathOperator, "/") && !p.at(xpathOperator, "//") {
+		if len(predicates) == 0 {
+			return primary, nil
+		}
+		return path, nil
+	}
+
+	if path.steps, err = p.parseRelativeSteps(); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// parseLocationPath parses an absolute or relative location path.
+func (p *xpathParser) parseLocationPath() (xpathExpr, error) {
+	path := &xpathPath{}
+
+	switch {
+	case p.at(xpathOperator, "/"):
+		p.next()
+		path.absolute = true
+		if !p.startsStep() {
+			return path, nil
+		}
+	case p.at(xpathOperator, "//"):
+		p.next()
+		path.absolute = true
+		path.steps = append(path.steps, descendantOrSelfStep())
+	}
+
+	step, err := p.parseStep()
+	if err != nil {
+		return nil, err
+	}
+	path.steps = append(path.steps, step)
+
+	steps, err := p.parseRelativeSteps()
+	if err != nil {
+		return nil, err
+	}
+	path.steps = append(path.steps, steps...)
+	return path, nil
+}
+
+// parseRelativeSteps parses the steps following a `/` or `//` operator.
+func (p *xpathParser) parseRelativeSteps() ([]*xpathStep, error) {
+	var steps []*xpathStep
+	for p.at(xpathOperator, "/") || p.at(xpathOperator, "//") {
+		if p.next().text == "//" {
+			steps = append(steps, descendantOrSelfStep())
+		}
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// startsStep reports whether the current token can start a location step.
+func (p *xpathParser) startsStep() bool {
+	tok := p.peek()
+	return tok.kind == xpathName || (tok.kind == xpathSymbol && slices.Contains([]string{".", "..", "@"}, tok.text))
+}
+
+// parseStep parses a location step with its predicates.
+func (p *xpathParser) parseStep() (*xpathStep, error) {
+	switch {
+	case p.at(xpathSymbol, "."):
+		p.next()
+		return &xpathStep{axis: "self", test: xpathNodeTest{nodeType: "node"}}, nil
+	case p.at(xpathSymbol, ".."):
+		p.next()
+		return &xpathStep{axis: "parent", test: xpathNodeTest{nodeType: "node"}}, nil
+	}
+
+	step := &xpathStep{axis: "child"}
+	if p.at(xpathSymbol, "@") {
+		p.next()
+		step.axis = "attribute"
+	} else if p.peek().kind == xpathName && p.peekAt(1).text == "::" {
+		step.axis = p.next().text
+		p.next()
+		if !slices.Contains(xpathAxes, step.axis) {
+			return nil, fmt.Errorf("unknown axis %q", step.axis)
+		}
+	}
+
+	if !p.at(xpathName, "") {
+		if p.peek().kind == xpathEOF {
+			return nil, errors.New("expected a node test, found end of expression")
+		}
+		return nil, fmt.Errorf("expected a node test, found %q", p.peek().text)
+	}
+	name := p.next().text
+
+	if isXPathNodeType(name) && p.at(xpathSymbol, "(") {
+		p.next()
+		step.test.nodeType = name
+		if name == "processing-instruction" && p.at(xpathLiteral, "") {
+			step.test.name = p.next().text
+		}
+		if err := p.expect(xpathSymbol, ")"); err != nil {
+			return nil, err
+		}
+	} else {
+		step.test.name = name
+	}
+
+	var err error
+	step.predicates, err = p.parsePredicates()
+	return step, err
+}
+
+// parsePredicates parses a sequence of predicates.
+func (p *xpathParser) parsePredicates() ([]xpathExpr, error) {
+	var predicates []xpathExpr
+	for p.at(xpathSymbol, "[") {
+		p.next()
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(xpathSymbol, "]"); err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	return predicates, nil
+}
+
+// parsePrimary parses a literal, a number, a parenthesized expression or a
+// function call.
+func (p *xpathParser) parsePrimary() (xpathExpr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case xpathLiteral:
+		return xpathLiteralExpr(tok.text), nil
+	case xpathNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", tok.text)
+		}
+		return xpathNumberExpr(f), nil
+	case xpathVariable:
+		return nil, fmt.Errorf("variable $%s is not defined, variables are not supported", tok.text)
+	case xpathSymbol:
+		// opening parenthesis
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(xpathSymbol, ")")
+	}
+
+	fn, ok := xpathFunctions[tok.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s()", tok.text)
+	}
+	p.next() // opening parenthesis
+
+	call := &xpathCall{name: tok.text, fn: fn}
+	for !p.at(xpathSymbol, ")") {
+		if len(call.args) > 0 {
+			if err := p.expect(xpathSymbol, ","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next() // closing parenthesis
+
+	if len(call.args) < fn.minArgs || (fn.maxArgs >= 0 && len(call.args) > fn.maxArgs) {
+		return nil, fmt.Errorf("wrong number of arguments for %s(): %d", tok.text, len(call.args))
+	}
+	return call, nil
+}
+
+func isXPathNodeType(name string) bool {
+	return name == "node" || name == "text" || name == "comment" || name == "processing-instruction"
+}
+
+// xpathLiteralExpr is a string literal.
+type xpathLiteralExpr string
+
+func (e xpathLiteralExpr) eval(xpathContext) (any, error) {
+	return string(e), nil
+}
+
+// xpathNumberExpr is a number literal.
+type xpathNumberExpr float64
+
+func (e xpathNumberExpr) eval(xpathContext) (any, error) {
+	return float64(e), nil
+}
+
+// xpathNegate is the unary minus operator.
+type xpathNegate struct {
+	operand xpathExpr
+}
+
+func (e *xpathNegate) eval(ctx xpathContext) (any, error) {
+	value, err := e.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return -xpathToNumber(value), nil
+}
+
+// xpathBinary is a binary operator.
+type xpathBinary struct {
+	op          string
+	left, right xpathExpr
+}
+
+func (e *xpathBinary) eval(ctx xpathContext) (any, error) {
+	left, err := e.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Boolean operators are short-circuited
+	switch e.op {
+	case "or":
+		if xpathToBool(left) {
+			return true, nil
+		}
+	case "and":
+		if !xpathToBool(left) {
+			return false, nil
+		}
+	}
+
+	right, err := e.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "or", "and":
+		return xpathToBool(right), nil
+	case "=", "!=", "<", "<=", ">", ">=":
+		return xpathCompare(e.op, left, right), nil
+	case "|":
+		leftNodes, leftOK := left.(xpathNodeSet)
+		rightNodes, rightOK := right.(xpathNodeSet)
+		if !leftOK || !rightOK {
+			return nil, errors.New("operands of | must be node-sets")
+		}
+		return sortNodeSet(append(slices.Clone(leftNodes), rightNodes...)), nil
+	}
+
+	l, r := xpathToNumber(left), xpathToNumber(right)
+	switch e.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "div":
+		return l / r, nil
+	default: // mod
+		return math.Mod(l, r), nil
+	}
+}
+
+// xpathCompare compares two values according to the XPath 1.0 rules.
+func xpathCompare(op string, left, right any) bool {
+	leftNodes, leftIsNodes := left.(xpathNodeSet)
+	rightNodes, rightIsNodes := right.(xpathNodeSet)
+
+	switch {
+	case leftIsNodes && rightIsNodes:
+		for _, l := range leftNodes {
+			for _, r := range rightNodes {
+				if xpathCompare(op, l.stringValue(), r.stringValue()) {
+					return true
+				}
+			}
+		}
+		return false
+	case leftIsNodes || rightIsNodes:
+		nodes, other := leftNodes, right
+		if rightIsNodes {
+			nodes, other = rightNodes, left
+		}
+		if b, isBool := other.(bool); isBool {
+			return compareXPathValues(op, len(nodes) > 0, b, rightIsNodes)
+		}
+		for _, node := range nodes {
+			var value any = node.stringValue()
+			if _, isNumber := other.(float64); isNumber {
+				value = xpathToNumber(value)
+			}
+			if compareXPathValues(op, value, other, rightIsNodes) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return compareXPathValues(op, left, right, false)
+}
+
+// compareXPathValues compares two values which are not node-sets. When
+// swapped is set, the operands are compared in reverse order.
+func compareXPathValues(op string, left, right any, swapped bool) bool {
+	if swapped {
+		left, right = right, left
+	}
+
+	if op == "=" || op == "!=" {
+		var equal bool
+		_, leftIsBool := left.(bool)
+		_, rightIsBool := right.(bool)
+		_, leftIsNumber := left.(float64)
+		_, rightIsNumber := right.(float64)
+		switch {
+		case leftIsBool || rightIsBool:
+			equal = xpathToBool(left) == xpathToBool(right)
+		case leftIsNumber || rightIsNumber:
+			equal = xpathToNumber(left) == xpathToNumber(right)
+		default:
+			equal = xpathToString(left) == xpathToString(right)
+		}
+		return equal == (op == "=")
+	}
+
+	l, r := xpathToNumber(left), xpathToNumber(right)
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// xpathAxes are the supported axes.
+var xpathAxes = []string{
+	"ancestor", "ancestor-or-self", "attribute", "child", "descendant", "descendant-or-self",
+	"following", "following-sibling", "namespace", "parent", "preceding", "preceding-sibling", "self",
+}
+
+// xpathNodeTest is the node test of a step: a name test, or a node type test
+// when nodeType is set.
+type xpathNodeTest struct {
+	name     string
+	nodeType string
+}
+
+// matches reports whether the node passes the test on the given axis.
+func (t xpathNodeTest) matches(node *xmlNode, axis string) bool {
+	switch t.nodeType {
+	case "node":
+		return true
+	case "text":
+		return node.kind == xmlTextNode
+	case "comment":
+		return node.kind == xmlCommentNode
+	case "processing-instruction":
+		return node.kind == xmlProcInstNode && (t.name == "" || t.name == node.name)
+	}
+
+	principal := xmlElementNode
+	if axis == "attribute" {
+		principal = xmlAttributeNode
+	}
+	if node.kind != principal {
+		return false
+	}
+
+	switch {
+	case t.name == "*":
+		return true
+	case strings.HasSuffix(t.name, ":*"):
+		return strings.HasPrefix(node.name, strings.TrimSuffix(t.name, "*"))
+	default:
+		return node.name == t.name
+	}
+}
+
+// xpathStep is a location step.
+type xpathStep struct {
+	axis       string
+	test       xpathNodeTest
+	predicates []xpathExpr
+}
+
+func descendantOrSelfStep() *xpathStep {
+	return &xpathStep{axis: "descendant-or-self", test: xpathNodeTest{nodeType: "node"}}
+}
+
+// apply evaluates the step from every node of the node-set.
+func (s *xpathStep) apply(nodes xpathNodeSet) (xpathNodeSet, error) {
+	var result xpathNodeSet
+	for _, node := range nodes {
+		var candidates xpathNodeSet
+		for _, candidate := range xpathAxisNodes(node, s.axis) {
+			if s.test.matches(candidate, s.axis) {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		filtered, err := filterNodes(candidates, s.predicates)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, filtered...)
+	}
+	return sortNodeSet(result), nil
+}
+
+// filterNodes filters the nodes, given in the order of their axis, with the
+// predicates. A numeric predicate selects the node at this position.
+func filterNodes(nodes xpathNodeSet, predicates []xpathExpr) (xpathNodeSet, error) {
+	for _, predicate := range predicates {
+		var kept xpathNodeSet
+		for i, node := range nodes {
+			value, err := predicate.eval(xpathContext{node: node, position: i + 1, size: len(nodes)})
+			if err != nil {
+				return nil, err
+			}
+
+			keep := xpathToBool(value)
+			if number, isNumber := value.(float64); isNumber {
+				keep = number == float64(i+1)
+			}
+			if keep {
+				kept = append(kept, node)
+			}
+		}
+		nodes = kept
+	}
+	return nodes, nil
+}
+
+// xpathAxisNodes returns the nodes of the axis from the node, in the order of
+// the axis: reverse document order for reverse axes.
+func xpathAxisNodes(node *xmlNode, axis string) xpathNodeSet {
+	switch axis {
+	case "self":
+		return xpathNodeSet{node}
+	case "child":
+		if node.kind == xmlAttributeNode {
+			return nil
+		}
+		return node.children
+	case "attribute":
+		return node.attrs
+	case "parent":
+		if node.parent == nil {
+			return nil
+		}
+		return xpathNodeSet{node.parent}
+	case "ancestor", "ancestor-or-self":
+		var nodes xpathNodeSet
+		if axis == "ancestor-or-self" {
+			nodes = append(nodes, node)
+		}
+		for parent := node.parent; parent != nil; parent = parent.parent {
+			nodes = append(nodes, parent)
+		}
+		return nodes
+	case "descendant", "descendant-or-self":
+		var nodes xpathNodeSet
+		if axis == "descendant-or-self" {
+			nodes = append(nodes, node)
+		}
+		return appendDescendants(nodes, node)
+	case "following-sibling", "preceding-sibling":
+		if node.parent == nil || node.kind == xmlAttributeNode {
+			return nil
+		}
+		siblings := node.parent.children
+		idx := slices.Index(siblings, node)
+		if axis == "following-sibling" {
+			return siblings[idx+1:]
+		}
+		preceding := slices.Clone(siblings[:idx])
+		slices.Reverse(preceding)
+		return preceding
+	case "following", "preceding":
+		root := node
+		for root.parent != nil {
+			root = root.parent
+		}
+		var nodes xpathNodeSet
+		for _, candidate := range appendDescendants(nil, root) {
+			if axis == "following" && candidate.order > node.order && !isXPathAncestor(node, candidate) {
+				nodes = append(nodes, candidate)
+			}
+			if axis == "preceding" && candidate.order < node.order && !isXPathAncestor(candidate, node) {
+				nodes = append(nodes, candidate)
+			}
+		}
+		if axis == "preceding" {
+			slices.Reverse(nodes)
+		}
+		return nodes
+	default: // namespace nodes are not supported
+		return nil
+	}
+}
+
+// appendDescendants appends the descendants of the node in document order,
+// attributes excluded.
+func appendDescendants(nodes xpathNodeSet, node *xmlNode) xpathNodeSet {
+	for _, child := range node.children {
+		nodes = append(nodes, child)
+		nodes = appendDescendants(nodes, child)
+	}
+	return nodes
+}
+
+// isXPathAncestor reports whether ancestor is an ancestor of node.
+func isXPathAncestor(ancestor, node *xmlNode) bool {
+	for parent := node.parent; parent != nil; parent = parent.parent {
+		if parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// sortNodeSet sorts the nodes in document order and removes duplicates.
+func sortNodeSet(nodes xpathNodeSet) xpathNodeSet {
+	slices.SortFunc(nodes, func(a, b *xmlNode) int { return a.order - b.order })
+	return slices.Compact(nodes)
+}
+
+// xpathPath is a location path, optionally starting from a filter expression.
+type xpathPath struct {
+	filter           xpathExpr
+	filterPredicates []xpathExpr
+	absolute         bool
+	steps            []*xpathStep
+}
+
+func (e *xpathPath) eval(ctx xpathContext) (any, error) {
+	var nodes xpathNodeSet
+	switch {
+	case e.filter != nil:
+		value, err := e.filter.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if nodes, ok = value.(xpathNodeSet); !ok {
+			return nil, errors.New("predicates and paths can only be applied to node-sets")
+		}
+		if nodes, err = filterNodes(nodes, e.filterPredicates); err != nil {
+			return nil, err
+		}
+	case e.absolute:
+		root := ctx.node
+		for root.parent != nil {
+			root = root.parent
+		}
+		nodes = xpathNodeSet{root}
+	default:
+		nodes = xpathNodeSet{ctx.node}
+	}
+
+	for _, step := range e.steps {
+		var err error
+		if nodes, err = step.apply(nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// xpathFunction is a function of the XPath core function library.
+type xpathFunction struct {
+	minArgs, maxArgs int // maxArgs is -1 for variadic functions
+	call             func(ctx xpathContext, args []any) (any, error)
+}
+
+// xpathCall is a function call.
+type xpathCall struct {
+	name string
+	fn   xpathFunction
+	args []xpathExpr
+}
+
+func (e *xpathCall) eval(ctx xpathContext) (any, error) {
+	args := make([]any, len(e.args))
+	for i, arg := range e.args {
+		value, err := arg.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	result, err := e.fn.call(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %w", e.name, err)
+	}
+	return result, nil
+}
+
+// xpathFunctions is the XPath 1.0 core function library.
+var xpathFunctions = map[string]xpathFunction{
+	// Node-set functions
+	"last":     {0, 0, func(ctx xpathContext, _ []any) (any, error) { return float64(ctx.size), nil }},
+	"position": {0, 0, func(ctx xpathContext, _ []any) (any, error) { return float64(ctx.position), nil }},
+	"count": {1, 1, func(_ xpathContext, args []any) (any, error) {
+		nodes, err := xpathArgNodes(args[0])
+		return float64(len(nodes)), err
+	}},
+	"id": {1, 1, xpathID},
+	"local-name": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		name, err := xpathNodeName(ctx, args)
+		_, local, found := strings.Cut(name, ":")
+		if !found {
+			local = name
+		}
+		return local, err
+	}},
+	"name": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		return xpathNodeName(ctx, args)
+	}},
+	"namespace-uri": {0, 1, xpathNamespaceURI},
+
+	// String functions
+	"string": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		return xpathToString(xpathArgOrContext(ctx, args)), nil
+	}},
+	"concat": {2, -1, func(_ xpathContext, args []any) (any, error) {
+		var sb strings.Builder
+		for _, arg := range args {
+			sb.WriteString(xpathToString(arg))
+		}
+		return sb.String(), nil
+	}},
+	"starts-with": {2, 2, func(_ xpathContext, args []any) (any, error) {
+		return strings.HasPrefix(xpathToString(args[0]), xpathToString(args[1])), nil
+	}},
+	"contains": {2, 2, func(_ xpathContext, args []any) (any, error) {
+		return strings.Contains(xpathToString(args[0]), xpathToString(args[1])), nil
+	}},
+	"substring-before": {2, 2, func(_ xpathContext, args []any) (any, error) {
+		before, _, found := strings.Cut(xpathToString(args[0]), xpathToString(args[1]))
+		if !found {
+			return "", nil
+		}
+		return before, nil
+	}},
+	"substring-after": {2, 2, func(_ xpathContext, args []any) (any, error) {
+		_, after, _ := strings.Cut(xpathToString(args[0]), xpathToString(args[1]))
+		return after, nil
+	}},
+	"substring": {2, 3, xpathSubstring},
+	"string-length": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		return float64(utf8.RuneCountInString(xpathToString(xpathArgOrContext(ctx, args)))), nil
+	}},
+	"normalize-space": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		return strings.Join(strings.Fields(xpathToString(xpathArgOrContext(ctx, args))), " "), nil
+	}},
+	"translate": {3, 3, xpathTranslate},
+
+	// Boolean functions
+	"boolean": {1, 1, func(_ xpathContext, args []any) (any, error) { return xpathToBool(args[0]), nil }},
+	"not":     {1, 1, func(_ xpathContext, args []any) (any, error) { return !xpathToBool(args[0]), nil }},
+	"true":    {0, 0, func(xpathContext, []any) (any, error) { return true, nil }},
+	"false":   {0, 0, func(xpathContext, []any) (any, error) { return false, nil }},
+	"lang":    {1, 1, xpathLang},
+
+	// Number functions
+	"number": {0, 1, func(ctx xpathContext, args []any) (any, error) {
+		return xpathToNumber(xpathArgOrContext(ctx, args)), nil
+	}},
+	"sum": {1, 1, func(_ xpathContext, args []any) (any, error) {
+		nodes, err := xpathArgNodes(args[0])
+		sum := 0.0
+		for _, node := range nodes {
+			sum += xpathToNumber(node.stringValue())
+		}
+		return sum, err
+	}},
+	"floor":   {1, 1, func(_ xpathContext, args []any) (any, error) { return math.Floor(xpathToNumber(args[0])), nil }},
+	"ceiling": {1, 1, func(_ xpathContext, args []any) (any, error) { return math.Ceil(xpathToNumber(args[0])), nil }},
+	"round": {1, 1, func(_ xpathContext, args []any) (any, error) {
+		n := xpathToNumber(args[0])
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return n, nil
+		}
+		return math.Floor(n + 0.5), nil
+	}},
+}
+
+// xpathArgOrContext returns the first argument, or the context node as a
+// node-set when there is no argument.
+func xpathArgOrContext(ctx xpathContext, args []any) any {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return xpathNodeSet{ctx.node}
+}
+
+// xpathArgNodes returns the argument as a node-set.
+func xpathArgNodes(arg any) (xpathNodeSet, error) {
+	nodes, ok := arg.(xpathNodeSet)
+	if !ok {
+		return nil, errors.New("argument must be a node-set")
+	}
+	return nodes, nil
+}
+
+// xpathNodeName returns the qualified name of the first node of the argument,
+// or of the context node.
+func xpathNodeName(ctx xpathContext, args []any) (string, error) {
+	nodes, err := xpathArgNodes(xpathArgOrContext(ctx, args))
+	if err != nil || len(nodes) == 0 {
+		return "", err
+	}
+	switch nodes[0].kind {
+	case xmlElementNode, xmlAttributeNode, xmlProcInstNode:
+		return nodes[0].name, nil
+	}
+	return "", nil
+}
+
+// xpathNamespaceURI resolves the prefix of the name of the first node of the
+// argument, or of the context node, from the xmlns attributes in scope.
+func xpathNamespaceURI(ctx xpathContext, args []any) (any, error) {
+	nodes, err := xpathArgNodes(xpathArgOrContext(ctx, args))
+	if err != nil || len(nodes) == 0 {
+		return "", err
+	}
+
+	node := nodes[0]
+	if node.kind != xmlElementNode && node.kind != xmlAttributeNode {
+		return "", nil
+	}
+
+	attr := "xmlns"
+	prefix, _, found := strings.Cut(node.name, ":")
+	if found {
+		attr = "xmlns:" + prefix
+	} else if node.kind == xmlAttributeNode {
+		// Unprefixed attributes have no namespace
+		return "", nil
+	}
+
+	for element := node; element != nil; element = element.parent {
+		for _, a := range element.attrs {
+			if a.name == attr {
+				return a.value, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// xpathID selects the elements having an `id` or `xml:id` attribute matching
+// one of the space-separated identifiers of the argument.
+func xpathID(ctx xpathContext, args []any) (any, error) {
+	var ids []string
+	if nodes, ok := args[0].(xpathNodeSet); ok {
+		for _, node := range nodes {
+			ids = append(ids, strings.Fields(node.stringValue())...)
+		}
+	} else {
+		ids = strings.Fields(xpathToString(args[0]))
+	}
+
+	root := ctx.node
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	var result xpathNodeSet
+	for _, node := range appendDescendants(nil, root) {
+		for _, attr := range node.attrs {
+			if (attr.name == "id" || attr.name == "xml:id") && slices.Contains(ids, attr.value) {
+				result = append(result, node)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// xpathSubstring implements substring(), with the rounding rules of XPath.
+func xpathSubstring(_ xpathContext, args []any) (any, error) {
+	runes := []rune(xpathToString(args[0]))
+	start := math.Floor(xpathToNumber(args[1]) + 0.5)
+	end := math.Inf(1)
+	if len(args) == 3 {
+		end = start + math.Floor(xpathToNumber(args[2])+0.5)
+	}
+
+	var sb strings.Builder
+	for i, r := range runes {
+		position := float64(i + 1)
+		if position >= start && position < end {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), nil
+}
+
+// xpathTranslate implements translate(), replacing the characters of the
+// second argument by the characters at the same position in the third one,
+// or removing them when there is no such character.
+func xpathTranslate(_ xpathContext, args []any) (any, error) {
+	from, to := []rune(xpathToString(args[1])), []rune(xpathToString(args[2]))
+
+	var sb strings.Builder
+	for _, r := range xpathToString(args[0]) {
+		idx := slices.Index(from, r)
+		switch {
+		case idx == -1:
+			sb.WriteRune(r)
+		case idx < len(to):
+			sb.WriteRune(to[idx])
+		}
+	}
+	return sb.String(), nil
+}
+
+// xpathLang reports whether the language of the context node, given by the
+// nearest xml:lang attribute, matches the argument.
+func xpathLang(ctx xpathContext, args []any) (any, error) {
+	lang := strings.ToLower(xpathToString(args[0]))
+	for node := ctx.node; node != nil; node = node.parent {
+		for _, attr := range node.attrs {
+			if attr.name == "xml:lang" {
+				value := strings.ToLower(attr.value)
+				return value == lang || strings.HasPrefix(value, lang+"-"), nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// xpathToString converts a value to a string.
+func xpathToString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		case v == 0:
+			return "0"
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case xpathNodeSet:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0].stringValue()
+	}
+	return ""
+}
+
+// xpathToNumber converts a value to a number, NaN when it is not a number.
+func xpathToNumber(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if !xpathNumberRegex.MatchString(trimmed) {
+			return math.NaN()
+		}
+		f, _ := strconv.ParseFloat(trimmed, 64)
+		return f
+	case xpathNodeSet:
+		return xpathToNumber(xpathToString(v))
+	}
+	return math.NaN()
+}
+
+// xpathToBool converts a value to a boolean.
+func xpathToBool(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	case xpathNodeSet:
+		return len(v) > 0
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package encoding
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const libraryXML = `<library xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <book id="b1" year="1999" xml:lang="en-US">
+    <dc:title>Go</dc:title>
+    <price>10</price>
+  </book>
+  <book id="b2" year="2005">
+    <dc:title>XPath</dc:title>
+    <price>25.5</price>
+    <!-- out of print -->
+  </book>
+  <book id="b3" year="2020">
+    <dc:title>  Templates   in   action </dc:title>
+    <price>4.5</price>
+  </book>
+  <?render fast?>
+</library>`
+
+func TestEvaluateXPath(t *testing.T) {
+	doc, err := parseXML(libraryXML)
+	require.NoError(t, err)
+
+	tests := []struct {
+		expression string
+		expected   any
+	}{
+		// Location paths
+		{"/library/book/dc:title", []string{"Go", "XPath", "  Templates   in   action "}},
+		{"//price", []string{"10", "25.5", "4.5"}},
+		{"/library/book[2]/price", []string{"25.5"}},
+		{"/library/book[last()]/@id", []string{"b3"}},
+		{"//book[@year > 2000]/@id", []string{"b2", "b3"}},
+		{"//book[price < 20 and @year < 2010]/@id", []string{"b1"}},
+		{"//book[dc:title = 'XPath']/@year", []string{"2005"}},
+		{"//dc:*", []string{"Go", "XPath", "  Templates   in   action "}},
+		{"/library/*[1]/@*", []string{"b1", "1999", "en-US"}},
+		{"//book[2]/comment()", []string{" out of print "}},
+		{"//processing-instruction('render')", []string{"fast"}},
+		{"//book[1]/following-sibling::book/@id", []string{"b2", "b3"}},
+		{"//book[3]/preceding-sibling::book[1]/@id", []string{"b2"}},
+		{"//price[. = 25.5]/../@id", []string{"b2"}},
+		{"//price[. = 25.5]/ancestor::*[last()]/@year", []string{}},
+		{"name(//price[1]/ancestor::*[last()])", "library"},
+		{"//book[@id = 'b2']/following::price", []string{"4.5"}},
+		{"//book[@id = 'b2']/preceding::price", []string{"10"}},
+		{"count(//book/descendant-or-self::*)", 9.0},
+		{"//book[@id='b1']/self::book/@id", []string{"b1"}},
+		{"(//book/@id)[2]", []string{"b2"}},
+		{"(//book)[position() > 1]/@id | //book[1]/@id", []string{"b1", "b2", "b3"}},
+		{"id('b3 b1')/@year", []string{"1999", "2020"}},
+		{"//book[not(@xml:lang)]/@id", []string{"b2", "b3"}},
+		{"//book[lang('en')]/@id", []string{"b1"}},
+		{"/", []string{"\n  \n    Go\n    10\n  \n  \n    XPath\n    25.5\n    \n  \n  \n      Templates   in   action \n    4.5\n  \n  \n"}},
+
+		// Functions
+		{"count(//book)", 3.0},
+		{"sum(//price)", 40.0},
+		{"string(//book[1]/@year)", "1999"},
+		{"normalize-space(//book[3]/dc:title)", "Templates in action"},
+		{"concat(//book[1]/dc:title, '-', //book[2]/dc:title)", "Go-XPath"},
+		{"string-length(//book[2]/dc:title)", 5.0},
+		{"substring('12345', 2, 3)", "234"},
+		{"substring('12345', 1.5, 2.6)", "234"},
+		{"substring('12345', 0, 3)", "12"},
+		{"substring-before('1999/04/01', '/')", "1999"},
+		{"substring-before('1999', '/')", ""},
+		{"substring-after('1999/04/01', '/')", "04/01"},
+		{"translate('bar', 'abc', 'ABC')", "BAr"},
+		{"translate('--aaa--', 'abc-', 'ABC')", "AAA"},
+		{"starts-with(//book[1]/dc:title, 'G')", true},
+		{"contains(//book[2]/dc:title, 'Pa')", true},
+		{"local-name(//dc:title)", "title"},
+		{"name(//dc:title)", "dc:title"},
+		{"namespace-uri(//dc:title)", "http://purl.org/dc/elements/1.1/"},
+		{"namespace-uri(//book)", ""},
+		{"boolean(//missing)", false},
+		{"true() and not(false())", true},
+		{"number('12.5') + 1", 13.5},
+		{"floor(2.7) + ceiling(2.1) + round(2.5)", 8.0},
+		{"round(-2.5)", -2.0},
+		{"string(1 div 0)", "Infinity"},
+		{"string(0 div 0)", "NaN"},
+		{"string(2.50)", "2.5"},
+
+		// Operators
+		{"1 + 2 * 3 - 4 div 2", 5.0},
+		{"7 mod 3", 1.0},
+		{"-(1 + 2)", -3.0},
+		{"1 = 1.0", true},
+		{"'a' != 'b'", true},
+		{"//price = 10", true},
+		{"//price > 20", true},
+		{"//price = //book/@year", false},
+		{"//price != 10", true},
+		{"//missing = ''", false},
+		{"//book = true()", true},
+		{"true() = 'x'", true},
+		{"2 >= 2 and 1 <= 0 or 3 > 2", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			result, err := evaluateXPath(test.expression, doc)
+			require.NoError(t, err)
+
+			if nodes, ok := result.(xpathNodeSet); ok {
+				values := make([]string, len(nodes))
+				for i, node := range nodes {
+					values[i] = node.stringValue()
+				}
+				result = values
+			}
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestEvaluateXPathNaN(t *testing.T) {
+	doc, err := parseXML(libraryXML)
+	require.NoError(t, err)
+
+	result, err := evaluateXPath("number('abc')", doc)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(result.(float64)))
+}
+
+func TestEvaluateXPathErrors(t *testing.T) {
+	doc, err := parseXML(libraryXML)
+	require.NoError(t, err)
+
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "expected a node test, found end of expression"},
+		{"//book[", "expected a node test, found end of expression"},
+		{"//book[1", `expected "]", found end of expression`},
+		{"unknown()", "unknown function unknown()"},
+		{"count()", "wrong number of arguments for count(): 0"},
+		{"count('a')", "count(): argument must be a node-set"},
+		{"$var", "variable $var is not defined"},
+		{"foo::bar", `unknown axis "foo"`},
+		{"'abc", "unterminated string literal"},
+		{"1 | 2", "operands of | must be node-sets"},
+		{"'a'/b", "predicates and paths can only be applied to node-sets"},
+		{"//book )", `unexpected token ")"`},
+		{"#", `unexpected character '#'`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := evaluateXPath(test.expression, doc)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
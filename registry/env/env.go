@@ -11,6 +11,10 @@ func NewRegistry() *EnvironmentRegistry {
 	return &EnvironmentRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (or *EnvironmentRegistry) UID() string {
 	return "go-sprout/sprout.env"
@@ -11,6 +11,10 @@ func NewRegistry() *FileSystemRegistry {
 	return &FileSystemRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (fsr *FileSystemRegistry) UID() string {
 	return "go-sprout/sprout.filesystem"
@@ -0,0 +1,85 @@
+package formatting
+
+// The separators, grouping sizes, percent patterns and currency symbols come
+// from the CLDR tables of golang.org/x/text. The tables below hold the CLDR
+// data it does not expose: the position of the currency symbol and the
+// compact forms of numbers.
+
+// nbsp is the no-break space used by CLDR patterns between a number and its
+// symbol, so they are never split across lines.
+const nbsp = "\u00a0"
+
+// currencyPattern tells where the currency symbol is written, relative to
+// the amount.
+type currencyPattern struct {
+	suffix bool   // the symbol is written after the amount
+	space  string // the space between the symbol and the amount
+}
+
+// currencyPatterns holds the position of the currency symbol of the CLDR
+// standard currency format, by language or locale. Unlisted locales write
+// the symbol before the amount, without space, like English.
+var currencyPatterns = map[string]currencyPattern{
+	"bg": {suffix: true, space: nbsp},
+	"ca": {suffix: true, space: nbsp},
+	"cs": {suffix: true, space: nbsp},
+	"da": {suffix: true, space: nbsp},
+	"de": {suffix: true, space: nbsp},
+	"el": {suffix: true, space: nbsp},
+	"es": {suffix: true, space: nbsp},
+	"et": {suffix: true, space: nbsp},
+	"fi": {suffix: true, space: nbsp},
+	"fr": {suffix: true, space: nbsp},
+	"he": {suffix: true, space: nbsp},
+	"hr": {suffix: true, space: nbsp},
+	"hu": {suffix: true, space: nbsp},
+	"it": {suffix: true, space: nbsp},
+	"lt": {suffix: true, space: nbsp},
+	"lv": {suffix: true, space: nbsp},
+	"nb": {suffix: true, space: nbsp},
+	"nl": {space: nbsp},
+	"nn": {suffix: true, space: nbsp},
+	"no": {suffix: true, space: nbsp},
+	"pl": {suffix: true, space: nbsp},
+	"pt": {space: nbsp},
+	"ro": {suffix: true, space: nbsp},
+	"ru": {suffix: true, space: nbsp},
+	"sk": {suffix: true, space: nbsp},
+	"sl": {suffix: true, space: nbsp},
+	"sr": {suffix: true, space: nbsp},
+	"sv": {suffix: true, space: nbsp},
+	"uk": {suffix: true, space: nbsp},
+	"vi": {suffix: true, space: nbsp},
+
+	"de-AT":  {space: nbsp},
+	"de-CH":  {space: nbsp},
+	"de-LI":  {space: nbsp},
+	"es-419": {},
+	"es-MX":  {},
+	"es-US":  {},
+	"it-CH":  {space: nbsp},
+	"pt-PT":  {suffix: true, space: nbsp},
+}
+
+// compactUnit is a unit of the compact form of numbers: the numbers from
+// 10^magnitude are divided by it and followed by the suffix.
+type compactUnit struct {
+	magnitude int
+	suffix    string
+}
+
+// compactUnits holds the units of the CLDR short compact format, by
+// language, in ascending order. Unlisted languages use the English units.
+var compactUnits = map[string][]compactUnit{
+	"de": {{6, nbsp + "Mio."}, {9, nbsp + "Mrd."}, {12, nbsp + "Bio."}},
+	"en": {{3, "K"}, {6, "M"}, {9, "B"}, {12, "T"}},
+	"es": {{3, nbsp + "mil"}, {6, nbsp + "M"}, {12, nbsp + "B"}},
+	"fr": {{3, nbsp + "k"}, {6, nbsp + "M"}, {9, nbsp + "Md"}, {12, nbsp + "Bn"}},
+	"it": {{6, nbsp + "Mln"}, {9, nbsp + "Mrd"}, {12, nbsp + "Bln"}},
+	"ja": {{4, "万"}, {8, "億"}, {12, "兆"}},
+	"ko": {{3, "천"}, {4, "만"}, {8, "억"}, {12, "조"}},
+	"nl": {{3, "K"}, {6, nbsp + "mln."}, {9, nbsp + "mld."}, {12, nbsp + "bln."}},
+	"pt": {{3, nbsp + "mil"}, {6, nbsp + "mi"}, {9, nbsp + "bi"}, {12, nbsp + "tri"}},
+	"ru": {{3, nbsp + "тыс."}, {6, nbsp + "млн"}, {9, nbsp + "млрд"}, {12, nbsp + "трлн"}},
+	"zh": {{4, "万"}, {8, "亿"}, {12, "万亿"}},
+}
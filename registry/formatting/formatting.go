@@ -0,0 +1,43 @@
+// Package formatting provides functions to format numbers, currencies,
+// percentages and sizes following the customs of a locale, like the grouping
+// and decimal separators or the position of the currency symbol. The locale
+// data comes from the Unicode CLDR and is embedded at build time.
+package formatting
+
+import (
+	"github.com/go-sprout/sprout"
+)
+
+type FormattingRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+}
+
+// NewRegistry creates a new instance of formatting registry.
+func NewRegistry() *FormattingRegistry {
+	return &FormattingRegistry{}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (fr *FormattingRegistry) UID() string {
+	return "go-sprout/sprout.formatting"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (fr *FormattingRegistry) LinkHandler(fh sprout.Handler) error {
+	fr.handler = fh
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (fr *FormattingRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "formatNumber", fr.FormatNumber)
+	sprout.AddFunction(funcsMap, "formatCurrency", fr.FormatCurrency)
+	sprout.AddFunction(funcsMap, "formatPercent", fr.FormatPercent)
+	sprout.AddFunction(funcsMap, "formatCompact", fr.FormatCompact)
+	sprout.AddFunction(funcsMap, "formatBytes", fr.FormatBytes)
+	return nil
+}
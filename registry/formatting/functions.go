@@ -0,0 +1,228 @@
+package formatting
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/go-sprout/sprout/internal/helpers"
+)
+
+// numberDecimals is the maximum number of decimals of formatNumber, the
+// default of the CLDR decimal format.
+const numberDecimals = 3
+
+// byteUnits holds the units of formatBytes, by system of units.
+var byteUnits = map[string]struct {
+	base    float64
+	symbols []string
+}{
+	"si":  {1000, []string{"kB", "MB", "GB", "TB", "PB", "EB"}},
+	"iec": {1024, []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}},
+}
+
+// FormatNumber formats a number with the grouping and decimal separators of
+// the locale, with at most three decimals rounded half away from zero.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "en-US" or "fr".
+//	value any - the number to format.
+//
+// Returns:
+//
+//	string - the formatted number.
+//	error - an error if the locale is invalid or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: formatNumber].
+//
+// [Sprout Documentation: formatNumber]: https://docs.atom.codes/sprout/registries/formatting#formatnumber
+func (fr *FormattingRegistry) FormatNumber(locale string, value any) (string, error) {
+	printer, err := newPrinter(locale)
+	if err != nil {
+		return "", err
+	}
+	n, err := toNumber(value)
+	if err != nil {
+		return "", err
+	}
+	if float, ok := n.(float64); ok {
+		n = roundHalfAway(float, numberDecimals)
+	}
+	return printer.Sprint(number.Decimal(n, number.MaxFractionDigits(numberDecimals))), nil
+}
+
+// FormatCurrency formats an amount of money with the customs of the locale:
+// the symbol of the currency, its position, and the number of decimals of
+// the currency, like 2 for euros and 0 for yens. The amount is rounded half
+// away from zero.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "en-US" or "fr".
+//	code string - the ISO 4217 code of the currency, like "EUR".
+//	value any - the amount to format.
+//
+// Returns:
+//
+//	string - the formatted amount.
+//	error - an error if the locale or the currency is invalid, or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: formatCurrency].
+//
+// [Sprout Documentation: formatCurrency]: https://docs.atom.codes/sprout/registries/formatting#formatcurrency
+func (fr *FormattingRegistry) FormatCurrency(locale string, code string, value any) (string, error) {
+	tag, err := helpers.ParseLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid currency %q: %w", code, err)
+	}
+	amount, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	printer := message.NewPrinter(tag)
+	decimals, _ := currency.Standard.Rounding(unit)
+	amount = roundHalfAway(amount, decimals)
+	formatted := printer.Sprint(number.Decimal(math.Abs(amount), number.Scale(decimals)))
+	symbol := printer.Sprint(currency.Symbol(unit))
+
+	pattern, _ := helpers.LookupLocale(currencyPatterns, tag)
+	if pattern.suffix {
+		formatted = formatted + pattern.space + symbol
+	} else {
+		formatted = symbol + pattern.space + formatted
+	}
+	if amount < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted, nil
+}
+
+// FormatPercent formats a ratio as a percentage with the customs of the
+// locale, 0.25 being 25%. The percentage is rounded half away from zero to
+// a whole number.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "en-US" or "fr".
+//	value any - the ratio to format.
+//
+// Returns:
+//
+//	string - the formatted percentage.
+//	error - an error if the locale is invalid or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: formatPercent].
+//
+// [Sprout Documentation: formatPercent]: https://docs.atom.codes/sprout/registries/formatting#formatpercent
+func (fr *FormattingRegistry) FormatPercent(locale string, value any) (string, error) {
+	printer, err := newPrinter(locale)
+	if err != nil {
+		return "", err
+	}
+	ratio, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+	return printer.Sprint(number.Percent(roundHalfAway(ratio*100, 0)/100, number.MaxFractionDigits(0))), nil
+}
+
+// FormatCompact formats a number in the short compact form of the locale,
+// like 1.2K for 1,234 in English or 1,2 Mio. for 1,234,567 in German. The
+// number is rounded to two significant digits, keeping all the digits of
+// its integer part.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "en-US" or "fr".
+//	value any - the number to format.
+//
+// Returns:
+//
+//	string - the formatted number.
+//	error - an error if the locale is invalid or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: formatCompact].
+//
+// [Sprout Documentation: formatCompact]: https://docs.atom.codes/sprout/registries/formatting#formatcompact
+func (fr *FormattingRegistry) FormatCompact(locale string, value any) (string, error) {
+	tag, err := helpers.ParseLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	float, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	units, ok := helpers.LookupLocale(compactUnits, tag)
+	if !ok {
+		units = compactUnits["en"]
+	}
+	sizes := make([]float64, len(units))
+	for i, unit := range units {
+		sizes[i] = math.Pow10(unit.magnitude)
+	}
+
+	scaled, unit := scaleNumber(float, sizes, significantDecimals)
+	formatted := message.NewPrinter(tag).Sprint(number.Decimal(scaled, number.MaxFractionDigits(significantDecimals(scaled))))
+	if unit >= 0 {
+		formatted += units[unit].suffix
+	}
+	return formatted, nil
+}
+
+// FormatBytes formats a size in bytes with the unit it reaches, with at most
+// one decimal formatted with the customs of the locale. The units are either
+// the SI ones, multiples of 1000 (kB, MB, GB...), or the IEC ones, multiples
+// of 1024 (KiB, MiB, GiB...).
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "en-US" or "fr".
+//	system string - the system of units, "si" or "iec".
+//	value any - the size in bytes.
+//
+// Returns:
+//
+//	string - the formatted size.
+//	error - an error if the locale or the system is invalid, or the value is not a number.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: formatBytes].
+//
+// [Sprout Documentation: formatBytes]: https://docs.atom.codes/sprout/registries/formatting#formatbytes
+func (fr *FormattingRegistry) FormatBytes(locale string, system string, value any) (string, error) {
+	printer, err := newPrinter(locale)
+	if err != nil {
+		return "", err
+	}
+	units, ok := byteUnits[strings.ToLower(system)]
+	if !ok {
+		return "", fmt.Errorf("unknown system of units %q, expected \"si\" or \"iec\"", system)
+	}
+	size, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	sizes := make([]float64, len(units.symbols))
+	for i := range sizes {
+		sizes[i] = math.Pow(units.base, float64(i+1))
+	}
+
+	scaled, unit := scaleNumber(size, sizes, func(float64) int { return 1 })
+	symbol := "B"
+	if unit >= 0 {
+		symbol = units.symbols[unit]
+	}
+	return printer.Sprint(number.Decimal(scaled, number.MaxFractionDigits(1))) + " " + symbol, nil
+}
@@ -0,0 +1,93 @@
+package formatting_test
+
+import (
+	"testing"
+
+	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/formatting"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEnglish", Input: `{{ formatNumber "en-US" 1234567.891 }}`, ExpectedOutput: "1,234,567.891"},
+		{Name: "TestGerman", Input: `{{ formatNumber "de-DE" 1234.56 }}`, ExpectedOutput: "1.234,56"},
+		{Name: "TestFrench", Input: `{{ formatNumber "fr-FR" 1234.56 }}`, ExpectedOutput: "1\u00a0234,56"},
+		{Name: "TestSwiss", Input: `{{ formatNumber "de-CH" 1234.56 }}`, ExpectedOutput: "1’234.56"},
+		{Name: "TestIndian", Input: `{{ formatNumber "en-IN" 12345678 }}`, ExpectedOutput: "1,23,45,678"},
+		{Name: "TestUnderscore", Input: `{{ formatNumber "pt_BR" 1234.5 }}`, ExpectedOutput: "1.234,5"},
+		{Name: "TestRounded", Input: `{{ formatNumber "en" 1.0005 }}`, ExpectedOutput: "1.001"},
+		{Name: "TestLargeInteger", Input: `{{ formatNumber "en" .V }}`, Data: map[string]any{"V": int64(9007199254740993)}, ExpectedOutput: "9,007,199,254,740,993"},
+		{Name: "TestString", Input: `{{ formatNumber "en" "-1234.5" }}`, ExpectedOutput: "-1,234.5"},
+		{Name: "TestInvalidLocale", Input: `{{ formatNumber "not a locale" 1 }}`, ExpectedErr: "invalid locale \"not a locale\""},
+		{Name: "TestInvalidNumber", Input: `{{ formatNumber "en" "abc" }}`, ExpectedErr: "failed to convert: abc to float64"},
+	}
+
+	pesticide.RunTestCases(t, formatting.NewRegistry(), tc)
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestDollars", Input: `{{ formatCurrency "en-US" "USD" 1234.5 }}`, ExpectedOutput: "$1,234.50"},
+		{Name: "TestEurosInFrench", Input: `{{ formatCurrency "fr-FR" "EUR" 1234.5 }}`, ExpectedOutput: "1\u00a0234,50\u00a0€"},
+		{Name: "TestEurosInEnglish", Input: `{{ formatCurrency "en-IE" "EUR" 1234.5 }}`, ExpectedOutput: "€1,234.50"},
+		{Name: "TestNegative", Input: `{{ formatCurrency "de-DE" "EUR" -1234.5 }}`, ExpectedOutput: "-1.234,50\u00a0€"},
+		{Name: "TestNegativePrefix", Input: `{{ formatCurrency "en-US" "USD" -5 }}`, ExpectedOutput: "-$5.00"},
+		{Name: "TestHalfUp", Input: `{{ formatCurrency "en-US" "USD" 0.125 }}`, ExpectedOutput: "$0.13"},
+		{Name: "TestYen", Input: `{{ formatCurrency "ja-JP" "JPY" 1234.5 }}`, ExpectedOutput: "￥1,235"},
+		{Name: "TestRegionOverride", Input: `{{ formatCurrency "de-CH" "CHF" 1234.5 }}`, ExpectedOutput: "CHF\u00a01’234.50"},
+		{Name: "TestPrefixWithSpace", Input: `{{ formatCurrency "pt-BR" "BRL" 10 }}`, ExpectedOutput: "R$\u00a010,00"},
+		{Name: "TestForeignSymbol", Input: `{{ formatCurrency "en-CA" "USD" 1 }}`, ExpectedOutput: "US$1.00"},
+		{Name: "TestLowercaseCode", Input: `{{ formatCurrency "en" "eur" "19.99" }}`, ExpectedOutput: "€19.99"},
+		{Name: "TestInvalidCurrency", Input: `{{ formatCurrency "en" "XYZ" 1 }}`, ExpectedErr: "invalid currency \"XYZ\""},
+		{Name: "TestInvalidLocale", Input: `{{ formatCurrency "" "EUR" 1 }}`, ExpectedErr: "invalid locale \"\""},
+	}
+
+	pesticide.RunTestCases(t, formatting.NewRegistry(), tc)
+}
+
+func TestFormatPercent(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEnglish", Input: `{{ formatPercent "en" 0.256 }}`, ExpectedOutput: "26%"},
+		{Name: "TestFrench", Input: `{{ formatPercent "fr" 0.256 }}`, ExpectedOutput: "26\u00a0%"},
+		{Name: "TestHalfUp", Input: `{{ formatPercent "en" 0.125 }}`, ExpectedOutput: "13%"},
+		{Name: "TestAboveOne", Input: `{{ formatPercent "en" 1.5 }}`, ExpectedOutput: "150%"},
+		{Name: "TestNegative", Input: `{{ formatPercent "en" -0.05 }}`, ExpectedOutput: "-5%"},
+		{Name: "TestInvalidNumber", Input: `{{ formatPercent "en" "half" }}`, ExpectedErr: "failed to convert: half to float64"},
+	}
+
+	pesticide.RunTestCases(t, formatting.NewRegistry(), tc)
+}
+
+func TestFormatCompact(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestThousands", Input: `{{ formatCompact "en" 1234 }}`, ExpectedOutput: "1.2K"},
+		{Name: "TestHundredsOfThousands", Input: `{{ formatCompact "en" 123456 }}`, ExpectedOutput: "123K"},
+		{Name: "TestMillions", Input: `{{ formatCompact "en" 1500000 }}`, ExpectedOutput: "1.5M"},
+		{Name: "TestRoundedToNextUnit", Input: `{{ formatCompact "en" 999999 }}`, ExpectedOutput: "1M"},
+		{Name: "TestNegative", Input: `{{ formatCompact "en" -2500000000 }}`, ExpectedOutput: "-2.5B"},
+		{Name: "TestSmall", Input: `{{ formatCompact "en" 12.345 }}`, ExpectedOutput: "12"},
+		{Name: "TestBelowOne", Input: `{{ formatCompact "en" 0.1234 }}`, ExpectedOutput: "0.12"},
+		{Name: "TestFrench", Input: `{{ formatCompact "fr" 1234 }}`, ExpectedOutput: "1,2\u00a0k"},
+		{Name: "TestGerman", Input: `{{ formatCompact "de" 1234567 }}`, ExpectedOutput: "1,2\u00a0Mio."},
+		{Name: "TestJapanese", Input: `{{ formatCompact "ja" 12345 }}`, ExpectedOutput: "1.2万"},
+		{Name: "TestFallback", Input: `{{ formatCompact "sw" 1234 }}`, ExpectedOutput: "1.2K"},
+		{Name: "TestBeyondLastUnit", Input: `{{ formatCompact "en" 1.5e15 }}`, ExpectedOutput: "1,500T"},
+	}
+
+	pesticide.RunTestCases(t, formatting.NewRegistry(), tc)
+}
+
+func TestFormatBytes(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestSI", Input: `{{ formatBytes "en" "si" 1536 }}`, ExpectedOutput: "1.5 kB"},
+		{Name: "TestIEC", Input: `{{ formatBytes "en" "iec" 1536 }}`, ExpectedOutput: "1.5 KiB"},
+		{Name: "TestLocale", Input: `{{ formatBytes "fr" "si" 1500000 }}`, ExpectedOutput: "1,5 MB"},
+		{Name: "TestBytes", Input: `{{ formatBytes "en" "si" 512 }}`, ExpectedOutput: "512 B"},
+		{Name: "TestZero", Input: `{{ formatBytes "en" "iec" 0 }}`, ExpectedOutput: "0 B"},
+		{Name: "TestRoundedToNextUnit", Input: `{{ formatBytes "en" "iec" 1048575 }}`, ExpectedOutput: "1 MiB"},
+		{Name: "TestLarge", Input: `{{ formatBytes "en" "SI" 3.2e12 }}`, ExpectedOutput: "3.2 TB"},
+		{Name: "TestUnknownSystem", Input: `{{ formatBytes "en" "binary" 1 }}`, ExpectedErr: "unknown system of units \"binary\", expected \"si\" or \"iec\""},
+	}
+
+	pesticide.RunTestCases(t, formatting.NewRegistry(), tc)
+}
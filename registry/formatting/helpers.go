@@ -0,0 +1,123 @@
+package formatting
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/spf13/cast"
+	"golang.org/x/text/message"
+
+	"github.com/go-sprout/sprout"
+	"github.com/go-sprout/sprout/internal/helpers"
+)
+
+// newPrinter returns a printer formatting numbers with the customs of the
+// locale.
+func newPrinter(locale string) (*message.Printer, error) {
+	tag, err := helpers.ParseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	return message.NewPrinter(tag), nil
+}
+
+// toNumber converts a value to a number which can be formatted: integers are
+// kept as int64 or uint64 so they are never rounded, any other value is
+// converted to float64, like numeric strings or decimals.
+func toNumber(value any) (any, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	}
+	return toFloat(value)
+}
+
+// toFloat converts a value to float64, with the rules of the conversion
+// registry.
+func toFloat(value any) (float64, error) {
+	float, err := cast.ToFloat64E(value)
+	if err != nil {
+		return 0, sprout.NewErrConvertFailed("float64", value, err)
+	}
+	return float, nil
+}
+
+// roundHalfAway rounds a number at the given number of decimals, ties away
+// from zero. The number is rounded from its shortest decimal representation,
+// so 1.005 is rounded up to 1.01 although its binary value is slightly lower.
+func roundHalfAway(value float64, decimals int) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+
+	exact, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	exact.Mul(exact, new(big.Rat).SetInt(scale))
+
+	q, r := new(big.Int).QuoRem(exact.Num(), exact.Denom(), new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(exact.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(exact.Sign())))
+	}
+
+	rounded, _ := new(big.Rat).SetFrac(q, scale).Float64()
+	return rounded
+}
+
+// significantDecimals returns the number of decimals keeping two significant
+// digits of a number, 0 from 10, as done by the compact forms.
+func significantDecimals(value float64) int {
+	if value == 0 {
+		return 0
+	}
+	return max(0, 1-int(math.Floor(math.Log10(math.Abs(value)))))
+}
+
+// scaleNumber divides a number by the largest unit it reaches, rounding the
+// result to the decimals returned by decimals. The unit above is used when
+// the rounding reaches it, so 999,999 is 1M rather than 1,000K.
+//
+// Parameters:
+//
+//	value float64 - the number to scale.
+//	units []float64 - the ascending sizes of the units.
+//	decimals func(float64) int - the number of decimals of a scaled number.
+//
+// Returns:
+//
+//	float64 - the scaled and rounded number.
+//	int - the index of the unit, -1 when the number is lower than all units.
+func scaleNumber(value float64, units []float64, decimals func(float64) int) (float64, int) {
+	unit := -1
+	for i, size := range units {
+		if math.Abs(value) >= size {
+			unit = i
+		}
+	}
+
+	for {
+		scaled := value
+		if unit >= 0 {
+			scaled = value / units[unit]
+		}
+		scaled = roundHalfAway(scaled, decimals(scaled))
+
+		next := unit + 1
+		if next == len(units) || math.Abs(scaled*unitSize(units, unit)) < units[next] {
+			return scaled, unit
+		}
+		unit = next
+	}
+}
+
+// unitSize returns the size of the unit at the given index, 1 for -1.
+func unitSize(units []float64, unit int) float64 {
+	if unit < 0 {
+		return 1
+	}
+	return units[unit]
+}
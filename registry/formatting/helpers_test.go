@@ -0,0 +1,41 @@
+package formatting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundHalfAway(t *testing.T) {
+	assert.InDelta(t, 1.01, roundHalfAway(1.005, 2), 1e-12)
+	assert.InDelta(t, -1.01, roundHalfAway(-1.005, 2), 1e-12)
+	assert.InDelta(t, 3.0, roundHalfAway(2.5, 0), 1e-12)
+	assert.InDelta(t, 0.1, roundHalfAway(0.14, 1), 1e-12)
+	assert.True(t, math.IsInf(roundHalfAway(math.Inf(1), 2), 1))
+}
+
+func TestSignificantDecimals(t *testing.T) {
+	assert.Equal(t, 0, significantDecimals(0))
+	assert.Equal(t, 2, significantDecimals(0.5))
+	assert.Equal(t, 1, significantDecimals(-1.5))
+	assert.Equal(t, 0, significantDecimals(12))
+	assert.Equal(t, 0, significantDecimals(999))
+}
+
+func TestScaleNumber(t *testing.T) {
+	units := []float64{1e3, 1e6}
+	oneDecimal := func(float64) int { return 1 }
+
+	scaled, unit := scaleNumber(999, units, oneDecimal)
+	assert.InDelta(t, 999.0, scaled, 1e-9)
+	assert.Equal(t, -1, unit)
+
+	scaled, unit = scaleNumber(999.99, units, oneDecimal)
+	assert.InDelta(t, 1.0, scaled, 1e-9)
+	assert.Equal(t, 0, unit)
+
+	scaled, unit = scaleNumber(5e9, units, oneDecimal)
+	assert.InDelta(t, 5000.0, scaled, 1e-9)
+	assert.Equal(t, 1, unit)
+}
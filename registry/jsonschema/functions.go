@@ -0,0 +1,74 @@
+package jsonschema
+
+// ValidateJSONSchema validates a value against a JSON Schema and returns the
+// list of violations, empty when the value is valid. The schema is either a
+// JSON or YAML string, or already decoded data like the output of `fromJSON`
+// or `fromYAML`. Drafts 2020-12 and 7 are supported, selected by the
+// `$schema` keyword and defaulting to 2020-12. Only local references are
+// resolved, remote references are reported as violations.
+//
+// Parameters:
+//
+//	schema any - the JSON Schema, as a string or decoded data.
+//	value any - the value to validate.
+//
+// Returns:
+//
+//	[]Violation - the violations, with JSON pointers to the value and to the schema keyword.
+//	error - an error if the schema cannot be decoded or the value cannot be converted to JSON.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: validateJSONSchema].
+//
+// [Sprout Documentation: validateJSONSchema]: https://docs.atom.codes/sprout/registries/jsonschema#validatejsonschema
+func (jr *JSONSchemaRegistry) ValidateJSONSchema(schema any, value any) ([]Violation, error) {
+	parsedSchema, err := parseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := toJSONValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := newValidator(parsedSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := v.validate(parsedSchema, instance, defaultBaseURI, "", "").violations
+	if violations == nil {
+		violations = []Violation{}
+	}
+	return violations, nil
+}
+
+// MustValidate validates a value against a JSON Schema like
+// ValidateJSONSchema, and returns the value when it is valid so it can be
+// used in a pipeline. The template fails with a *SchemaError listing all
+// violations otherwise.
+//
+// Parameters:
+//
+//	schema any - the JSON Schema, as a string or decoded data.
+//	value any - the value to validate.
+//
+// Returns:
+//
+//	any - the given value.
+//	error - a *SchemaError when the value does not match the schema.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: mustValidate].
+//
+// [Sprout Documentation: mustValidate]: https://docs.atom.codes/sprout/registries/jsonschema#mustvalidate
+func (jr *JSONSchemaRegistry) MustValidate(schema any, value any) (any, error) {
+	violations, err := jr.ValidateJSONSchema(schema, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(violations) > 0 {
+		return nil, &SchemaError{Violations: violations}
+	}
+	return value, nil
+}
@@ -0,0 +1,161 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/jsonschema"
+)
+
+const personSchema = `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1},
+    "age": {"type": "integer", "minimum": 0}
+  }
+}`
+
+func TestValidateJSONSchema(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestValidValue", Input: `{{ validateJSONSchema .schema .values | len }}`, Data: map[string]any{"schema": personSchema, "values": map[string]any{"name": "Ada", "age": 36}}, ExpectedOutput: "0"},
+		{Name: "TestInvalidValue", Input: `{{ range validateJSONSchema .schema .values }}{{ . }};{{ end }}`, Data: map[string]any{"schema": personSchema, "values": map[string]any{"age": -1}}, ExpectedOutput: `/: missing required property "name";/age: value must be greater than or equal to 0;`},
+		{Name: "TestSchemaPointer", Input: `{{ range validateJSONSchema .schema .values }}{{ .SchemaPointer }}{{ end }}`, Data: map[string]any{"schema": personSchema, "values": map[string]any{"name": ""}}, ExpectedOutput: "/properties/name/minLength"},
+		{Name: "TestYAMLSchema", Input: `{{ validateJSONSchema "type: string" 42 | len }}`, ExpectedOutput: "1"},
+		{Name: "TestDecodedSchema", Input: `{{ validateJSONSchema (dict "type" "string") "hello" | len }}`, ExpectedOutput: "0"},
+		{Name: "TestInvalidSchema", Input: `{{ validateJSONSchema "{" 42 }}`, ExpectedErr: "cannot decode schema"},
+		{Name: "TestUnsupportedDraft", Input: `{{ validateJSONSchema .schema 42 }}`, Data: map[string]any{"schema": `{"$schema": "http://json-schema.org/draft-04/schema#"}`}, ExpectedErr: "unsupported $schema"},
+	}
+
+	pesticide.RunTestCases(t, jsonschema.NewRegistry(), tc)
+}
+
+func TestMustValidate(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestValidValue", Input: `{{ (mustValidate .schema .values).name }}`, Data: map[string]any{"schema": personSchema, "values": map[string]any{"name": "Ada"}}, ExpectedOutput: "Ada"},
+		{Name: "TestInvalidValue", Input: `{{ mustValidate .schema .values }}`, Data: map[string]any{"schema": personSchema, "values": map[string]any{"name": 42}}, ExpectedErr: "value does not match the schema: /name: expected type string, got integer"},
+	}
+
+	pesticide.RunTestCases(t, jsonschema.NewRegistry(), tc)
+}
+
+func TestSchemaError(t *testing.T) {
+	_, err := pesticide.TestTemplate(t, jsonschema.NewRegistry(), `{{ mustValidate .schema .values }}`, map[string]any{
+		"schema": personSchema,
+		"values": map[string]any{"age": "old"},
+	})
+	require.Error(t, err)
+
+	var schemaErr *jsonschema.SchemaError
+	require.ErrorAs(t, err, &schemaErr, "the template error should wrap the schema error")
+	assert.Equal(t, []jsonschema.Violation{
+		{Pointer: "", SchemaPointer: "/required", Message: `missing required property "name"`},
+		{Pointer: "/age", SchemaPointer: "/properties/age/type", Message: "expected type integer, got string"},
+	}, schemaErr.Violations)
+}
+
+func TestValidateJSONSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  any
+		valid  bool
+	}{
+		// Generic keywords
+		{"TypeNumberAcceptsInteger", `{"type": "number"}`, 3, true},
+		{"TypeIntegerAcceptsIntegralFloat", `{"type": "integer"}`, 3.0, true},
+		{"TypeIntegerRejectsFloat", `{"type": "integer"}`, 3.5, false},
+		{"TypeList", `{"type": ["string", "null"]}`, nil, true},
+		{"Enum", `{"enum": [1, "a", {"b": true}]}`, map[string]any{"b": true}, true},
+		{"EnumMismatch", `{"enum": [1, "a"]}`, "b", false},
+		{"ConstNumber", `{"const": 1}`, 1.0, true},
+		{"BooleanSchemaFalse", `false`, 1, false},
+		{"BooleanSchemaTrue", `true`, 1, true},
+
+		// Numbers and strings
+		{"ExclusiveMaximum", `{"exclusiveMaximum": 10}`, 10, false},
+		{"MultipleOfDecimal", `{"multipleOf": 0.1}`, 0.3, true},
+		{"MultipleOfMismatch", `{"multipleOf": 2}`, 3, false},
+		{"MaxLengthCountsRunes", `{"maxLength": 2}`, "éé", true},
+		{"Pattern", `{"pattern": "^[a-z]+$"}`, "abc1", false},
+		{"FormatIsAnnotation", `{"format": "email"}`, "not an email", true},
+
+		// Arrays
+		{"Items", `{"items": {"type": "integer"}}`, []any{1, "2"}, false},
+		{"PrefixItems", `{"prefixItems": [{"type": "string"}], "items": false}`, []any{"a"}, true},
+		{"PrefixItemsExtra", `{"prefixItems": [{"type": "string"}], "items": false}`, []any{"a", 1}, false},
+		{"Contains", `{"contains": {"type": "string"}}`, []any{1, 2}, false},
+		{"MinContains", `{"contains": {"type": "string"}, "minContains": 2}`, []any{"a", 1, "b"}, true},
+		{"MaxContains", `{"contains": {"type": "string"}, "maxContains": 1}`, []any{"a", "b"}, false},
+		{"UniqueItems", `{"uniqueItems": true}`, []any{1, 1.0}, false},
+		{"MinItems", `{"minItems": 1}`, []any{}, false},
+
+		// Objects
+		{"AdditionalPropertiesFalse", `{"properties": {"a": {}}, "additionalProperties": false}`, map[string]any{"a": 1, "b": 2}, false},
+		{"PatternProperties", `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`, map[string]any{"x-a": "1"}, true},
+		{"PropertyNames", `{"propertyNames": {"maxLength": 2}}`, map[string]any{"abc": 1}, false},
+		{"MaxProperties", `{"maxProperties": 1}`, map[string]any{"a": 1, "b": 2}, false},
+		{"DependentRequired", `{"dependentRequired": {"a": ["b"]}}`, map[string]any{"a": 1}, false},
+		{"DependentSchemas", `{"dependentSchemas": {"a": {"required": ["b"]}}}`, map[string]any{"a": 1, "b": 2}, true},
+
+		// Applicators
+		{"AllOf", `{"allOf": [{"type": "integer"}, {"minimum": 5}]}`, 3, false},
+		{"AnyOf", `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, 3, true},
+		{"OneOfMatchesTwo", `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, 3, false},
+		{"Not", `{"not": {"type": "string"}}`, "a", false},
+		{"IfThen", `{"if": {"properties": {"kind": {"const": "a"}}}, "then": {"required": ["a"]}, "else": {"required": ["b"]}}`, map[string]any{"kind": "a", "a": 1}, true},
+		{"IfElse", `{"if": {"properties": {"kind": {"const": "a"}}}, "then": {"required": ["a"]}, "else": {"required": ["b"]}}`, map[string]any{"kind": "c", "a": 1}, false},
+
+		// Unevaluated keywords
+		{"UnevaluatedPropertiesWithAllOf", `{"allOf": [{"properties": {"a": {}}}], "unevaluatedProperties": false}`, map[string]any{"a": 1}, true},
+		{"UnevaluatedPropertiesRejected", `{"allOf": [{"properties": {"a": {}}}], "unevaluatedProperties": false}`, map[string]any{"a": 1, "b": 2}, false},
+		{"UnevaluatedItems", `{"prefixItems": [{}], "unevaluatedItems": false}`, []any{1, 2}, false},
+		{"UnevaluatedItemsWithContains", `{"contains": {"type": "string"}, "unevaluatedItems": {"type": "integer"}}`, []any{"a", 1}, true},
+
+		// References
+		{"RefDefs", `{"$defs": {"port": {"type": "integer", "maximum": 65535}}, "properties": {"port": {"$ref": "#/$defs/port"}}}`, map[string]any{"port": 70000}, false},
+		{"RefWithSiblings", `{"$defs": {"s": {"type": "string"}}, "$ref": "#/$defs/s", "minLength": 2}`, "a", false},
+		{"RefAnchor", `{"$defs": {"s": {"$anchor": "str", "type": "string"}}, "$ref": "#str"}`, 1, false},
+		{"RefEmbeddedResource", `{"$id": "https://example.com/root.json", "$defs": {"s": {"$id": "string.json", "type": "string"}}, "$ref": "string.json"}`, "a", true},
+		{"RefRecursive", `{"type": "object", "properties": {"child": {"$ref": "#"}}, "additionalProperties": false}`, map[string]any{"child": map[string]any{"child": map[string]any{"other": 1}}}, false},
+		{"RefEscapedPointer", `{"$defs": {"a/b": {"type": "string"}}, "$ref": "#/$defs/a~1b"}`, "a", true},
+
+		// Draft 7
+		{"Draft7ItemsList", `{"$schema": "http://json-schema.org/draft-07/schema#", "items": [{"type": "string"}], "additionalItems": false}`, []any{"a", 1}, false},
+		{"Draft7RefIgnoresSiblings", `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"s": {"type": "string"}}, "$ref": "#/definitions/s", "minLength": 2}`, "a", true},
+		{"Draft7Dependencies", `{"$schema": "http://json-schema.org/draft-07/schema#", "dependencies": {"a": ["b"], "c": {"required": ["d"]}}}`, map[string]any{"c": 1}, false},
+		{"Draft7IDAnchor", `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"s": {"$id": "#str", "type": "string"}}, "$ref": "#str"}`, 1, false},
+	}
+
+	reg := jsonschema.NewRegistry()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := reg.ValidateJSONSchema(test.schema, test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.valid, len(violations) == 0, "violations: %v", violations)
+		})
+	}
+}
+
+func TestValidateJSONSchemaRemoteRef(t *testing.T) {
+	violations, err := jsonschema.NewRegistry().ValidateJSONSchema(`{"$ref": "https://example.com/schema.json"}`, 1)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/$ref", violations[0].SchemaPointer)
+	assert.Contains(t, violations[0].Message, "remote references are not supported")
+}
+
+func TestValidateJSONSchemaInfiniteRef(t *testing.T) {
+	violations, err := jsonschema.NewRegistry().ValidateJSONSchema(`{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, violations)
+	assert.Contains(t, violations[0].Message, "maximum reference depth exceeded")
+}
+
+func TestValidateJSONSchemaInvalidValue(t *testing.T) {
+	_, err := jsonschema.NewRegistry().ValidateJSONSchema(`{}`, func() {})
+	require.ErrorContains(t, err, "cannot convert value to JSON")
+}
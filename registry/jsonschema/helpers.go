@@ -0,0 +1,865 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// draft identifies the JSON Schema specification used to validate data.
+type draft int
+
+const (
+	draft7 draft = iota + 1
+	draft202012
+)
+
+const (
+	// defaultBaseURI is the base URI of a schema without `$id`, used to
+	// resolve its references.
+	defaultBaseURI = "https://sprout.local/schema.json"
+	// maxRefDepth is the maximum number of nested references followed on the
+	// same value, protecting against infinite recursion.
+	maxRefDepth = 256
+)
+
+// schemaDrafts maps the known `$schema` URIs, without fragment, to their draft.
+var schemaDrafts = map[string]draft{
+	"http://json-schema.org/draft-07/schema":       draft7,
+	"https://json-schema.org/draft-07/schema":      draft7,
+	"https://json-schema.org/draft/2020-12/schema": draft202012,
+}
+
+// schemaMapKeywords are the keywords holding a map of subschemas.
+var schemaMapKeywords = []string{"properties", "patternProperties", "$defs", "definitions", "dependentSchemas", "dependencies"}
+
+// schemaKeywords are the keywords holding a single subschema.
+var schemaKeywords = []string{"additionalProperties", "propertyNames", "items", "additionalItems", "contains", "not", "if", "then", "else", "unevaluatedProperties", "unevaluatedItems"}
+
+// schemaListKeywords are the keywords holding a list of subschemas.
+var schemaListKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"}
+
+// validator validates values against a compiled schema.
+type validator struct {
+	draft     draft
+	resources map[string]any // absolute URI without fragment -> schema resource
+	anchors   map[string]any // absolute URI with an anchor fragment -> schema
+	refDepth  int
+}
+
+// result is the outcome of the validation of a value against a schema: its
+// violations, and the properties and items evaluated successfully, used by
+// the `unevaluatedProperties` and `unevaluatedItems` keywords.
+type result struct {
+	violations []Violation
+	props      map[string]struct{}
+	items      map[int]struct{}
+	allItems   bool
+}
+
+// newResult creates an empty result.
+func newResult() *result {
+	return &result{props: make(map[string]struct{}), items: make(map[int]struct{})}
+}
+
+// valid reports whether the validation succeeded.
+func (r *result) valid() bool {
+	return len(r.violations) == 0
+}
+
+// addViolation records a violation of the keyword at the given locations.
+func (r *result) addViolation(instancePtr, schemaPtr, keyword, format string, args ...any) {
+	r.violations = append(r.violations, Violation{
+		Pointer:       instancePtr,
+		SchemaPointer: schemaPtr + "/" + escapePointer(keyword),
+		Message:       fmt.Sprintf(format, args...),
+	})
+}
+
+// merge adds the violations of other to the result, and its annotations when
+// other is valid.
+func (r *result) merge(other *result) {
+	r.violations = append(r.violations, other.violations...)
+	if other.valid() {
+		r.mergeAnnotations(other)
+	}
+}
+
+// mergeAnnotations adds the evaluated properties and items of other.
+func (r *result) mergeAnnotations(other *result) {
+	for prop := range other.props {
+		r.props[prop] = struct{}{}
+	}
+	for item := range other.items {
+		r.items[item] = struct{}{}
+	}
+	r.allItems = r.allItems || other.allItems
+}
+
+// newValidator compiles the schema, indexing its resources and anchors.
+func newValidator(schema any) (*validator, error) {
+	v := &validator{
+		draft:     draft202012,
+		resources: make(map[string]any),
+		anchors:   make(map[string]any),
+	}
+
+	if obj, ok := schema.(map[string]any); ok {
+		if uri, ok := obj["$schema"].(string); ok {
+			d, found := schemaDrafts[strings.TrimSuffix(uri, "#")]
+			if !found {
+				return nil, fmt.Errorf("unsupported $schema %q, only drafts 2020-12 and 7 are supported", uri)
+			}
+			v.draft = d
+		}
+	}
+
+	v.resources[defaultBaseURI] = schema
+	if err := v.index(schema, defaultBaseURI); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// index walks the schema to register its resources, identified by `$id`, and
+// its anchors.
+func (v *validator) index(schema any, base string) error {
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if id, ok := obj["$id"].(string); ok {
+		if v.draft == draft7 && strings.HasPrefix(id, "#") {
+			v.anchors[base+id] = obj
+		} else {
+			resolved, err := resolveURI(base, id)
+			if err != nil {
+				return err
+			}
+			base = stripFragment(resolved)
+			v.resources[base] = obj
+		}
+	}
+	if anchor, ok := obj["$anchor"].(string); ok {
+		v.anchors[base+"#"+anchor] = obj
+	}
+	if anchor, ok := obj["$dynamicAnchor"].(string); ok {
+		v.anchors[base+"#"+anchor] = obj
+	}
+
+	for _, sub := range subschemas(obj) {
+		if err := v.index(sub, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subschemas returns every direct subschema of the schema.
+func subschemas(obj map[string]any) []any {
+	var subs []any
+	for _, keyword := range schemaMapKeywords {
+		if m, ok := obj[keyword].(map[string]any); ok {
+			for _, key := range sortedKeys(m) {
+				subs = append(subs, m[key])
+			}
+		}
+	}
+	for _, keyword := range schemaKeywords {
+		if sub, ok := obj[keyword]; ok {
+			if _, isList := sub.([]any); !isList {
+				subs = append(subs, sub)
+			}
+		}
+	}
+	for _, keyword := range schemaListKeywords {
+		if list, ok := obj[keyword].([]any); ok {
+			subs = append(subs, list...)
+		}
+	}
+	return subs
+}
+
+// resolveRef resolves a reference against the base URI and returns the
+// referenced schema with its own base URI.
+func (v *validator) resolveRef(base, ref string) (any, string, error) {
+	resolved, err := resolveURI(base, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if schema, ok := v.anchors[resolved]; ok {
+		return schema, stripFragment(resolved), nil
+	}
+
+	resourceURI := stripFragment(resolved)
+	resource, ok := v.resources[resourceURI]
+	if !ok {
+		return nil, "", fmt.Errorf("cannot resolve $ref %q: remote references are not supported", ref)
+	}
+
+	fragment := ""
+	if idx := strings.IndexByte(resolved, '#'); idx != -1 {
+		fragment = resolved[idx+1:]
+	}
+	if fragment == "" {
+		return resource, resourceURI, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, "", fmt.Errorf("cannot resolve $ref %q: unknown anchor %q", ref, fragment)
+	}
+
+	// Follow the JSON pointer, keeping track of the resources crossed
+	current, currentBase := resource, resourceURI
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token, err = url.PathUnescape(token)
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot resolve $ref %q: %w", ref, err)
+		}
+		token = unescapePointer(token)
+
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, "", fmt.Errorf("cannot resolve $ref %q: %q not found", ref, token)
+			}
+			current = next
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, "", fmt.Errorf("cannot resolve $ref %q: invalid index %q", ref, token)
+			}
+			current = node[idx]
+		default:
+			return nil, "", fmt.Errorf("cannot resolve $ref %q: %q not found", ref, token)
+		}
+
+		if obj, ok := current.(map[string]any); ok {
+			if id, ok := obj["$id"].(string); ok && !(v.draft == draft7 && strings.HasPrefix(id, "#")) {
+				if resolvedID, err := resolveURI(currentBase, id); err == nil {
+					currentBase = stripFragment(resolvedID)
+				}
+			}
+		}
+	}
+	return current, currentBase, nil
+}
+
+// validate validates the instance against the schema. instancePtr and
+// schemaPtr are the JSON pointers of the instance and of the schema, used to
+// locate the violations.
+func (v *validator) validate(schema any, instance any, base, instancePtr, schemaPtr string) *result {
+	res := newResult()
+
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			res.violations = append(res.violations, Violation{Pointer: instancePtr, SchemaPointer: schemaPtr, Message: "value is not allowed"})
+		}
+		return res
+	case map[string]any:
+		// validated below
+	default:
+		res.violations = append(res.violations, Violation{Pointer: instancePtr, SchemaPointer: schemaPtr, Message: fmt.Sprintf("invalid schema of type %T", schema)})
+		return res
+	}
+	obj := schema.(map[string]any)
+
+	if id, ok := obj["$id"].(string); ok && !(v.draft == draft7 && strings.HasPrefix(id, "#")) {
+		if resolved, err := resolveURI(base, id); err == nil {
+			base = stripFragment(resolved)
+		}
+	}
+
+	for _, keyword := range []string{"$ref", "$dynamicRef"} {
+		ref, ok := obj[keyword].(string)
+		if !ok {
+			continue
+		}
+		res.merge(v.validateRef(ref, instance, base, instancePtr, schemaPtr, keyword))
+		if v.draft == draft7 {
+			// In draft 7, all other properties of a schema with a $ref are ignored
+			return res
+		}
+	}
+
+	v.validateGeneric(obj, instance, instancePtr, schemaPtr, res)
+
+	switch inst := instance.(type) {
+	case json.Number:
+		v.validateNumber(obj, inst, instancePtr, schemaPtr, res)
+	case string:
+		v.validateString(obj, inst, instancePtr, schemaPtr, res)
+	case []any:
+		v.validateArray(obj, inst, base, instancePtr, schemaPtr, res)
+	case map[string]any:
+		v.validateObject(obj, inst, base, instancePtr, schemaPtr, res)
+	}
+
+	v.validateApplicators(obj, instance, base, instancePtr, schemaPtr, res)
+
+	switch inst := instance.(type) {
+	case []any:
+		v.validateUnevaluatedItems(obj, inst, base, instancePtr, schemaPtr, res)
+	case map[string]any:
+		v.validateUnevaluatedProperties(obj, inst, base, instancePtr, schemaPtr, res)
+	}
+
+	return res
+}
+
+// validateRef validates the instance against the schema referenced by ref.
+func (v *validator) validateRef(ref string, instance any, base, instancePtr, schemaPtr, keyword string) *result {
+	res := newResult()
+
+	if v.refDepth >= maxRefDepth {
+		res.addViolation(instancePtr, schemaPtr, keyword, "maximum reference depth exceeded, the schema is probably recursive")
+		return res
+	}
+
+	target, targetBase, err := v.resolveRef(base, ref)
+	if err != nil {
+		res.addViolation(instancePtr, schemaPtr, keyword, "%s", err)
+		return res
+	}
+
+	v.refDepth++
+	defer func() { v.refDepth-- }()
+
+	return v.validate(target, instance, targetBase, instancePtr, schemaPtr+"/"+escapePointer(keyword))
+}
+
+// validateGeneric validates the keywords applying to any type of instance.
+func (v *validator) validateGeneric(obj map[string]any, instance any, instancePtr, schemaPtr string, res *result) {
+	if typ, ok := obj["type"]; ok {
+		var types []string
+		switch t := typ.(type) {
+		case string:
+			types = []string{t}
+		case []any:
+			for _, item := range t {
+				if str, ok := item.(string); ok {
+					types = append(types, str)
+				}
+			}
+		}
+
+		actual := jsonType(instance)
+		if !slices.ContainsFunc(types, func(expected string) bool {
+			return expected == actual || (expected == "number" && actual == "integer")
+		}) {
+			res.addViolation(instancePtr, schemaPtr, "type", "expected type %s, got %s", strings.Join(types, " or "), actual)
+		}
+	}
+
+	if enum, ok := obj["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(item any) bool { return jsonEqual(item, instance) }) {
+			res.addViolation(instancePtr, schemaPtr, "enum", "value must be one of %s", formatValue(enum))
+		}
+	}
+
+	if constant, ok := obj["const"]; ok && !jsonEqual(constant, instance) {
+		res.addViolation(instancePtr, schemaPtr, "const", "value must be %s", formatValue(constant))
+	}
+}
+
+// validateNumber validates the numeric keywords.
+func (v *validator) validateNumber(obj map[string]any, instance json.Number, instancePtr, schemaPtr string, res *result) {
+	value, ok := toRat(instance)
+	if !ok {
+		return
+	}
+
+	checks := []struct {
+		keyword string
+		fails   func(cmp int) bool
+		message string
+	}{
+		{"minimum", func(cmp int) bool { return cmp < 0 }, "value must be greater than or equal to %s"},
+		{"exclusiveMinimum", func(cmp int) bool { return cmp <= 0 }, "value must be greater than %s"},
+		{"maximum", func(cmp int) bool { return cmp > 0 }, "value must be less than or equal to %s"},
+		{"exclusiveMaximum", func(cmp int) bool { return cmp >= 0 }, "value must be less than %s"},
+	}
+	for _, check := range checks {
+		limit, ok := toRat(obj[check.keyword])
+		if ok && check.fails(value.Cmp(limit)) {
+			res.addViolation(instancePtr, schemaPtr, check.keyword, check.message, obj[check.keyword])
+		}
+	}
+
+	if divisor, ok := toRat(obj["multipleOf"]); ok && divisor.Sign() > 0 {
+		if !new(big.Rat).Quo(value, divisor).IsInt() {
+			res.addViolation(instancePtr, schemaPtr, "multipleOf", "value must be a multiple of %s", obj["multipleOf"])
+		}
+	}
+}
+
+// validateString validates the string keywords.
+func (v *validator) validateString(obj map[string]any, instance string, instancePtr, schemaPtr string, res *result) {
+	length := utf8.RuneCountInString(instance)
+
+	if limit, ok := toInt(obj["minLength"]); ok && length < limit {
+		res.addViolation(instancePtr, schemaPtr, "minLength", "length must be greater than or equal to %d", limit)
+	}
+	if limit, ok := toInt(obj["maxLength"]); ok && length > limit {
+		res.addViolation(instancePtr, schemaPtr, "maxLength", "length must be less than or equal to %d", limit)
+	}
+
+	if pattern, ok := obj["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		switch {
+		case err != nil:
+			res.addViolation(instancePtr, schemaPtr, "pattern", "invalid pattern %q: %s", pattern, err)
+		case !re.MatchString(instance):
+			res.addViolation(instancePtr, schemaPtr, "pattern", "value must match the pattern %q", pattern)
+		}
+	}
+}
+
+// validateArray validates the array keywords.
+func (v *validator) validateArray(obj map[string]any, instance []any, base, instancePtr, schemaPtr string, res *result) {
+	if limit, ok := toInt(obj["minItems"]); ok && len(instance) < limit {
+		res.addViolation(instancePtr, schemaPtr, "minItems", "array must have at least %d items", limit)
+	}
+	if limit, ok := toInt(obj["maxItems"]); ok && len(instance) > limit {
+		res.addViolation(instancePtr, schemaPtr, "maxItems", "array must have at most %d items", limit)
+	}
+
+	if unique, ok := obj["uniqueItems"].(bool); ok && unique {
+	unique:
+		for i := range instance {
+			for j := i + 1; j < len(instance); j++ {
+				if jsonEqual(instance[i], instance[j]) {
+					res.addViolation(instancePtr, schemaPtr, "uniqueItems", "array items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+
+	// Items validated by position: `prefixItems` in 2020-12, `items` as a
+	// list in draft 7. The rest of the items is validated by `items` in
+	// 2020-12, by `additionalItems` in draft 7.
+	prefixKeyword, restKeyword := "prefixItems", "items"
+	if v.draft == draft7 {
+		prefixKeyword, restKeyword = "items", "additionalItems"
+		if _, isList := obj["items"].([]any); !isList {
+			prefixKeyword, restKeyword = "", "items"
+		}
+	}
+
+	prefix, _ := obj[prefixKeyword].([]any)
+	for i, sub := range prefix {
+		if i >= len(instance) {
+			break
+		}
+		itemRes := v.validate(sub, instance[i], base, instancePtr+"/"+strconv.Itoa(i), schemaPtr+"/"+prefixKeyword+"/"+strconv.Itoa(i))
+		res.merge(itemRes)
+		res.items[i] = struct{}{}
+	}
+
+	if rest, ok := obj[restKeyword]; ok {
+		if _, isList := rest.([]any); !isList {
+			for i := len(prefix); i < len(instance); i++ {
+				res.merge(v.validate(rest, instance[i], base, instancePtr+"/"+strconv.Itoa(i), schemaPtr+"/"+restKeyword))
+			}
+			res.allItems = true
+		}
+	}
+
+	if contains, ok := obj["contains"]; ok {
+		matches := 0
+		for i, item := range instance {
+			if v.validate(contains, item, base, instancePtr+"/"+strconv.Itoa(i), schemaPtr+"/contains").valid() {
+				matches++
+				res.items[i] = struct{}{}
+			}
+		}
+
+		minContains, hasMin := toInt(obj["minContains"])
+		if !hasMin || v.draft == draft7 {
+			minContains = 1
+		}
+		if matches < minContains {
+			res.addViolation(instancePtr, schemaPtr, "contains", "array must contain at least %d matching items, got %d", minContains, matches)
+		}
+		if maxContains, ok := toInt(obj["maxContains"]); ok && v.draft != draft7 && matches > maxContains {
+			res.addViolation(instancePtr, schemaPtr, "maxContains", "array must contain at most %d matching items, got %d", maxContains, matches)
+		}
+	}
+}
+
+// validateObject validates the object keywords.
+func (v *validator) validateObject(obj map[string]any, instance map[string]any, base, instancePtr, schemaPtr string, res *result) {
+	if limit, ok := toInt(obj["minProperties"]); ok && len(instance) < limit {
+		res.addViolation(instancePtr, schemaPtr, "minProperties", "object must have at least %d properties", limit)
+	}
+	if limit, ok := toInt(obj["maxProperties"]); ok && len(instance) > limit {
+		res.addViolation(instancePtr, schemaPtr, "maxProperties", "object must have at most %d properties", limit)
+	}
+
+	if required, ok := obj["required"].([]any); ok {
+		for _, name := range required {
+			if str, ok := name.(string); ok {
+				if _, found := instance[str]; !found {
+					res.addViolation(instancePtr, schemaPtr, "required", "missing required property %q", str)
+				}
+			}
+		}
+	}
+
+	keys := sortedKeys(instance)
+	evaluated := make(map[string]struct{})
+
+	if properties, ok := obj["properties"].(map[string]any); ok {
+		for _, key := range keys {
+			if sub, found := properties[key]; found {
+				res.merge(v.validate(sub, instance[key], base, instancePtr+"/"+escapePointer(key), schemaPtr+"/properties/"+escapePointer(key)))
+				evaluated[key] = struct{}{}
+			}
+		}
+	}
+
+	if patterns, ok := obj["patternProperties"].(map[string]any); ok {
+		for _, pattern := range sortedKeys(patterns) {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				res.addViolation(instancePtr, schemaPtr, "patternProperties", "invalid pattern %q: %s", pattern, err)
+				continue
+			}
+			for _, key := range keys {
+				if re.MatchString(key) {
+					res.merge(v.validate(patterns[pattern], instance[key], base, instancePtr+"/"+escapePointer(key), schemaPtr+"/patternProperties/"+escapePointer(pattern)))
+					evaluated[key] = struct{}{}
+				}
+			}
+		}
+	}
+
+	if additional, ok := obj["additionalProperties"]; ok {
+		for _, key := range keys {
+			if _, found := evaluated[key]; found {
+				continue
+			}
+			if b, isBool := additional.(bool); isBool && !b {
+				res.addViolation(instancePtr, schemaPtr, "additionalProperties", "additional property %q is not allowed", key)
+				continue
+			}
+			res.merge(v.validate(additional, instance[key], base, instancePtr+"/"+escapePointer(key), schemaPtr+"/additionalProperties"))
+			evaluated[key] = struct{}{}
+		}
+	}
+
+	for key := range evaluated {
+		res.props[key] = struct{}{}
+	}
+
+	if names, ok := obj["propertyNames"]; ok {
+		for _, key := range keys {
+			res.violations = append(res.violations, v.validate(names, key, base, instancePtr+"/"+escapePointer(key), schemaPtr+"/propertyNames").violations...)
+		}
+	}
+
+	dependentRequired, _ := obj["dependentRequired"].(map[string]any)
+	dependentSchemas, _ := obj["dependentSchemas"].(map[string]any)
+	if dependencies, ok := obj["dependencies"].(map[string]any); ok && v.draft == draft7 {
+		dependentRequired, dependentSchemas = make(map[string]any), make(map[string]any)
+		for key, dependency := range dependencies {
+			if _, isList := dependency.([]any); isList {
+				dependentRequired[key] = dependency
+			} else {
+				dependentSchemas[key] = dependency
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(dependentRequired) {
+		if _, found := instance[key]; !found {
+			continue
+		}
+		required, _ := dependentRequired[key].([]any)
+		for _, name := range required {
+			if str, ok := name.(string); ok {
+				if _, found := instance[str]; !found {
+					res.addViolation(instancePtr, schemaPtr, "dependentRequired", "property %q is required when %q is present", str, key)
+				}
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(dependentSchemas) {
+		if _, found := instance[key]; found {
+			res.merge(v.validate(dependentSchemas[key], instance, base, instancePtr, schemaPtr+"/dependentSchemas/"+escapePointer(key)))
+		}
+	}
+}
+
+// validateApplicators validates the keywords combining subschemas.
+func (v *validator) validateApplicators(obj map[string]any, instance any, base, instancePtr, schemaPtr string, res *result) {
+	if allOf, ok := obj["allOf"].([]any); ok {
+		for i, sub := range allOf {
+			res.merge(v.validate(sub, instance, base, instancePtr, schemaPtr+"/allOf/"+strconv.Itoa(i)))
+		}
+	}
+
+	if anyOf, ok := obj["anyOf"].([]any); ok {
+		matched := false
+		for i, sub := range anyOf {
+			subRes := v.validate(sub, instance, base, instancePtr, schemaPtr+"/anyOf/"+strconv.Itoa(i))
+			if subRes.valid() {
+				matched = true
+				res.mergeAnnotations(subRes)
+			}
+		}
+		if !matched {
+			res.addViolation(instancePtr, schemaPtr, "anyOf", "value must match at least one schema of anyOf")
+		}
+	}
+
+	if oneOf, ok := obj["oneOf"].([]any); ok {
+		var matches []int
+		for i, sub := range oneOf {
+			subRes := v.validate(sub, instance, base, instancePtr, schemaPtr+"/oneOf/"+strconv.Itoa(i))
+			if subRes.valid() {
+				matches = append(matches, i)
+				res.mergeAnnotations(subRes)
+			}
+		}
+		if len(matches) != 1 {
+			res.addViolation(instancePtr, schemaPtr, "oneOf", "value must match exactly one schema of oneOf, matched %d", len(matches))
+		}
+	}
+
+	if not, ok := obj["not"]; ok {
+		if v.validate(not, instance, base, instancePtr, schemaPtr+"/not").valid() {
+			res.addViolation(instancePtr, schemaPtr, "not", "value must not match the schema of not")
+		}
+	}
+
+	if condition, ok := obj["if"]; ok {
+		condRes := v.validate(condition, instance, base, instancePtr, schemaPtr+"/if")
+		if condRes.valid() {
+			res.mergeAnnotations(condRes)
+			if then, ok := obj["then"]; ok {
+				res.merge(v.validate(then, instance, base, instancePtr, schemaPtr+"/then"))
+			}
+		} else if otherwise, ok := obj["else"]; ok {
+			res.merge(v.validate(otherwise, instance, base, instancePtr, schemaPtr+"/else"))
+		}
+	}
+}
+
+// validateUnevaluatedItems validates the items not evaluated by any other
+// keyword against `unevaluatedItems`.
+func (v *validator) validateUnevaluatedItems(obj map[string]any, instance []any, base, instancePtr, schemaPtr string, res *result) {
+	unevaluated, ok := obj["unevaluatedItems"]
+	if !ok || v.draft == draft7 || res.allItems {
+		return
+	}
+
+	for i, item := range instance {
+		if _, found := res.items[i]; found {
+			continue
+		}
+		if b, isBool := unevaluated.(bool); isBool && !b {
+			res.addViolation(instancePtr, schemaPtr, "unevaluatedItems", "unevaluated item %d is not allowed", i)
+			continue
+		}
+		res.violations = append(res.violations, v.validate(unevaluated, item, base, instancePtr+"/"+strconv.Itoa(i), schemaPtr+"/unevaluatedItems").violations...)
+	}
+	res.allItems = true
+}
+
+// validateUnevaluatedProperties validates the properties not evaluated by any
+// other keyword against `unevaluatedProperties`.
+func (v *validator) validateUnevaluatedProperties(obj map[string]any, instance map[string]any, base, instancePtr, schemaPtr string, res *result) {
+	unevaluated, ok := obj["unevaluatedProperties"]
+	if !ok || v.draft == draft7 {
+		return
+	}
+
+	for _, key := range sortedKeys(instance) {
+		if _, found := res.props[key]; found {
+			continue
+		}
+		if b, isBool := unevaluated.(bool); isBool && !b {
+			res.addViolation(instancePtr, schemaPtr, "unevaluatedProperties", "unevaluated property %q is not allowed", key)
+			continue
+		}
+		res.violations = append(res.violations, v.validate(unevaluated, instance[key], base, instancePtr+"/"+escapePointer(key), schemaPtr+"/unevaluatedProperties").violations...)
+		res.props[key] = struct{}{}
+	}
+}
+
+// parseSchema converts a schema to the JSON data model. A string schema is
+// decoded as JSON or YAML.
+func parseSchema(schema any) (any, error) {
+	if str, ok := schema.(string); ok {
+		var decoded any
+		if err := yaml.Unmarshal([]byte(str), &decoded); err != nil {
+			return nil, fmt.Errorf("cannot decode schema: %w", err)
+		}
+		schema = decoded
+	}
+
+	return toJSONValue(schema)
+}
+
+// toJSONValue converts a Go value to the JSON data model: nil, bool,
+// json.Number, string, []any and map[string]any.
+func toJSONValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert value to JSON: %w", err)
+	}
+
+	var out any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&out); err != nil {
+		return nil, fmt.Errorf("cannot convert value to JSON: %w", err)
+	}
+	return out, nil
+}
+
+// jsonType returns the JSON Schema type of a value of the JSON data model.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if r, ok := toRat(v); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonEqual reports whether two values of the JSON data model are equal,
+// numbers being compared by value.
+func jsonEqual(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		ar, aok := toRat(av)
+		br, bok := toRat(bv)
+		return aok && bok && ar.Cmp(br) == 0
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, found := bv[key]
+			if !found || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// toRat converts a JSON number to a rational number.
+func toRat(value any) (*big.Rat, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(number.String())
+}
+
+// toInt converts a JSON number to an int.
+func toInt(value any) (int, bool) {
+	r, ok := toRat(value)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// formatValue formats a value of the JSON data model as JSON.
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// resolveURI resolves the reference against the base URI.
+func resolveURI(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URI %q: %w", base, err)
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid URI reference %q: %w", ref, err)
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+// stripFragment removes the fragment of an URI.
+func stripFragment(uri string) string {
+	if idx := strings.IndexByte(uri, '#'); idx != -1 {
+		return uri[:idx]
+	}
+	return uri
+}
+
+// escapePointer escapes a JSON pointer reference token.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// unescapePointer unescapes a JSON pointer reference token.
+func unescapePointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// sortedKeys returns the keys of the map in a deterministic order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+// Package jsonschema provides functions to validate template data against a
+// JSON Schema, supporting drafts 2020-12 and 7. Schemas are self-contained:
+// only references to the schema itself or to its embedded resources are
+// resolved, remote references are never fetched.
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sprout/sprout"
+)
+
+// Violation describes a value violating a constraint of a JSON Schema.
+type Violation struct {
+	// Pointer is the JSON pointer to the offending value in the validated data.
+	Pointer string `json:"pointer"`
+	// SchemaPointer is the JSON pointer to the violated keyword in the schema.
+	SchemaPointer string `json:"schemaPointer"`
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// String returns the violation formatted as `pointer: message`.
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return pointer + ": " + v.Message
+}
+
+// SchemaError is the error returned by `mustValidate` when the data does not
+// match the schema. It holds the list of violations.
+type SchemaError struct {
+	Violations []Violation
+}
+
+// Error returns the list of violations in a single message.
+func (e *SchemaError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("value does not match the schema: %s", strings.Join(messages, "; "))
+}
+
+type JSONSchemaRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+}
+
+// NewRegistry creates a new instance of jsonschema registry.
+func NewRegistry() *JSONSchemaRegistry {
+	return &JSONSchemaRegistry{}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (jr *JSONSchemaRegistry) UID() string {
+	return "go-sprout/sprout.jsonschema"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (jr *JSONSchemaRegistry) LinkHandler(fh sprout.Handler) error {
+	jr.handler = fh
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (jr *JSONSchemaRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "validateJSONSchema", jr.ValidateJSONSchema)
+	sprout.AddFunction(funcsMap, "mustValidate", jr.MustValidate)
+	return nil
+}
@@ -0,0 +1,97 @@
+package maps
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Change describes a value added, removed or changed at a path between two
+// documents.
+type Change struct {
+	// Path is the path to the value, in the dot notation of `dig`.
+	Path string `json:"path"`
+	// Old is the value in the old document, nil when the value was added.
+	Old any `json:"old"`
+	// New is the value in the new document, nil when the value was removed.
+	New any `json:"new"`
+}
+
+// Changes holds the differences between two documents found by `mapDiff`.
+// Each list follows the order of the documents, with map keys sorted.
+type Changes struct {
+	Added   []Change `json:"added"`
+	Removed []Change `json:"removed"`
+	Changed []Change `json:"changed"`
+}
+
+// HasChanges reports whether the documents are different.
+func (c Changes) HasChanges() bool {
+	return len(c.Added) > 0 || len(c.Removed) > 0 || len(c.Changed) > 0
+}
+
+// diffValues records the differences between two values found at the same
+// path. Maps are compared key by key, in sorted order, and lists index by
+// index; any other difference, including a change of kind, is a change of
+// the whole value.
+func (mr *MapsRegistry) diffValues(changes *Changes, path string, oldValue, newValue any) {
+	if jsonEqual(oldValue, newValue) {
+		return
+	}
+	child := func(segment string) string {
+		segment = mr.escapeKeySegment(segment, ".")
+		if path == "" {
+			return segment
+		}
+		return path + "." + segment
+	}
+
+	switch before := containerValue(oldValue).(type) {
+	case map[string]any:
+		after, ok := containerValue(newValue).(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(before)+len(after))
+		for key := range before {
+			keys = append(keys, key)
+		}
+		for key := range after {
+			if _, exists := before[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			oldItem, inOld := before[key]
+			newItem, inNew := after[key]
+			switch {
+			case !inNew:
+				changes.Removed = append(changes.Removed, Change{Path: child(key), Old: oldItem})
+			case !inOld:
+				changes.Added = append(changes.Added, Change{Path: child(key), New: newItem})
+			default:
+				mr.diffValues(changes, child(key), oldItem, newItem)
+			}
+		}
+		return
+	case []any:
+		after, ok := containerValue(newValue).([]any)
+		if !ok {
+			break
+		}
+		for i := range max(len(before), len(after)) {
+			switch {
+			case i >= len(after):
+				changes.Removed = append(changes.Removed, Change{Path: child(strconv.Itoa(i)), Old: before[i]})
+			case i >= len(before):
+				changes.Added = append(changes.Added, Change{Path: child(strconv.Itoa(i)), New: after[i]})
+			default:
+				mr.diffValues(changes, child(strconv.Itoa(i)), before[i], after[i])
+			}
+		}
+		return
+	}
+
+	changes.Changed = append(changes.Changed, Change{Path: path, Old: oldValue, New: newValue})
+}
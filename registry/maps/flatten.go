@@ -0,0 +1,134 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-sprout/sprout/internal/helpers"
+)
+
+// validateSeparator checks that a separator can be used to join and split
+// flattened keys.
+func validateSeparator(sep string) error {
+	if sep == "" {
+		return errors.New("separator cannot be empty")
+	}
+	if strings.Contains(sep, "\\") {
+		return fmt.Errorf("separator %q cannot contain a backslash", sep)
+	}
+	return nil
+}
+
+// flattenValue adds the leaves of a value to the result, keyed by their path.
+// Maps and lists are traversed, lists using the indexes as segments; empty
+// maps and lists are kept as leaves so they survive unflattening.
+func (mr *MapsRegistry) flattenValue(result map[string]any, prefix, sep string, value any) {
+	join := func(segment string) string {
+		segment = mr.escapeKeySegment(segment, sep)
+		if prefix == "" {
+			return segment
+		}
+		return prefix + sep + segment
+	}
+
+	switch v := containerValue(value).(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for key, item := range v {
+				mr.flattenValue(result, join(key), sep, item)
+			}
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, item := range v {
+				mr.flattenValue(result, join(strconv.Itoa(i)), sep, item)
+			}
+			return
+		}
+	}
+	result[prefix] = value
+}
+
+// flatNode is a node of the tree built when unflattening keys.
+type flatNode struct {
+	key      string // flattened key defining the node, to report conflicts
+	value    any
+	children map[string]*flatNode
+}
+
+// unflattenKeys rebuilds the nested structure of flattened keys. Keys are
+// processed in sorted order so conflicts are reported deterministically.
+func (mr *MapsRegistry) unflattenKeys(dict map[string]any, sep string) (map[string]any, error) {
+	keys := make([]string, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	root := &flatNode{children: make(map[string]*flatNode)}
+	for _, key := range keys {
+		segments, err := helpers.SplitEscapedPath(key, sep, "separators")
+		if err != nil {
+			return nil, err
+		}
+
+		node := root
+		for _, segment := range segments {
+			if node.children == nil {
+				return nil, fmt.Errorf("key %q conflicts with key %q", key, node.key)
+			}
+			child, exists := node.children[segment]
+			if !exists {
+				child = &flatNode{key: key, children: make(map[string]*flatNode)}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		if node.children == nil || len(node.children) > 0 {
+			return nil, fmt.Errorf("key %q conflicts with key %q", key, node.key)
+		}
+		node.key, node.value, node.children = key, dict[key], nil
+	}
+
+	return root.build(true).(map[string]any), nil
+}
+
+// build returns the value of the node: its value for leaves, a list when the
+// segments of its children are the indexes 0 to n-1, and a map otherwise.
+// The root node is always a map.
+func (n *flatNode) build(root bool) any {
+	if n.children == nil {
+		return n.value
+	}
+
+	if !root && n.isList() {
+		list := make([]any, len(n.children))
+		for segment, child := range n.children {
+			index, _ := strconv.Atoi(segment)
+			list[index] = child.build(false)
+		}
+		return list
+	}
+
+	m := make(map[string]any, len(n.children))
+	for segment, child := range n.children {
+		m[segment] = child.build(false)
+	}
+	return m
+}
+
+// isList reports whether the segments of the children of the node are the
+// indexes 0 to n-1, written without leading zeros.
+func (n *flatNode) isList() bool {
+	for segment := range n.children {
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(n.children) || strconv.Itoa(index) != segment {
+			return false
+		}
+	}
+	return true
+}
@@ -102,6 +102,93 @@ func (mr *MapsRegistry) Unset(key string, dict map[string]any) (map[string]any,
 	return dict, nil
 }
 
+// SetPath sets a value at a nested path of the dictionary, using the dot
+// syntax of Dig where `\.` escapes a literal dot. Missing intermediate keys
+// are created as maps, and numeric segments index lists, an index equal to
+// the length of the list appending the value.
+//
+// Parameters:
+//
+//	path string - the dot-separated path of the value.
+//	value any - the value to set.
+//	dict map[string]any - the dictionary.
+//
+// Returns:
+//
+//	map[string]any - the dictionary with the value set.
+//	error - an error if the path is invalid or traverses a value which is neither a map nor a list.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: setPath].
+//
+// [Sprout Documentation: setPath]: https://docs.atom.codes/sprout/registries/maps#setpath
+func (mr *MapsRegistry) SetPath(path string, value any, dict map[string]any) (map[string]any, error) {
+	keys, err := mr.splitKeysWithEscapes([]string{path})
+	if err != nil {
+		return nil, fmt.Errorf("cannot split keys: %w", err)
+	}
+
+	if dict == nil {
+		dict = make(map[string]any)
+	}
+	if _, err := mr.setInPath(dict, keys, value); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// UnsetPath removes the value at a nested path of the dictionary, using the
+// dot syntax of Dig where `\.` escapes a literal dot. Numeric segments index
+// lists, the item being removed from its list. Missing paths are ignored.
+//
+// Parameters:
+//
+//	path string - the dot-separated path of the value.
+//	dict map[string]any - the dictionary.
+//
+// Returns:
+//
+//	map[string]any - the dictionary without the value.
+//	error - an error if the path is invalid.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: unsetPath].
+//
+// [Sprout Documentation: unsetPath]: https://docs.atom.codes/sprout/registries/maps#unsetpath
+func (mr *MapsRegistry) UnsetPath(path string, dict map[string]any) (map[string]any, error) {
+	keys, err := mr.splitKeysWithEscapes([]string{path})
+	if err != nil {
+		return nil, fmt.Errorf("cannot split keys: %w", err)
+	}
+
+	mr.unsetInPath(dict, keys)
+	return dict, nil
+}
+
+// HasPath checks if a value exists at a nested path of the dictionary, using
+// the dot syntax of Dig where `\.` escapes a literal dot. Numeric segments
+// index lists.
+//
+// Parameters:
+//
+//	path string - the dot-separated path of the value.
+//	dict map[string]any - the dictionary.
+//
+// Returns:
+//
+//	bool - true if a value, even nil, exists at the path, otherwise false.
+//	error - an error if the path is invalid.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: hasPath].
+//
+// [Sprout Documentation: hasPath]: https://docs.atom.codes/sprout/registries/maps#haspath
+func (mr *MapsRegistry) HasPath(path string, dict map[string]any) (bool, error) {
+	keys, err := mr.splitKeysWithEscapes([]string{path})
+	if err != nil {
+		return false, fmt.Errorf("cannot split keys: %w", err)
+	}
+
+	return mr.lookupPath(dict, keys), nil
+}
+
 // Keys retrieves all keys from one or more dictionaries.
 //
 // Parameters:
@@ -384,3 +471,205 @@ func (mr *MapsRegistry) MergeOverwrite(dest map[string]any, srcs ...map[string]a
 	}
 	return dest, nil
 }
+
+// FlattenMap flattens a nested map into a map of a single level, where the
+// keys are the paths to the leaves joined by the separator. Lists are
+// traversed using their indexes as segments. Separators and backslashes in
+// keys are escaped with a backslash, following the rules of `dig`, so the
+// map can be restored by UnflattenMap. Empty maps and lists are kept as
+// values.
+//
+// Parameters:
+//
+//	sep string - the separator of the key segments, like "." or "__".
+//	dict map[string]any - the nested map to flatten.
+//
+// Returns:
+//
+//	map[string]any - the flattened map.
+//	error - an error if the separator is empty or contains a backslash.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: flattenMap].
+//
+// [Sprout Documentation: flattenMap]: https://docs.atom.codes/sprout/registries/maps#flattenmap
+func (mr *MapsRegistry) FlattenMap(sep string, dict map[string]any) (map[string]any, error) {
+	if err := validateSeparator(sep); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(dict))
+	for key, value := range dict {
+		mr.flattenValue(result, mr.escapeKeySegment(key, sep), sep, value)
+	}
+	return result, nil
+}
+
+// UnflattenMap rebuilds a nested map from a map whose keys are paths joined
+// by the separator, like the output of FlattenMap. Escaped separators and
+// backslashes are resolved following the rules of `dig`. Maps whose keys
+// are all the indexes from 0 to n-1 are converted to lists.
+//
+// Parameters:
+//
+//	sep string - the separator of the key segments, like "." or "__".
+//	dict map[string]any - the flattened map.
+//
+// Returns:
+//
+//	map[string]any - the nested map.
+//	error - an error if the separator is invalid, a key has an empty segment or an invalid escape sequence, or a key is both a value and a parent of other keys.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: unflattenMap].
+//
+// [Sprout Documentation: unflattenMap]: https://docs.atom.codes/sprout/registries/maps#unflattenmap
+func (mr *MapsRegistry) UnflattenMap(sep string, dict map[string]any) (map[string]any, error) {
+	if err := validateSeparator(sep); err != nil {
+		return nil, err
+	}
+	return mr.unflattenKeys(dict, sep)
+}
+
+// MergeWith deeply merges multiple source maps into a destination map, with
+// options controlling the strategy. Nested maps are always merged
+// recursively. The destination map is modified and returned.
+//
+// The supported options are:
+//
+//   - slices: how lists are merged, "replace" (default) to replace the list,
+//     "append" to append the source items, or "union" to append the source
+//     items not already present and merge the maps sharing the same key.
+//   - key: the key identifying the maps of lists with the "union" strategy,
+//     "name" by default.
+//   - overwrite: whether source values replace existing values, true by
+//     default.
+//   - deleteNulls: whether null source values delete the keys, false by
+//     default.
+//   - strictTypes: whether merging values of different kinds, like a map
+//     into a list or a string into a number, is an error, false by default.
+//
+// Parameters:
+//
+//	options map[string]any - the merge options.
+//	dest map[string]any - the destination map.
+//	srcs ...map[string]any - one or more source maps, merged in order.
+//
+// Returns:
+//
+//	map[string]any - the merged destination map.
+//	error - an error if an option is invalid or types conflict with strictTypes.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: mergeWith].
+//
+// [Sprout Documentation: mergeWith]: https://docs.atom.codes/sprout/registries/maps#mergewith
+func (mr *MapsRegistry) MergeWith(options map[string]any, dest map[string]any, srcs ...map[string]any) (map[string]any, error) {
+	opts, err := parseMergeOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if dest == nil {
+		dest = make(map[string]any)
+	}
+	for _, src := range srcs {
+		if err := opts.mergeMaps(dest, jsonValue(src).(map[string]any), ""); err != nil {
+			return nil, err
+		}
+	}
+	return dest, nil
+}
+
+// MapDiff compares two documents, like maps from `fromJSON` or `fromYAML`,
+// and returns the added, removed and changed values with their paths. Maps
+// are compared key by key and lists index by index, numbers being compared
+// by value whatever their type. Paths use the dot notation of `dig`, with
+// list indexes as segments.
+//
+// Parameters:
+//
+//	oldValue any - the old document.
+//	newValue any - the new document.
+//
+// Returns:
+//
+//	Changes - the added, removed and changed values, with their old and new values.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: mapDiff].
+//
+// [Sprout Documentation: mapDiff]: https://docs.atom.codes/sprout/registries/maps#mapdiff
+func (mr *MapsRegistry) MapDiff(oldValue, newValue any) Changes {
+	changes := Changes{Added: []Change{}, Removed: []Change{}, Changed: []Change{}}
+	mr.diffValues(&changes, "", oldValue, newValue)
+	return changes
+}
+
+// JSONPatch applies a JSON Patch, as defined by RFC 6902, to a document and
+// returns the patched document. The patch is a list of operations, like the
+// output of `fromJSON` or `fromYAML`, each one being a map with an `op`
+// (add, remove, replace, move, copy or test), a `path` and, depending on the
+// operation, a `value` or a `from` JSON pointer. The given document is not
+// modified, and no change is returned if any operation fails.
+//
+// Parameters:
+//
+//	operations any - the list of operations to apply.
+//	document any - the document to patch.
+//
+// Returns:
+//
+//	any - the patched document.
+//	error - an error if an operation is invalid, targets a missing path or fails its test.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: jsonPatch].
+//
+// [Sprout Documentation: jsonPatch]: https://docs.atom.codes/sprout/registries/maps#jsonpatch
+func (mr *MapsRegistry) JSONPatch(operations any, document any) (any, error) {
+	result, err := applyJSONPatch(operations, document)
+	if err != nil {
+		return nil, fmt.Errorf("json patch: %w", err)
+	}
+	return result, nil
+}
+
+// JSONMergePatch applies a JSON Merge Patch, as defined by RFC 7386, to a
+// document and returns the patched document. Maps of the patch are merged
+// recursively into the document, null values remove the keys and any other
+// value, including lists, replaces the value of the document. The given
+// document is not modified.
+//
+// Parameters:
+//
+//	patch any - the merge patch.
+//	document any - the document to patch.
+//
+// Returns:
+//
+//	any - the patched document.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: jsonMergePatch].
+//
+// [Sprout Documentation: jsonMergePatch]: https://docs.atom.codes/sprout/registries/maps#jsonmergepatch
+func (mr *MapsRegistry) JSONMergePatch(patch any, document any) any {
+	return applyJSONMergePatch(jsonValue(patch), jsonValue(document))
+}
+
+// JSONDiff compares two documents and returns the JSON Patch, as defined by
+// RFC 6902, transforming the first one into the second one. Maps are
+// compared key by key, in sorted order, and lists index by index. The patch
+// only holds add, remove and replace operations, and is empty when the
+// documents are equal.
+//
+// Parameters:
+//
+//	a any - the original document.
+//	b any - the target document.
+//
+// Returns:
+//
+//	[]any - the list of operations, as maps with `op`, `path` and `value` keys.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: jsonDiff].
+//
+// [Sprout Documentation: jsonDiff]: https://docs.atom.codes/sprout/registries/maps#jsondiff
+func (mr *MapsRegistry) JSONDiff(a, b any) []any {
+	return diffJSON([]any{}, []string{}, jsonValue(a), jsonValue(b))
+}
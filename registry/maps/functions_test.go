@@ -47,6 +47,84 @@ func TestUnset(t *testing.T) {
 	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
 }
 
+func TestSetPath(t *testing.T) {
+	var dest map[string]any
+
+	manifest := func() map[string]any {
+		return map[string]any{
+			"spec": map[string]any{
+				"containers": []any{map[string]any{"name": "app", "image": "app:1.0"}},
+			},
+			"port": 80,
+		}
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestTopLevel", Input: `{{setPath "a" 1 .}}`, ExpectedOutput: "map[a:1]", Data: map[string]any{}},
+		{Name: "TestCreateIntermediate", Input: `{{setPath "a.b.c" 1 .}}`, ExpectedOutput: "map[a:map[b:map[c:1]] x:2]", Data: map[string]any{"x": 2}},
+		{Name: "TestOverwrite", Input: `{{(setPath "spec.replicas" 3 .).spec.replicas}}`, ExpectedOutput: "3", Data: map[string]any{"spec": map[string]any{"replicas": 1}}},
+		{Name: "TestListIndex", Input: `{{(setPath "spec.containers.0.image" "app:2.0" .).spec.containers}}`, ExpectedOutput: "[map[image:app:2.0 name:app]]", Data: manifest()},
+		{Name: "TestListAppend", Input: `{{(setPath "spec.containers.1.name" "sidecar" .).spec.containers}}`, ExpectedOutput: "[map[image:app:1.0 name:app] map[name:sidecar]]", Data: manifest()},
+		{Name: "TestEscapedDot", Input: `{{setPath "annotations.example\\.com/owner" "me" .}}`, ExpectedOutput: "map[annotations:map[example.com/owner:me]]", Data: map[string]any{}},
+		{Name: "TestNilIntermediate", Input: `{{setPath "a.b" 1 .}}`, ExpectedOutput: "map[a:map[b:1]]", Data: map[string]any{"a": nil}},
+		{Name: "TestNilDictionary", Input: `{{setPath "a.b" 1 .Nil}}`, ExpectedOutput: "map[a:map[b:1]]", Data: map[string]any{"Nil": dest}},
+		{Name: "TestNotAContainer", Input: `{{setPath "port.number" 1 .}}`, ExpectedErr: `cannot set key "number": value is not a map or a list but int`, Data: manifest()},
+		{Name: "TestIndexOutOfBounds", Input: `{{setPath "spec.containers.5" 1 .}}`, ExpectedErr: `cannot set key "5": array index 5 out of bounds`, Data: manifest()},
+		{Name: "TestInvalidIndex", Input: `{{setPath "spec.containers.first" 1 .}}`, ExpectedErr: `invalid array index "first"`, Data: manifest()},
+		{Name: "TestInvalidPath", Input: `{{setPath "a..b" 1 .}}`, ExpectedErr: "empty key segment", Data: map[string]any{}},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestUnsetPath(t *testing.T) {
+	manifest := func() map[string]any {
+		return map[string]any{
+			"spec": map[string]any{
+				"replicas":   1,
+				"containers": []any{map[string]any{"name": "app"}, map[string]any{"name": "sidecar"}},
+			},
+			"example.com": true,
+		}
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestNestedKey", Input: `{{(unsetPath "spec.replicas" .).spec.replicas}}`, ExpectedOutput: "<no value>", Data: manifest()},
+		{Name: "TestListItem", Input: `{{(unsetPath "spec.containers.0" .).spec.containers}}`, ExpectedOutput: "[map[name:sidecar]]", Data: manifest()},
+		{Name: "TestKeyInListItem", Input: `{{(unsetPath "spec.containers.1.name" .).spec.containers}}`, ExpectedOutput: "[map[name:app] map[]]", Data: manifest()},
+		{Name: "TestEscapedDot", Input: `{{unsetPath "example\\.com" . | hasKey "example.com"}}`, ExpectedOutput: "false", Data: manifest()},
+		{Name: "TestMissingPath", Input: `{{(unsetPath "spec.missing.key" .).spec.replicas}}`, ExpectedOutput: "1", Data: manifest()},
+		{Name: "TestMissingIndex", Input: `{{len (unsetPath "spec.containers.9" .).spec.containers}}`, ExpectedOutput: "2", Data: manifest()},
+		{Name: "TestThroughScalar", Input: `{{(unsetPath "spec.replicas.value" .).spec.replicas}}`, ExpectedOutput: "1", Data: manifest()},
+		{Name: "TestInvalidPath", Input: `{{unsetPath "a\\" .}}`, ExpectedErr: "trailing backslash", Data: manifest()},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestHasPath(t *testing.T) {
+	data := map[string]any{
+		"spec": map[string]any{
+			"containers": []any{map[string]any{"name": "app"}},
+			"empty":      nil,
+		},
+		"example.com": true,
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestExisting", Input: `{{hasPath "spec.containers" .}}`, ExpectedOutput: "true", Data: data},
+		{Name: "TestListIndex", Input: `{{hasPath "spec.containers.0.name" .}}`, ExpectedOutput: "true", Data: data},
+		{Name: "TestMissingIndex", Input: `{{hasPath "spec.containers.1" .}}`, ExpectedOutput: "false", Data: data},
+		{Name: "TestNilValue", Input: `{{hasPath "spec.empty" .}}`, ExpectedOutput: "true", Data: data},
+		{Name: "TestMissing", Input: `{{hasPath "spec.replicas" .}}`, ExpectedOutput: "false", Data: data},
+		{Name: "TestThroughScalar", Input: `{{hasPath "example.com" .}}`, ExpectedOutput: "false", Data: data},
+		{Name: "TestEscapedDot", Input: `{{hasPath "example\\.com" .}}`, ExpectedOutput: "true", Data: data},
+		{Name: "TestInvalidPath", Input: `{{hasPath ".a" .}}`, ExpectedErr: "empty key segment", Data: data},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
 func TestKeys(t *testing.T) {
 	tc := []pesticide.TestCase{
 		{Name: "TestEmpty", Input: `{{keys .}}`, ExpectedOutput: "[]"},
@@ -158,7 +236,8 @@ func TestDigWithEscapedKeys(t *testing.T) {
 		// Error: trailing backslash: \\ produces single \, which is trailing
 		{Name: "TestTrailingBackslash", Input: `{{dig "abc\\" .}}`, ExpectedErr: "trailing backslash"},
 		// Error: consecutive dots create empty segments
-		{Name: "TestConsecutiveDots", Input: `{{dig "a..b" .}}`, ExpectedErr: "empty key segment"},
+		{Name: "TestConsecutiveDots", Input: `{{dig "a..b" .}}`, ExpectedErr: `empty key segment in path "a..b" (consecutive or leading/trailing dots)`},
+		{Name: "TestConsecutiveDotsWithEscape", Input: `{{dig "a\\.b..c" .}}`, ExpectedErr: `(consecutive or leading/trailing dots)`},
 		// Error: leading dot
 		{Name: "TestLeadingDot", Input: `{{dig ".abc" .}}`, ExpectedErr: "empty key segment"},
 		// Error: trailing dot
@@ -220,3 +299,170 @@ func TestMergeOverwrite(t *testing.T) {
 
 	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
 }
+
+func TestFlattenMap(t *testing.T) {
+	data := map[string]any{
+		"Values": map[string]any{
+			"db":     map[string]any{"host": "localhost", "port": 5432},
+			"hosts":  []any{"a", map[string]any{"name": "b"}},
+			"empty":  map[string]any{},
+			"domain": map[string]any{"example.com": true},
+		},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestDotSeparator", Input: `{{flattenMap "." .Values}}`, ExpectedOutput: `map[db.host:localhost db.port:5432 domain.example\.com:true empty:map[] hosts.0:a hosts.1.name:b]`, Data: data},
+		{Name: "TestEnvSeparator", Input: `{{flattenMap "__" .Values.db}}`, ExpectedOutput: "map[host:localhost port:5432]", Data: data},
+		{Name: "TestNestedEnvSeparator", Input: `{{flattenMap "__" (dict "app" (dict "db" (dict "host" "x")))}}`, ExpectedOutput: "map[app__db__host:x]"},
+		{Name: "TestDigCompatible", Input: `{{$f := flattenMap "." .Values}}{{range $k, $v := $f}}{{if ne $k "empty"}}{{eq (dig $k $.Values) $v}}{{end}}{{end}}`, ExpectedOutput: "truetruetrue", Data: map[string]any{"Values": map[string]any{"a": map[string]any{"b": 1, "c.d": 2}, "e": 3, "empty": map[string]any{}}}},
+		{Name: "TestEmpty", Input: `{{flattenMap "." (dict)}}`, ExpectedOutput: "map[]"},
+		{Name: "TestEmptySeparator", Input: `{{flattenMap "" (dict)}}`, ExpectedErr: "separator cannot be empty"},
+		{Name: "TestBackslashSeparator", Input: `{{flattenMap "\\" (dict)}}`, ExpectedErr: "cannot contain a backslash"},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestUnflattenMap(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestDotSeparator", Input: `{{unflattenMap "." (dict "db.host" "localhost" "db.port" 5432 "name" "app")}}`, ExpectedOutput: "map[db:map[host:localhost port:5432] name:app]"},
+		{Name: "TestIndexSegments", Input: `{{unflattenMap "." (dict "hosts.0" "a" "hosts.1.name" "b")}}`, ExpectedOutput: "map[hosts:[a map[name:b]]]"},
+		{Name: "TestSparseIndexes", Input: `{{unflattenMap "." (dict "hosts.0" "a" "hosts.2" "b")}}`, ExpectedOutput: "map[hosts:map[0:a 2:b]]"},
+		{Name: "TestLeadingZero", Input: `{{unflattenMap "." (dict "hosts.00" "a")}}`, ExpectedOutput: "map[hosts:map[00:a]]"},
+		{Name: "TestRootIndexes", Input: `{{unflattenMap "." (dict "0" "a")}}`, ExpectedOutput: "map[0:a]"},
+		{Name: "TestEscapedSeparator", Input: `{{unflattenMap "." (dict "domain.example\\.com" true)}}`, ExpectedOutput: "map[domain:map[example.com:true]]"},
+		{Name: "TestEnvSeparator", Input: `{{unflattenMap "__" (dict "APP__DB_HOST" "x" "APP__PORT" 80)}}`, ExpectedOutput: "map[APP:map[DB_HOST:x PORT:80]]"},
+		{Name: "TestRoundTrip", Input: `{{unflattenMap "." (flattenMap "." .)}}`, ExpectedOutput: "map[a:map[b:[1 map[c.d:2]]] e:map[]]", Data: map[string]any{"a": map[string]any{"b": []any{1, map[string]any{"c.d": 2}}}, "e": map[string]any{}}},
+		{Name: "TestConflict", Input: `{{unflattenMap "." (dict "a" 1 "a.b" 2)}}`, ExpectedErr: `key "a.b" conflicts with key "a"`},
+		{Name: "TestConflictWithParent", Input: `{{unflattenMap "." (dict "a.b.c" 1 "a.b" 2)}}`, ExpectedErr: `key "a.b.c" conflicts with key "a.b"`},
+		{Name: "TestEmptySegment", Input: `{{unflattenMap "." (dict "a..b" 1)}}`, ExpectedErr: `empty key segment in path "a..b" (consecutive or leading/trailing separators)`},
+		{Name: "TestInvalidEscape", Input: `{{unflattenMap "." (dict "a\\nb" 1)}}`, ExpectedErr: "invalid escape sequence"},
+		{Name: "TestEmptySeparator", Input: `{{unflattenMap "" (dict)}}`, ExpectedErr: "separator cannot be empty"},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestMergeWith(t *testing.T) {
+	var dest map[string]any
+
+	base := func() map[string]any {
+		return map[string]any{
+			"image":  map[string]any{"repository": "app", "tag": "1.0"},
+			"ports":  []any{80},
+			"env":    []any{map[string]any{"name": "A", "value": "1"}, map[string]any{"name": "B", "value": "2"}},
+			"debug":  true,
+			"labels": map[string]any{"tier": "web"},
+		}
+	}
+	override := map[string]any{
+		"image": map[string]any{"tag": "2.0"},
+		"ports": []any{80, 443},
+		"env":   []any{map[string]any{"name": "B", "value": "3"}, map[string]any{"name": "C", "value": "4"}},
+		"debug": nil,
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestDefaults", Input: `{{$m := mergeWith (dict) .Base .Src}}{{$m.image}} {{$m.ports}} {{$m.debug}}`, ExpectedOutput: "map[repository:app tag:2.0] [80 443] <no value>", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestSliceAppend", Input: `{{(mergeWith (dict "slices" "append") .Base .Src).ports}}`, ExpectedOutput: "[80 80 443]", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestSliceUnion", Input: `{{(mergeWith (dict "slices" "union") .Base .Src).ports}}`, ExpectedOutput: "[80 443]", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestSliceUnionByKey", Input: `{{range (mergeWith (dict "slices" "union") .Base .Src).env}}{{.name}}={{.value}};{{end}}`, ExpectedOutput: "A=1;B=3;C=4;", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestSliceUnionCustomKey", Input: `{{(mergeWith (dict "slices" "union" "key" "id") .Base .Src).items}}`, ExpectedOutput: "[map[id:1 v:b] map[id:2]]", Data: map[string]any{"Base": map[string]any{"items": []any{map[string]any{"id": 1, "v": "a"}}}, "Src": map[string]any{"items": []any{map[string]any{"id": 1.0, "v": "b"}, map[string]any{"id": 2}}}}},
+		{Name: "TestDeleteNulls", Input: `{{$m := mergeWith (dict "deleteNulls" true) .Base .Src}}{{hasKey "debug" $m}}`, ExpectedOutput: "false", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestNoOverwrite", Input: `{{$m := mergeWith (dict "overwrite" false) .Base .Src}}{{$m.image.tag}} {{$m.ports}}`, ExpectedOutput: "1.0 [80]", Data: map[string]any{"Base": base(), "Src": override}},
+		{Name: "TestMultipleSources", Input: `{{mergeWith (dict) (dict "a" 1) (dict "b" 2) (dict "a" 3)}}`, ExpectedOutput: "map[a:3 b:2]"},
+		{Name: "TestNilDestination", Input: `{{mergeWith (dict) .Nil (dict "a" 1)}}`, ExpectedOutput: "map[a:1]", Data: map[string]any{"Nil": dest}},
+		{Name: "TestTypeConflict", Input: `{{mergeWith (dict) .Base .Src}}`, ExpectedOutput: "map[labels:web]", Data: map[string]any{"Base": map[string]any{"labels": map[string]any{"tier": "web"}}, "Src": map[string]any{"labels": "web"}}},
+		{Name: "TestStrictTypes", Input: `{{mergeWith (dict "strictTypes" true) .Base .Src}}`, ExpectedErr: `type conflict at "labels.tier": cannot merge a list into a string`, Data: map[string]any{"Base": map[string]any{"labels": map[string]any{"tier": "web"}}, "Src": map[string]any{"labels": map[string]any{"tier": []any{"web"}}}}},
+		{Name: "TestStrictTypesInList", Input: `{{mergeWith (dict "strictTypes" true "slices" "union") .Base .Src}}`, ExpectedErr: `type conflict at "env[0].value": cannot merge a map into a string`, Data: map[string]any{"Base": base(), "Src": map[string]any{"env": []any{map[string]any{"name": "A", "value": map[string]any{}}}}}},
+		{Name: "TestStrictTypesScalars", Input: `{{mergeWith (dict "strictTypes" true) .Base .Src}}`, ExpectedErr: `type conflict at "replicas": cannot merge a string into a number`, Data: map[string]any{"Base": map[string]any{"replicas": 3}, "Src": map[string]any{"replicas": "3"}}},
+		{Name: "TestStrictTypesNumbers", Input: `{{mergeWith (dict "strictTypes" true) .Base .Src}}`, ExpectedOutput: "map[ratio:1 replicas:5]", Data: map[string]any{"Base": map[string]any{"replicas": 3, "ratio": 0.5}, "Src": map[string]any{"replicas": int64(5), "ratio": 1}}},
+		{Name: "TestInvalidStrategy", Input: `{{mergeWith (dict "slices" "zip") (dict) (dict)}}`, ExpectedErr: `invalid slices strategy "zip"`},
+		{Name: "TestUnknownOption", Input: `{{mergeWith (dict "deep" true) (dict) (dict)}}`, ExpectedErr: `unknown merge option "deep"`},
+		{Name: "TestInvalidOptionType", Input: `{{mergeWith (dict "overwrite" "yes") (dict) (dict)}}`, ExpectedErr: `merge option "overwrite" has an invalid type string`},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestMapDiff(t *testing.T) {
+	data := map[string]any{
+		"Old": map[string]any{
+			"image":  map[string]any{"name": "app", "tag": "1.0"},
+			"ports":  []any{80, 443},
+			"debug":  true,
+			"domain": map[string]any{"example.com": 1},
+		},
+		"New": map[string]any{
+			"image":    map[string]any{"name": "app", "tag": "2.0"},
+			"ports":    []any{80},
+			"replicas": 3,
+			"domain":   map[string]any{"example.com": 1.0},
+		},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestAdded", Input: `{{range (mapDiff .Old .New).Added}}{{.Path}}={{.New}};{{end}}`, ExpectedOutput: "replicas=3;", Data: data},
+		{Name: "TestRemoved", Input: `{{range (mapDiff .Old .New).Removed}}{{.Path}}={{.Old}};{{end}}`, ExpectedOutput: "debug=true;ports.1=443;", Data: data},
+		{Name: "TestChanged", Input: `{{range (mapDiff .Old .New).Changed}}{{.Path}}: {{.Old}} -> {{.New}};{{end}}`, ExpectedOutput: "image.tag: 1.0 -> 2.0;", Data: data},
+		{Name: "TestHasChanges", Input: `{{(mapDiff .Old .New).HasChanges}} {{(mapDiff .Old .Old).HasChanges}}`, ExpectedOutput: "true false", Data: data},
+		{Name: "TestKindChange", Input: `{{range (mapDiff (dict "a" (list 1)) (dict "a" (dict "b" 1))).Changed}}{{.Path}}: {{.Old}} -> {{.New}}{{end}}`, ExpectedOutput: "a: [1] -> map[b:1]"},
+		{Name: "TestEscapedPath", Input: `{{range (mapDiff (dict "a.b" 1) (dict "a.b" 2)).Changed}}{{.Path}}{{end}}`, ExpectedOutput: `a\.b`},
+		{Name: "TestRootChange", Input: `{{range (mapDiff "a" "b").Changed}}[{{.Path}}] {{.Old}} -> {{.New}}{{end}}`, ExpectedOutput: "[] a -> b"},
+		{Name: "TestEmptyLists", Input: `{{$d := mapDiff (dict) (dict)}}{{len $d.Added}}{{len $d.Removed}}{{len $d.Changed}}`, ExpectedOutput: "000"},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestJSONPatch(t *testing.T) {
+	data := map[string]any{
+		"Doc": map[string]any{"spec": map[string]any{"replicas": 1, "ports": []any{80}}},
+		"Ops": []any{
+			map[string]any{"op": "replace", "path": "/spec/replicas", "value": 3},
+			map[string]any{"op": "add", "path": "/spec/ports/-", "value": 443},
+		},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestPatch", Input: `{{jsonPatch .Ops .Doc}}`, ExpectedOutput: "map[spec:map[ports:[80 443] replicas:3]]", Data: data},
+		{Name: "TestDocumentUnchanged", Input: `{{$_ := jsonPatch .Ops .Doc}}{{.Doc}}`, ExpectedOutput: "map[spec:map[ports:[80] replicas:1]]", Data: data},
+		{Name: "TestPipeline", Input: `{{.Doc | jsonPatch (list (dict "op" "remove" "path" "/spec"))}}`, ExpectedOutput: "map[]", Data: data},
+		{Name: "TestFailedTest", Input: `{{jsonPatch (list (dict "op" "test" "path" "/spec/replicas" "value" 2)) .Doc}}`, ExpectedErr: "json patch: operation 0: test failed", Data: data},
+		{Name: "TestMissingPath", Input: `{{jsonPatch (list (dict "op" "remove" "path" "/status")) .Doc}}`, ExpectedErr: "json patch: operation 0: path /status does not exist", Data: data},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	data := map[string]any{
+		"Doc":   map[string]any{"name": "app", "labels": map[string]any{"tier": "web", "env": "dev"}},
+		"Patch": map[string]any{"labels": map[string]any{"env": "prod", "tier": nil}, "replicas": 2},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestMergePatch", Input: `{{jsonMergePatch .Patch .Doc}}`, ExpectedOutput: "map[labels:map[env:prod] name:app replicas:2]", Data: data},
+		{Name: "TestDocumentUnchanged", Input: `{{$_ := jsonMergePatch .Patch .Doc}}{{.Doc.labels}}`, ExpectedOutput: "map[env:dev tier:web]", Data: data},
+		{Name: "TestScalarPatch", Input: `{{jsonMergePatch "value" .Doc}}`, ExpectedOutput: "value", Data: data},
+		{Name: "TestNilDocument", Input: `{{jsonMergePatch .Patch .Nil}}`, ExpectedOutput: "map[labels:map[env:prod] replicas:2]", Data: map[string]any{"Patch": data["Patch"], "Nil": nil}},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
+
+func TestJSONDiff(t *testing.T) {
+	data := map[string]any{
+		"A": map[string]any{"name": "app", "replicas": 1, "ports": []any{80}},
+		"B": map[string]any{"name": "app", "replicas": 3.0, "ports": []any{80, 443}, "debug": true},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestDiff", Input: `{{range jsonDiff .A .B}}{{.op}} {{.path}} {{.value}};{{end}}`, ExpectedOutput: "add /debug true;add /ports/1 443;replace /replicas 3;", Data: data},
+		{Name: "TestEqual", Input: `{{jsonDiff .A .A | len}}`, ExpectedOutput: "0", Data: data},
+		{Name: "TestNumericEquality", Input: `{{jsonDiff (dict "a" 1) (dict "a" 1.0) | len}}`, ExpectedOutput: "0"},
+		{Name: "TestRoundTrip", Input: `{{jsonPatch (jsonDiff .A .B) .A}}`, ExpectedOutput: "map[debug:true name:app ports:[80 443] replicas:3]", Data: data},
+	}
+
+	pesticide.RunTestCases(t, maps.NewRegistry(), tc)
+}
@@ -3,6 +3,8 @@ package maps
 import (
 	"fmt"
 	"strings"
+
+	"github.com/go-sprout/sprout/internal/helpers"
 )
 
 // digIntoDict navigates through a nested dictionary using a sequence of keys and returns the value found.
@@ -144,9 +146,8 @@ func (mr *MapsRegistry) splitKeysWithEscapes(keySet []string) ([]string, error)
 	return result, nil
 }
 
-// splitKeyOnUnescapedDots splits a key on unescaped dots and resolves escape sequences.
-// Empty segments (from consecutive dots like "a..b") are preserved intentionally,
-// as empty strings are valid map keys in Go.
+// splitKeyOnUnescapedDots splits a key on unescaped dots and resolves escape
+// sequences, see [helpers.SplitEscapedPath].
 //
 // Parameters:
 //
@@ -157,53 +158,146 @@ func (mr *MapsRegistry) splitKeysWithEscapes(keySet []string) ([]string, error)
 //	[]string - the key split on unescaped dots, with escapes resolved.
 //	error - an error if an invalid escape sequence is found or if the key contains empty segments.
 func (mr *MapsRegistry) splitKeyOnUnescapedDots(key string) ([]string, error) {
-	var parts []string
-	var segment strings.Builder
-	lastWasDot := true // Start true to detect leading dot
-
-	runes := []rune(key)
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
-
-		// Handle unescaped dot as path separator
-		if r == '.' {
-			if lastWasDot {
-				return nil, fmt.Errorf("empty key segment in path %q (consecutive or leading/trailing dots)", key)
-			}
-			parts = append(parts, segment.String())
-			segment.Reset()
-			lastWasDot = true
-			continue
-		}
+	return helpers.SplitEscapedPath(key, ".", "dots")
+}
 
-		lastWasDot = false
+// escapeKeySegment escapes the backslashes and the separators of a key
+// segment, so helpers.SplitEscapedPath returns it unchanged.
+//
+// Example:
+//
+//	fmt.Println(mr.escapeKeySegment("example.com", ".")) // Output: example\.com
+func (mr *MapsRegistry) escapeKeySegment(segment, sep string) string {
+	if !strings.Contains(segment, "\\") && !strings.Contains(segment, sep) {
+		return segment
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "\\", "\\\\"), sep, "\\"+sep)
+}
 
-		// Handle non-escape characters
-		if r != '\\' {
-			segment.WriteRune(r)
-			continue
+// setInPath sets a value at the path of keys inside a map or a list and
+// returns the updated node. Missing or nil intermediate values are created as
+// maps, and a list index equal to the length of the list appends the value.
+//
+// Parameters:
+//
+//	node any - the map or list to update.
+//	keys []string - the path of keys, list indexes being numeric keys.
+//	value any - the value to set.
+//
+// Returns:
+//
+//	any - the updated node, which is a new list when a value is appended.
+//	error - an error if a value of the path is neither a map nor a list, or an index is invalid.
+func (mr *MapsRegistry) setInPath(node any, keys []string, value any) (any, error) {
+	key := keys[0]
+	child := func(current any, exists bool) (any, error) {
+		if len(keys) == 1 {
+			return value, nil
 		}
-
-		// Handle escape sequences
-		if i+1 >= len(runes) {
-			return nil, fmt.Errorf("invalid escape sequence: trailing backslash in key %q", key)
+		if !exists || current == nil {
+			current = make(map[string]any)
 		}
+		return mr.setInPath(current, keys[1:], value)
+	}
 
-		next := runes[i+1]
-		switch next {
-		case '.', '\\':
-			segment.WriteRune(next)
-		default:
-			return nil, fmt.Errorf("invalid escape sequence: \\%c in key %q", next, key)
+	switch n := node.(type) {
+	case map[string]any:
+		current, exists := n[key]
+		updated, err := child(current, exists)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = updated
+		return n, nil
+	case []any:
+		index, err := arrayIndex(key, len(n), true)
+		if err != nil {
+			return nil, fmt.Errorf("cannot set key %q: %w", key, err)
+		}
+		if index == len(n) {
+			updated, err := child(nil, false)
+			if err != nil {
+				return nil, err
+			}
+			return append(n, updated), nil
 		}
-		i++
+		updated, err := child(n[index], true)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot set key %q: value is not a map or a list but %T", key, node)
 	}
+}
+
+// unsetInPath removes the value at the path of keys inside a map or a list
+// and returns the updated node. Missing paths are ignored.
+//
+// Parameters:
+//
+//	node any - the map or list to update.
+//	keys []string - the path of keys, list indexes being numeric keys.
+//
+// Returns:
+//
+//	any - the updated node, which is a new list when an item is removed.
+func (mr *MapsRegistry) unsetInPath(node any, keys []string) any {
+	key := keys[0]
 
-	// Check for trailing dot
-	if lastWasDot && len(runes) > 0 {
-		return nil, fmt.Errorf("empty key segment in path %q (consecutive or leading/trailing dots)", key)
+	switch n := node.(type) {
+	case map[string]any:
+		if len(keys) == 1 {
+			delete(n, key)
+		} else if current, exists := n[key]; exists {
+			n[key] = mr.unsetInPath(current, keys[1:])
+		}
+		return n
+	case []any:
+		index, err := arrayIndex(key, len(n), false)
+		if err != nil {
+			return n
+		}
+		if len(keys) == 1 {
+			return append(n[:index:index], n[index+1:]...)
+		}
+		n[index] = mr.unsetInPath(n[index], keys[1:])
+		return n
+	default:
+		return node
 	}
+}
 
-	parts = append(parts, segment.String())
-	return parts, nil
+// lookupPath reports whether a value exists at the path of keys inside a map
+// or a list.
+//
+// Parameters:
+//
+//	node any - the map or list to search.
+//	keys []string - the path of keys, list indexes being numeric keys.
+//
+// Returns:
+//
+//	bool - true if a value, even nil, exists at the path.
+func (mr *MapsRegistry) lookupPath(node any, keys []string) bool {
+	for _, key := range keys {
+		switch n := node.(type) {
+		case map[string]any:
+			value, exists := n[key]
+			if !exists {
+				return false
+			}
+			node = value
+		case []any:
+			index, err := arrayIndex(key, len(n), false)
+			if err != nil {
+				return false
+			}
+			node = n[index]
+		default:
+			return false
+		}
+	}
+	return true
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-sprout/sprout/internal/helpers"
 )
 
 func TestDigIntoDictWithNoKeys(t *testing.T) {
@@ -244,3 +246,19 @@ func TestSplitKeyOnUnescapedDots(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeKeySegment(t *testing.T) {
+	mr := NewRegistry()
+
+	for _, segment := range []string{"plain", "example.com", `back\slash`, `a\.b`, "a__b", ""} {
+		for _, sep := range []string{".", "__", "/"} {
+			escaped := mr.escapeKeySegment(segment, sep)
+			parts, err := helpers.SplitEscapedPath(escaped, sep, "separators")
+			require.NoError(t, err)
+			assert.Equal(t, []string{segment}, parts, "segment %q with separator %q", segment, sep)
+		}
+	}
+
+	assert.Equal(t, `example\.com`, mr.escapeKeySegment("example.com", "."))
+	assert.Equal(t, "example.com", mr.escapeKeySegment("example.com", "/"))
+}
@@ -11,6 +11,10 @@ func NewRegistry() *MapsRegistry {
 	return &MapsRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (mr *MapsRegistry) UID() string {
 	return "go-sprout/sprout.maps"
@@ -28,6 +32,9 @@ func (mr *MapsRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
 	sprout.AddFunction(funcsMap, "get", mr.Get)
 	sprout.AddFunction(funcsMap, "set", mr.Set)
 	sprout.AddFunction(funcsMap, "unset", mr.Unset)
+	sprout.AddFunction(funcsMap, "setPath", mr.SetPath)
+	sprout.AddFunction(funcsMap, "unsetPath", mr.UnsetPath)
+	sprout.AddFunction(funcsMap, "hasPath", mr.HasPath)
 	sprout.AddFunction(funcsMap, "keys", mr.Keys)
 	sprout.AddFunction(funcsMap, "values", mr.Values)
 	sprout.AddFunction(funcsMap, "pluck", mr.Pluck)
@@ -37,6 +44,13 @@ func (mr *MapsRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
 	sprout.AddFunction(funcsMap, "hasKey", mr.HasKey)
 	sprout.AddFunction(funcsMap, "merge", mr.Merge)
 	sprout.AddFunction(funcsMap, "mergeOverwrite", mr.MergeOverwrite)
+	sprout.AddFunction(funcsMap, "mergeWith", mr.MergeWith)
+	sprout.AddFunction(funcsMap, "flattenMap", mr.FlattenMap)
+	sprout.AddFunction(funcsMap, "unflattenMap", mr.UnflattenMap)
+	sprout.AddFunction(funcsMap, "mapDiff", mr.MapDiff)
+	sprout.AddFunction(funcsMap, "jsonPatch", mr.JSONPatch)
+	sprout.AddFunction(funcsMap, "jsonMergePatch", mr.JSONMergePatch)
+	sprout.AddFunction(funcsMap, "jsonDiff", mr.JSONDiff)
 	return nil
 }
 
@@ -0,0 +1,197 @@
+package maps
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Slice merging strategies of mergeWith.
+const (
+	sliceReplace = "replace"
+	sliceAppend  = "append"
+	sliceUnion   = "union"
+)
+
+// mergeOptions configures how mergeWith merges values.
+type mergeOptions struct {
+	slices      string // strategy to merge lists, one of replace, append or union
+	key         string // key identifying the maps of lists merged with the union strategy
+	overwrite   bool   // whether source values replace existing destination values
+	deleteNulls bool   // whether null source values delete the destination keys
+	strictTypes bool   // whether merging values of different kinds is an error
+}
+
+// parseMergeOptions reads the options of mergeWith, applying the defaults
+// for missing keys.
+func parseMergeOptions(options map[string]any) (mergeOptions, error) {
+	opts := mergeOptions{slices: sliceReplace, key: "name", overwrite: true}
+
+	for name, value := range options {
+		var ok bool
+		switch name {
+		case "slices":
+			opts.slices, ok = value.(string)
+			if ok && opts.slices != sliceReplace && opts.slices != sliceAppend && opts.slices != sliceUnion {
+				return opts, fmt.Errorf("invalid slices strategy %q, expected replace, append or union", opts.slices)
+			}
+		case "key":
+			opts.key, ok = value.(string)
+		case "overwrite":
+			opts.overwrite, ok = value.(bool)
+		case "deleteNulls":
+			opts.deleteNulls, ok = value.(bool)
+		case "strictTypes":
+			opts.strictTypes, ok = value.(bool)
+		default:
+			return opts, fmt.Errorf("unknown merge option %q", name)
+		}
+		if !ok {
+			return opts, fmt.Errorf("merge option %q has an invalid type %T", name, value)
+		}
+	}
+	return opts, nil
+}
+
+// mergeMaps merges the source map into the destination map, in place. Keys
+// are visited in sorted order so errors are deterministic.
+func (o mergeOptions) mergeMaps(dest, src map[string]any, path string) error {
+	keys := make([]string, 0, len(src))
+	for key := range src {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := src[key]
+		if value == nil && o.deleteNulls {
+			delete(dest, key)
+			continue
+		}
+
+		current, exists := dest[key]
+		if !exists || current == nil {
+			dest[key] = value
+			continue
+		}
+
+		merged, err := o.mergeValues(current, value, mergePath(path, key))
+		if err != nil {
+			return err
+		}
+		dest[key] = merged
+	}
+	return nil
+}
+
+// mergeValues merges two values found at the same path: maps are merged
+// recursively, lists according to the slices strategy, and other values
+// according to the overwrite option.
+func (o mergeOptions) mergeValues(dest, src any, path string) (any, error) {
+	switch s := src.(type) {
+	case map[string]any:
+		if d, ok := containerValue(dest).(map[string]any); ok {
+			return d, o.mergeMaps(d, s, path)
+		}
+	case []any:
+		if d, ok := containerValue(dest).([]any); ok {
+			return o.mergeSlices(d, s, path)
+		}
+	}
+
+	if o.strictTypes && src != nil && valueKind(dest) != valueKind(src) {
+		return nil, fmt.Errorf("type conflict at %q: cannot merge a %s into a %s", path, valueKind(src), valueKind(dest))
+	}
+	if o.overwrite {
+		return src, nil
+	}
+	return dest, nil
+}
+
+// mergeSlices merges two lists according to the slices strategy. With the
+// union strategy, maps sharing the same value for the key are merged, and
+// other items are appended unless the destination already holds them.
+func (o mergeOptions) mergeSlices(dest, src []any, path string) (any, error) {
+	switch o.slices {
+	case sliceAppend:
+		return append(slices.Clip(dest), src...), nil
+	case sliceUnion:
+		result := slices.Clone(dest)
+		for _, item := range src {
+			if index := o.unionIndex(result, item); index >= 0 {
+				merged, err := o.mergeValues(result[index], item, fmt.Sprintf("%s[%d]", path, index))
+				if err != nil {
+					return nil, err
+				}
+				result[index] = merged
+				continue
+			}
+			if !slices.ContainsFunc(result, func(existing any) bool { return jsonEqual(existing, item) }) {
+				result = append(result, item)
+			}
+		}
+		return result, nil
+	default:
+		if o.overwrite {
+			return src, nil
+		}
+		return dest, nil
+	}
+}
+
+// unionIndex returns the index of the map of the list sharing the value of
+// the key with the given item, or -1 if there is none.
+func (o mergeOptions) unionIndex(list []any, item any) int {
+	m, ok := item.(map[string]any)
+	if !ok || o.key == "" {
+		return -1
+	}
+	id, ok := m[o.key]
+	if !ok {
+		return -1
+	}
+	return slices.IndexFunc(list, func(existing any) bool {
+		other, ok := containerValue(existing).(map[string]any)
+		return ok && other[o.key] != nil && jsonEqual(other[o.key], id)
+	})
+}
+
+// containerValue converts maps and lists of any type to map[string]any and
+// []any, keeping values of these types as is.
+func containerValue(value any) any {
+	switch value.(type) {
+	case map[string]any, []any:
+		return value
+	}
+	return jsonValue(value)
+}
+
+// valueKind returns the kind of a value, as reported in type conflicts.
+// Numbers of any type share the same kind, so an int can replace a float.
+func valueKind(value any) string {
+	switch containerValue(value).(type) {
+	case map[string]any:
+		return "map"
+	case []any:
+		return "list"
+	}
+	if _, ok := jsonNumber(value); ok {
+		return "number"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	default:
+		return "value"
+	}
+}
+
+// mergePath appends a key to a dot-separated path.
+func mergePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
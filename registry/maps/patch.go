@@ -0,0 +1,444 @@
+package maps
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonValue returns a deep copy of a value where maps with string keys are
+// converted to map[string]any and slices and arrays to []any, so patches
+// never modify the given values. Other values are kept as is.
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[key] = jsonValue(item)
+		}
+		return m
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = jsonValue(item)
+		}
+		return list
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		m := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = jsonValue(iter.Value().Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		list := make([]any, rv.Len())
+		for i := range list {
+			list[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+// jsonEqual reports whether two values are equal in the JSON data model:
+// numbers are compared by value whatever their Go type, lists and maps
+// deeply.
+func jsonEqual(a, b any) bool {
+	if x, ok := jsonNumber(a); ok {
+		y, ok := jsonNumber(b)
+		return ok && x == y
+	}
+
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, exists := y[key]
+			if !exists || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// jsonNumber returns the value of a number, whatever its Go type.
+func jsonNumber(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// parsePointer splits a JSON pointer, as defined by RFC 6901, into its
+// unescaped reference tokens. The empty pointer refers to the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] != '~' {
+				continue
+			}
+			if j+1 >= len(token) || (token[j+1] != '0' && token[j+1] != '1') {
+				return nil, fmt.Errorf("invalid JSON pointer %q: ~ must be followed by 0 or 1", pointer)
+			}
+			j++
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// formatPointer builds a JSON pointer from reference tokens.
+func formatPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return sb.String()
+}
+
+// arrayIndex parses a reference token as an index of a list of the given
+// length. The index can be equal to the length when appending.
+func arrayIndex(token string, length int, appending bool) (int, error) {
+	if appending && token == "-" {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	maxIndex := length - 1
+	if appending {
+		maxIndex = length
+	}
+	if err != nil || index > maxIndex {
+		return 0, fmt.Errorf("array index %s out of bounds", token)
+	}
+	return index, nil
+}
+
+// pointerGet returns the value referenced by the tokens.
+func pointerGet(document any, tokens []string) (any, error) {
+	current := document
+	for i, token := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			value, exists := node[token]
+			if !exists {
+				return nil, fmt.Errorf("path %s does not exist", formatPointer(tokens[:i+1]))
+			}
+			current = value
+		case []any:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %w", formatPointer(tokens[:i+1]), err)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %s does not exist", formatPointer(tokens[:i+1]))
+		}
+	}
+	return current, nil
+}
+
+// pointerUpdate applies the update to the container holding the last token,
+// and returns the document with the updated container.
+func pointerUpdate(document any, tokens []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return update(document, tokens[0])
+	}
+
+	child, err := pointerGet(document, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := pointerUpdate(child, tokens[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := document.(type) {
+	case map[string]any:
+		node[tokens[0]] = updated
+	case []any:
+		index, _ := arrayIndex(tokens[0], len(node), false)
+		node[index] = updated
+	}
+	return document, nil
+}
+
+// pointerAdd adds a value at the location referenced by the tokens, as the
+// `add` operation of RFC 6902.
+func pointerAdd(document any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	if _, err := pointerGet(document, tokens[:len(tokens)-1]); err != nil {
+		return nil, err
+	}
+	return pointerUpdate(document, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %w", formatPointer(tokens), err)
+			}
+			return slices.Insert(node, index, value), nil
+		default:
+			return nil, fmt.Errorf("path %s does not exist: parent is not a map or a list", formatPointer(tokens))
+		}
+	})
+}
+
+// pointerRemove removes the value referenced by the tokens, as the `remove`
+// operation of RFC 6902.
+func pointerRemove(document any, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	if _, err := pointerGet(document, tokens); err != nil {
+		return nil, err
+	}
+	return pointerUpdate(document, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			delete(node, token)
+			return node, nil
+		default:
+			index, _ := arrayIndex(token, len(node.([]any)), false)
+			return slices.Delete(node.([]any), index, index+1), nil
+		}
+	})
+}
+
+// applyJSONPatch applies a list of operations, as defined by RFC 6902, to a
+// copy of the document. The operations are applied atomically: the document
+// is only returned when all of them succeed.
+func applyJSONPatch(operations any, document any) (any, error) {
+	list, ok := jsonValue(operations).([]any)
+	if !ok {
+		return nil, fmt.Errorf("patch must be a list of operations, got %T", operations)
+	}
+
+	result := jsonValue(document)
+	for i, item := range list {
+		var err error
+		if result, err = applyJSONPatchOperation(item, result); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+// applyJSONPatchOperation applies a single operation to the document.
+func applyJSONPatchOperation(item any, document any) (any, error) {
+	operation, ok := item.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("operation must be a map, got %T", item)
+	}
+
+	op, _ := operation["op"].(string)
+	tokens, err := operationPointer(operation, "path")
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "add", "replace", "test":
+		value, exists := operation["value"]
+		if !exists {
+			return nil, fmt.Errorf("%s operation requires a value", op)
+		}
+		switch op {
+		case "add":
+			return pointerAdd(document, tokens, value)
+		case "replace":
+			if document, err = pointerRemove(document, tokens); err != nil {
+				return nil, err
+			}
+			return pointerAdd(document, tokens, value)
+		default:
+			current, err := pointerGet(document, tokens)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(current, value) {
+				return nil, fmt.Errorf("test failed: value at path %s is %v, expected %v", formatPointer(tokens), current, value)
+			}
+			return document, nil
+		}
+	case "remove":
+		return pointerRemove(document, tokens)
+	case "move", "copy":
+		from, err := operationPointer(operation, "from")
+		if err != nil {
+			return nil, err
+		}
+		value, err := pointerGet(document, from)
+		if err != nil {
+			return nil, err
+		}
+		if op == "copy" {
+			return pointerAdd(document, tokens, jsonValue(value))
+		}
+		if len(from) < len(tokens) && slices.Equal(from, tokens[:len(from)]) {
+			return nil, fmt.Errorf("cannot move %s into one of its children", formatPointer(from))
+		}
+		if document, err = pointerRemove(document, from); err != nil {
+			return nil, err
+		}
+		return pointerAdd(document, tokens, value)
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op)
+	}
+}
+
+// operationPointer returns the parsed JSON pointer of a member of an operation.
+func operationPointer(operation map[string]any, member string) ([]string, error) {
+	pointer, ok := operation[member].(string)
+	if !ok {
+		return nil, fmt.Errorf("%q must be a JSON pointer string", member)
+	}
+	return parsePointer(pointer)
+}
+
+// applyJSONMergePatch applies a merge patch, as defined by RFC 7386, to a
+// copy of the document: maps are merged recursively, null values remove the
+// keys and any other value replaces the target.
+func applyJSONMergePatch(patch any, document any) any {
+	patchMap, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	target, ok := document.(map[string]any)
+	if !ok {
+		target = make(map[string]any, len(patchMap))
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(target, key)
+		} else {
+			target[key] = applyJSONMergePatch(value, target[key])
+		}
+	}
+	return target
+}
+
+// diffJSON appends to the patch the operations transforming a into b. Map
+// keys are visited in sorted order so the patch is deterministic.
+func diffJSON(patch []any, tokens []string, a, b any) []any {
+	if jsonEqual(a, b) {
+		return patch
+	}
+	operation := func(op string, tokens []string, value any) map[string]any {
+		result := map[string]any{"op": op, "path": formatPointer(tokens)}
+		if op != "remove" {
+			result["value"] = value
+		}
+		return result
+	}
+	child := func(token string) []string {
+		return append(slices.Clip(tokens), token)
+	}
+
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(x)+len(y))
+		for key := range x {
+			keys = append(keys, key)
+		}
+		for key := range y {
+			if _, exists := x[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			before, inA := x[key]
+			after, inB := y[key]
+			switch {
+			case !inB:
+				patch = append(patch, operation("remove", child(key), nil))
+			case !inA:
+				patch = append(patch, operation("add", child(key), after))
+			default:
+				patch = diffJSON(patch, child(key), before, after)
+			}
+		}
+		return patch
+	case []any:
+		y, ok := b.([]any)
+		if !ok {
+			break
+		}
+		common := min(len(x), len(y))
+		for i := range common {
+			patch = diffJSON(patch, child(strconv.Itoa(i)), x[i], y[i])
+		}
+		for i := len(x) - 1; i >= common; i-- {
+			patch = append(patch, operation("remove", child(strconv.Itoa(i)), nil))
+		}
+		for i := common; i < len(y); i++ {
+			patch = append(patch, operation("add", child(strconv.Itoa(i)), y[i]))
+		}
+		return patch
+	}
+
+	return append(patch, operation("replace", tokens, b))
+}
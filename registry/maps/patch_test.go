@@ -0,0 +1,171 @@
+package maps
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	// Examples of the appendix A of RFC 6902
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"AddObjectMember", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{"AddArrayElement", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"RemoveObjectMember", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{"RemoveArrayElement", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"ReplaceValue", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{"MoveValue", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"MoveArrayElement", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"TestSuccess", `{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"AddNestedMember", `{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo": "bar", "child": {"grandchild": {}}}`},
+		{"EscapedPointer", `{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}, {"op": "remove", "path": "/~1"}]`, `{"~1": 10}`},
+		{"AddArrayValue", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{"CopyValue", `{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "replace", "path": "/c/b", "value": 2}]`, `{"a": {"b": 1}, "c": {"b": 2}}`},
+		{"ReplaceRoot", `{"a": 1}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`},
+		{"ReplaceLastElement", `[1, 2]`, `[{"op": "replace", "path": "/1", "value": 3}]`, `[1, 3]`},
+		{"TestNull", `{"a": null}`, `[{"op": "test", "path": "/a", "value": null}]`, `{"a": null}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := applyJSONPatch(decodeJSON(t, test.patch), decodeJSON(t, test.document))
+			require.NoError(t, err)
+			assert.Equal(t, decodeJSON(t, test.expected), result)
+		})
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		err      string
+	}{
+		{"MissingTarget", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, "operation 0: path /baz does not exist"},
+		{"FailedTest", `{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`, "operation 0: test failed: value at path /baz is qux, expected bar"},
+		{"OutOfBounds", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": 1}]`, "path /foo/2: array index 2 out of bounds"},
+		{"LeadingZero", `{"foo": ["bar"]}`, `[{"op": "remove", "path": "/foo/00"}]`, `path /foo/00: invalid array index "00"`},
+		{"DashOnRemove", `{"foo": ["bar"]}`, `[{"op": "remove", "path": "/foo/-"}]`, `invalid array index "-"`},
+		{"RemoveMissing", `{"foo": 1}`, `[{"op": "remove", "path": "/bar"}]`, "path /bar does not exist"},
+		{"ReplaceMissing", `{"foo": 1}`, `[{"op": "replace", "path": "/bar", "value": 1}]`, "path /bar does not exist"},
+		{"AddToScalar", `{"foo": 1}`, `[{"op": "add", "path": "/foo/bar", "value": 1}]`, "path /foo/bar does not exist: parent is not a map or a list"},
+		{"MissingValue", `{}`, `[{"op": "add", "path": "/a"}]`, "add operation requires a value"},
+		{"MissingPath", `{}`, `[{"op": "remove"}]`, `"path" must be a JSON pointer string`},
+		{"MissingFrom", `{}`, `[{"op": "move", "path": "/a"}]`, `"from" must be a JSON pointer string`},
+		{"InvalidPointer", `{}`, `[{"op": "remove", "path": "a"}]`, "must be empty or start with /"},
+		{"InvalidEscape", `{}`, `[{"op": "remove", "path": "/a~2"}]`, "~ must be followed by 0 or 1"},
+		{"UnknownOperation", `{}`, `[{"op": "merge", "path": ""}]`, `unknown operation "merge"`},
+		{"MoveIntoChild", `{"a": {"b": {}}}`, `[{"op": "move", "from": "/a", "path": "/a/b/c"}]`, "cannot move /a into one of its children"},
+		{"NotAnOperation", `{}`, `["add"]`, "operation must be a map, got string"},
+		{"NotAList", `{}`, `{"op": "add"}`, "patch must be a list of operations"},
+		{"SecondOperation", `{}`, `[{"op": "add", "path": "/a", "value": 1}, {"op": "remove", "path": "/b"}]`, "operation 1: path /b does not exist"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := applyJSONPatch(decodeJSON(t, test.patch), decodeJSON(t, test.document))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestApplyJSONPatchDoesNotModifyDocument(t *testing.T) {
+	document := map[string]any{"a": []any{1, 2}, "b": map[string]any{"c": 3}}
+	patch := []map[string]any{
+		{"op": "add", "path": "/a/0", "value": 0},
+		{"op": "remove", "path": "/b/c"},
+	}
+
+	result, err := applyJSONPatch(patch, document)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": []any{0, 1, 2}, "b": map[string]any{}}, result)
+	assert.Equal(t, map[string]any{"a": []any{1, 2}, "b": map[string]any{"c": 3}}, document)
+}
+
+func TestApplyJSONMergePatch(t *testing.T) {
+	// Examples of the appendix A of RFC 7386
+	tests := []struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"a": "foo"}`, `null`, `null`},
+		{`{"a": "foo"}`, `"bar"`, `"bar"`},
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.patch, func(t *testing.T) {
+			result := applyJSONMergePatch(decodeJSON(t, test.patch), jsonValue(decodeJSON(t, test.target)))
+			assert.Equal(t, decodeJSON(t, test.expected), result)
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected string
+	}{
+		{"Equal", `{"a": [1, {"b": 2}]}`, `{"a": [1, {"b": 2}]}`, `[]`},
+		{"ObjectMembers", `{"a": 1, "b": 2, "c": 3}`, `{"b": 2, "c": 4, "d": 5}`, `[{"op": "remove", "path": "/a"}, {"op": "replace", "path": "/c", "value": 4}, {"op": "add", "path": "/d", "value": 5}]`},
+		{"Nested", `{"a": {"b": {"c": 1}}}`, `{"a": {"b": {"c": 2}}}`, `[{"op": "replace", "path": "/a/b/c", "value": 2}]`},
+		{"ArrayShrinks", `[1, 2, 3, 4]`, `[1, 5]`, `[{"op": "replace", "path": "/1", "value": 5}, {"op": "remove", "path": "/3"}, {"op": "remove", "path": "/2"}]`},
+		{"ArrayGrows", `[1]`, `[1, 2, 3]`, `[{"op": "add", "path": "/1", "value": 2}, {"op": "add", "path": "/2", "value": 3}]`},
+		{"TypeChange", `{"a": [1]}`, `{"a": {"0": 1}}`, `[{"op": "replace", "path": "/a", "value": {"0": 1}}]`},
+		{"Root", `1`, `"a"`, `[{"op": "replace", "path": "", "value": "a"}]`},
+		{"EscapedKeys", `{"a/b": 1, "c~d": 1}`, `{"a/b": 2}`, `[{"op": "replace", "path": "/a~1b", "value": 2}, {"op": "remove", "path": "/c~0d"}]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := decodeJSON(t, test.a), decodeJSON(t, test.b)
+			patch := diffJSON([]any{}, []string{}, a, b)
+			assert.Equal(t, decodeJSON(t, test.expected), patch)
+
+			// Applying the diff must give the target document.
+			result, err := applyJSONPatch(patch, a)
+			require.NoError(t, err)
+			assert.Equal(t, b, result)
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	assert.True(t, jsonEqual(1, 1.0))
+	assert.True(t, jsonEqual(map[string]any{"a": []any{int64(1)}}, map[string]any{"a": []any{1.0}}))
+	assert.False(t, jsonEqual(1, "1"))
+	assert.False(t, jsonEqual([]any{1}, []any{1, 2}))
+	assert.False(t, jsonEqual(map[string]any{"a": 1}, map[string]any{"b": 1}))
+	assert.True(t, jsonEqual(nil, nil))
+	assert.False(t, jsonEqual(nil, false))
+}
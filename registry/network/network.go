@@ -13,6 +13,10 @@ func NewRegistry() *NetworkRegistry {
 	return &NetworkRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (nr *NetworkRegistry) UID() string {
 	return "go-sprout/sprout.network"
@@ -2,8 +2,10 @@ package numeric
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"reflect"
+	"slices"
 
 	"github.com/spf13/cast"
 
@@ -127,6 +129,7 @@ func (nr *NumericRegistry) Add(values ...any) (any, error) {
 //
 // [Sprout Documentation: add1]: https://docs.atom.codes/sprout/registries/numeric#add1-add1f
 func (nr *NumericRegistry) Add1(value any) (any, error) {
+	value = numberValue(value)
 	one := reflect.ValueOf(1).Convert(reflect.TypeOf(value)).Interface()
 	return nr.Add(value, one)
 }
@@ -263,6 +266,7 @@ func (nr *NumericRegistry) Divf(values ...any) (any, error) {
 //
 // [Sprout Documentation: mod]: https://docs.atom.codes/sprout/registries/numeric#mod
 func (nr *NumericRegistry) Mod(value, divisor any) (any, error) {
+	value = numberValue(value)
 	floatX, err := cast.ToFloat64E(value)
 	if err != nil {
 		return 0, sprout.NewErrConvertFailed("float64", value, err)
@@ -295,13 +299,13 @@ func (nr *NumericRegistry) Mod(value, divisor any) (any, error) {
 //
 // [Sprout Documentation: min]: https://docs.atom.codes/sprout/registries/numeric#min
 func (nr *NumericRegistry) Min(subtrahend any, values ...any) (int64, error) {
-	intA, err := cast.ToInt64E(subtrahend)
+	intA, err := cast.ToInt64E(numberValue(subtrahend))
 	if err != nil {
 		return 0, sprout.NewErrConvertFailed("int64", subtrahend, err)
 	}
 
 	for _, b := range values {
-		intB, err := cast.ToInt64E(b)
+		intB, err := cast.ToInt64E(numberValue(b))
 		if err != nil {
 			return 0, sprout.NewErrConvertFailed("int64", b, err)
 		}
@@ -358,13 +362,13 @@ func (nr *NumericRegistry) Minf(subtrahend any, values ...any) (float64, error)
 //
 // [Sprout Documentation: max]: https://docs.atom.codes/sprout/registries/numeric#max
 func (nr *NumericRegistry) Max(value any, values ...any) (int64, error) {
-	intA, err := cast.ToInt64E(value)
+	intA, err := cast.ToInt64E(numberValue(value))
 	if err != nil {
 		return 0, sprout.NewErrConvertFailed("int64", value, err)
 	}
 
 	for _, b := range values {
-		intB, err := cast.ToInt64E(b)
+		intB, err := cast.ToInt64E(numberValue(b))
 		if err != nil {
 			return 0, sprout.NewErrConvertFailed("int64", b, err)
 		}
@@ -405,3 +409,283 @@ func (nr *NumericRegistry) Maxf(value any, values ...any) (float64, error) {
 	}
 	return floatA, nil
 }
+
+// Sum returns the sum of a list of numbers. Values are converted to float64
+// with the rules of the conversion registry, so numeric strings are accepted.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the sum of the numbers, 0 for an empty list.
+//	error - when the list is not a slice/array or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: sum].
+//
+// [Sprout Documentation: sum]: https://docs.atom.codes/sprout/registries/numeric#sum
+func (nr *NumericRegistry) Sum(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return cleanFloatPrecision(sum), nil
+}
+
+// Avg returns the arithmetic mean of a list of numbers.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the mean of the numbers.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: avg].
+//
+// [Sprout Documentation: avg]: https://docs.atom.codes/sprout/registries/numeric#avg-mean
+func (nr *NumericRegistry) Avg(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot compute the average: %w", errEmptyList)
+	}
+	return cleanFloatPrecision(mean(values)), nil
+}
+
+// Median returns the median of a list of numbers, the mean of the two middle
+// numbers for lists of even length.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the median of the numbers.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: median].
+//
+// [Sprout Documentation: median]: https://docs.atom.codes/sprout/registries/numeric#median
+func (nr *NumericRegistry) Median(list any) (float64, error) {
+	sorted, err := toSortedFloatList(list)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute the median: %w", err)
+	}
+	return cleanFloatPrecision(quantile(sorted, 0.5)), nil
+}
+
+// Mode returns the most frequent number of a list. When several numbers are
+// the most frequent, the smallest one is returned.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the most frequent number.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: mode].
+//
+// [Sprout Documentation: mode]: https://docs.atom.codes/sprout/registries/numeric#mode
+func (nr *NumericRegistry) Mode(list any) (float64, error) {
+	sorted, err := toSortedFloatList(list)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute the mode: %w", err)
+	}
+
+	// Equal numbers are consecutive in the sorted list, the first longest
+	// run is the smallest most frequent number.
+	mode, best := sorted[0], 0
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end] == sorted[start] {
+			end++
+		}
+		if end-start > best {
+			mode, best = sorted[start], end-start
+		}
+		start = end
+	}
+	return mode, nil
+}
+
+// Variance returns the population variance of a list of numbers: the mean of
+// the squared deviations from their mean.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the variance of the numbers.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: variance].
+//
+// [Sprout Documentation: variance]: https://docs.atom.codes/sprout/registries/numeric#variance
+func (nr *NumericRegistry) Variance(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot compute the variance: %w", errEmptyList)
+	}
+	return cleanFloatPrecision(variance(values)), nil
+}
+
+// Stddev returns the population standard deviation of a list of numbers, the
+// square root of their variance.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the standard deviation of the numbers.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: stddev].
+//
+// [Sprout Documentation: stddev]: https://docs.atom.codes/sprout/registries/numeric#stddev
+func (nr *NumericRegistry) Stddev(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot compute the standard deviation: %w", errEmptyList)
+	}
+	return cleanFloatPrecision(math.Sqrt(variance(values))), nil
+}
+
+// Percentile returns the p-th percentile of a list of numbers, interpolating
+// linearly between the two closest numbers, so the 50th percentile is the
+// median.
+//
+// Parameters:
+//
+//	p any - the percentile, between 0 and 100.
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the percentile of the numbers.
+//	error - when p is out of range, the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: percentile].
+//
+// [Sprout Documentation: percentile]: https://docs.atom.codes/sprout/registries/numeric#percentile
+func (nr *NumericRegistry) Percentile(p any, list any) (float64, error) {
+	percent, err := cast.ToFloat64E(p)
+	if err != nil {
+		return 0, sprout.NewErrConvertFailed("float64", p, err)
+	}
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %v", percent)
+	}
+
+	sorted, err := toSortedFloatList(list)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute the percentile: %w", err)
+	}
+	return cleanFloatPrecision(quantile(sorted, percent/100)), nil
+}
+
+// MinOf returns the smallest number of a list.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the smallest number.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: minOf].
+//
+// [Sprout Documentation: minOf]: https://docs.atom.codes/sprout/registries/numeric#minof
+func (nr *NumericRegistry) MinOf(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot compute the minimum: %w", errEmptyList)
+	}
+	return slices.Min(values), nil
+}
+
+// MaxOf returns the largest number of a list.
+//
+// Parameters:
+//
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	float64 - the largest number.
+//	error - when the list is empty, not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: maxOf].
+//
+// [Sprout Documentation: maxOf]: https://docs.atom.codes/sprout/registries/numeric#maxof
+func (nr *NumericRegistry) MaxOf(list any) (float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot compute the maximum: %w", errEmptyList)
+	}
+	return slices.Max(values), nil
+}
+
+// Histogram counts the numbers of a list falling in each bucket. The buckets
+// are either a number of buckets of equal width between the smallest and the
+// largest number, or a list of ascending edges delimiting the buckets, the
+// numbers outside the edges being ignored. A bucket counts the numbers v with
+// Min <= v < Max, the last bucket also counting the numbers equal to its Max.
+//
+// Parameters:
+//
+//	buckets any - the number of buckets, or the list of their edges.
+//	list any - the list of numbers.
+//
+// Returns:
+//
+//	[]Bucket - the buckets, in ascending order.
+//	error - when the buckets are invalid, the list is not a slice/array, or a value cannot be converted.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: histogram].
+//
+// [Sprout Documentation: histogram]: https://docs.atom.codes/sprout/registries/numeric#histogram
+func (nr *NumericRegistry) Histogram(buckets any, list any) ([]Bucket, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := histogramEdges(buckets, values)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute the histogram: %w", err)
+	}
+	return histogram(edges, values), nil
+}
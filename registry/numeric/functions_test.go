@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/decimal"
 	"github.com/go-sprout/sprout/registry/numeric"
 )
 
@@ -251,3 +252,150 @@ func TestMaxf(t *testing.T) {
 
 	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
 }
+
+func TestSum(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 1 2 3 | sum }}`, ExpectedOutput: "6"},
+		{Input: `{{ list 1 2.5 "3" | sum }}`, ExpectedOutput: "6.5"},
+		{Input: `{{ list 0.1 0.2 | sum }}`, ExpectedOutput: "0.3"},
+		{Input: `{{ .V | sum }}`, ExpectedOutput: "10", Data: map[string]any{"V": []int64{4, 6}}},
+		{Input: `{{ list | sum }}`, ExpectedOutput: "0"},
+		{Input: `{{ list 1 "a" | sum }}`, ExpectedErr: "failed to convert: a to float64"},
+		{Input: `{{ sum 1 }}`, ExpectedErr: "expected a list of numbers but got int"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestAvg(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 1 2 3 4 | avg }}`, ExpectedOutput: "2.5"},
+		{Input: `{{ list "2" 4.0 | mean }}`, ExpectedOutput: "3"},
+		{Input: `{{ list | avg }}`, ExpectedErr: "cannot compute the average: list is empty"},
+		{Input: `{{ list true "x" | avg }}`, ExpectedErr: "failed to convert: x to float64"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestMedian(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 3 1 2 | median }}`, ExpectedOutput: "2"},
+		{Input: `{{ list 4 1 3 2 | median }}`, ExpectedOutput: "2.5"},
+		{Input: `{{ list "10" 1.5 | median }}`, ExpectedOutput: "5.75"},
+		{Input: `{{ list | median }}`, ExpectedErr: "cannot compute the median: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestMode(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 1 2 2 3 | mode }}`, ExpectedOutput: "2"},
+		{Input: `{{ list 3 3 1 1 2 | mode }}`, ExpectedOutput: "1"},
+		{Input: `{{ list 2 "2" 2.0 1 | mode }}`, ExpectedOutput: "2"},
+		{Input: `{{ list 7 | mode }}`, ExpectedOutput: "7"},
+		{Input: `{{ list | mode }}`, ExpectedErr: "cannot compute the mode: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestVariance(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 2 4 4 4 5 5 7 9 | variance }}`, ExpectedOutput: "4"},
+		{Input: `{{ list 1 | variance }}`, ExpectedOutput: "0"},
+		{Input: `{{ list | variance }}`, ExpectedErr: "cannot compute the variance: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestStddev(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 2 4 4 4 5 5 7 9 | stddev }}`, ExpectedOutput: "2"},
+		{Input: `{{ list 1 2 | stddev }}`, ExpectedOutput: "0.5"},
+		{Input: `{{ list | stddev }}`, ExpectedErr: "cannot compute the standard deviation: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestPercentile(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 1 2 3 4 5 | percentile 50 }}`, ExpectedOutput: "3"},
+		{Input: `{{ list 15 20 35 40 50 | percentile 40 }}`, ExpectedOutput: "29"},
+		{Input: `{{ list 1 2 3 4 | percentile "90" }}`, ExpectedOutput: "3.7"},
+		{Input: `{{ list 5 1 | percentile 0 }}`, ExpectedOutput: "1"},
+		{Input: `{{ list 5 1 | percentile 100 }}`, ExpectedOutput: "5"},
+		{Input: `{{ list 1 | percentile 101 }}`, ExpectedErr: "percentile must be between 0 and 100, got 101"},
+		{Input: `{{ list 1 | percentile "p" }}`, ExpectedErr: "failed to convert: p to float64"},
+		{Input: `{{ list | percentile 50 }}`, ExpectedErr: "cannot compute the percentile: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestMinOf(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 3 -1.5 "2" | minOf }}`, ExpectedOutput: "-1.5"},
+		{Input: `{{ .V | minOf }}`, ExpectedOutput: "4", Data: map[string]any{"V": []uint{8, 4}}},
+		{Input: `{{ list | minOf }}`, ExpectedErr: "cannot compute the minimum: list is empty"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestMaxOf(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Input: `{{ list 3 -1.5 "12" | maxOf }}`, ExpectedOutput: "12"},
+		{Input: `{{ list | maxOf }}`, ExpectedErr: "cannot compute the maximum: list is empty"},
+		{Input: `{{ maxOf "1,2" }}`, ExpectedErr: "expected a list of numbers but got string"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestHistogram(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestBucketCount", Input: `{{ range list 1 2 2 3 4 5 | histogram 2 }}[{{ .Min }},{{ .Max }}]={{ .Count }} {{ end }}`, ExpectedOutput: "[1,3]=3 [3,5]=3 "},
+		{Name: "TestBucketEdges", Input: `{{ range list 50 120 180 250 900 | histogram (list 0 100 200 500) }}{{ .Max }}={{ .Count }} {{ end }}`, ExpectedOutput: "100=1 200=2 500=1 "},
+		{Name: "TestLastEdgeInclusive", Input: `{{ range list 0 10 | histogram (list 0 5 10) }}{{ .Count }} {{ end }}`, ExpectedOutput: "1 1 "},
+		{Name: "TestSameValues", Input: `{{ range list 3 3 | histogram 2 }}{{ .Count }} {{ end }}`, ExpectedOutput: "0 2 "},
+		{Name: "TestEmptyWithEdges", Input: `{{ range list | histogram (list 0 1) }}{{ .Count }}{{ end }}`, ExpectedOutput: "0"},
+		{Name: "TestEmptyWithCount", Input: `{{ list | histogram 2 }}`, ExpectedErr: "cannot compute the histogram: list is empty"},
+		{Name: "TestInvalidCount", Input: `{{ list 1 | histogram 0 }}`, ExpectedErr: "number of buckets must be positive, got 0"},
+		{Name: "TestSingleEdge", Input: `{{ list 1 | histogram (list 1) }}`, ExpectedErr: "at least two bucket edges are required"},
+		{Name: "TestUnsortedEdges", Input: `{{ list 1 | histogram (list 2 1) }}`, ExpectedErr: "bucket edges must be strictly increasing"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
+
+func TestDecimalValues(t *testing.T) {
+	toDecimal := func(value string) decimal.Decimal {
+		d, err := decimal.NewRegistry().Decimal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	data := map[string]any{"Price": toDecimal("12.30"), "Tax": toDecimal("0.70"), "Quantity": toDecimal("7"), "Prices": []any{toDecimal("1.50"), toDecimal("2.50"), toDecimal("5.00")}}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestAdd", Input: `{{ add .Price .Tax }}`, Data: data, ExpectedOutput: "13"},
+		{Name: "TestAddFirstNumber", Input: `{{ add 1 .Tax }}`, Data: data, ExpectedOutput: "1"},
+		{Name: "TestAdd1", Input: `{{ add1 .Price }}`, Data: data, ExpectedOutput: "13.3"},
+		{Name: "TestSub", Input: `{{ sub .Price .Tax }}`, Data: data, ExpectedOutput: "11.6"},
+		{Name: "TestMulf", Input: `{{ mulf .Price 2 }}`, Data: data, ExpectedOutput: "24.6"},
+		{Name: "TestDivf", Input: `{{ divf .Price 3 }}`, Data: data, ExpectedOutput: "4.1"},
+		{Name: "TestMod", Input: `{{ mod .Quantity 4 }}`, Data: data, ExpectedOutput: "3"},
+		{Name: "TestMin", Input: `{{ min .Price 20 }}`, Data: data, ExpectedOutput: "12"},
+		{Name: "TestMaxf", Input: `{{ maxf .Price .Tax }}`, Data: data, ExpectedOutput: "12.3"},
+		{Name: "TestFloor", Input: `{{ floor .Price }}`, Data: data, ExpectedOutput: "12"},
+		{Name: "TestSum", Input: `{{ sum .Prices }}`, Data: data, ExpectedOutput: "9"},
+		{Name: "TestMedian", Input: `{{ median .Prices }}`, Data: data, ExpectedOutput: "2.5"},
+	}
+
+	pesticide.RunTestCases(t, numeric.NewRegistry(), tc)
+}
@@ -9,6 +9,24 @@ import (
 	"github.com/go-sprout/sprout"
 )
 
+// floatValuer is implemented by the number types which are not Go numbers,
+// like the values of the decimal registry or json.Number.
+type floatValuer interface {
+	Float64() (float64, error)
+}
+
+// numberValue returns the float64 value of the numbers implementing
+// floatValuer, so they are handled like any float, and the value unchanged
+// otherwise.
+func numberValue(value any) any {
+	if number, ok := value.(floatValuer); ok {
+		if float, err := number.Float64(); err == nil {
+			return float
+		}
+	}
+	return value
+}
+
 // cleanFloatPrecision rounds a float64 to 15 significant decimal digits
 // to eliminate floating-point representation noise.
 // This fixes issues like 0.1+0.2=0.30000000000000004 becoming exactly 0.3.
@@ -62,7 +80,7 @@ func operateNumeric(values []any, op numericOperation, initial any) (any, error)
 	result = cleanFloatPrecision(result)
 
 	// Direct type assertion for common types to avoid reflection overhead
-	initialType := reflect.TypeOf(values[0])
+	initialType := reflect.TypeOf(numberValue(values[0]))
 	switch initialType.Kind() {
 	case reflect.Int:
 		return int(result), nil
@@ -25,6 +25,10 @@ func NewRegistry() *NumericRegistry {
 	return &NumericRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (nr *NumericRegistry) UID() string {
 	return "go-sprout/sprout.numeric"
@@ -53,6 +57,17 @@ func (nr *NumericRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error
 	sprout.AddFunction(funcsMap, "minf", nr.Minf)
 	sprout.AddFunction(funcsMap, "max", nr.Max)
 	sprout.AddFunction(funcsMap, "maxf", nr.Maxf)
+	sprout.AddFunction(funcsMap, "sum", nr.Sum)
+	sprout.AddFunction(funcsMap, "avg", nr.Avg)
+	sprout.AddFunction(funcsMap, "mean", nr.Avg)
+	sprout.AddFunction(funcsMap, "median", nr.Median)
+	sprout.AddFunction(funcsMap, "mode", nr.Mode)
+	sprout.AddFunction(funcsMap, "variance", nr.Variance)
+	sprout.AddFunction(funcsMap, "stddev", nr.Stddev)
+	sprout.AddFunction(funcsMap, "percentile", nr.Percentile)
+	sprout.AddFunction(funcsMap, "minOf", nr.MinOf)
+	sprout.AddFunction(funcsMap, "maxOf", nr.MaxOf)
+	sprout.AddFunction(funcsMap, "histogram", nr.Histogram)
 	return nil
 }
 
@@ -0,0 +1,171 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cast"
+
+	"github.com/go-sprout/sprout"
+)
+
+// errEmptyList is returned by the aggregations which are undefined for an
+// empty list.
+var errEmptyList = errors.New("list is empty")
+
+// Bucket is a bucket of the histogram built by `histogram`: the number of
+// values v with Min <= v < Max, the last bucket also counting the values
+// equal to its Max.
+type Bucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// toFloatList converts a list of numbers to a list of float64, accepting any
+// value that the conversion registry can turn into a float, like numeric
+// strings.
+//
+// Parameters:
+//
+//	list any - the slice or array of numbers.
+//
+// Returns:
+//
+//	[]float64 - the converted numbers.
+//	error - an error if the list is not a slice/array or a value cannot be converted.
+func toFloatList(list any) ([]float64, error) {
+	valueOfList := reflect.ValueOf(list)
+	switch valueOfList.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return nil, fmt.Errorf("expected a list of numbers but got %T", list)
+	}
+
+	values := make([]float64, valueOfList.Len())
+	for i := range values {
+		item := valueOfList.Index(i).Interface()
+		value, err := cast.ToFloat64E(item)
+		if err != nil {
+			return nil, sprout.NewErrConvertFailed("float64", item, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// toSortedFloatList converts a list of numbers with toFloatList and sorts
+// it, failing on empty lists.
+func toSortedFloatList(list any) ([]float64, error) {
+	values, err := toFloatList(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, errEmptyList
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
+// mean returns the arithmetic mean of a non-empty list of numbers.
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// variance returns the population variance of a non-empty list of numbers.
+func variance(values []float64) float64 {
+	m := mean(values)
+	var sum float64
+	for _, value := range values {
+		sum += (value - m) * (value - m)
+	}
+	return sum / float64(len(values))
+}
+
+// quantile returns the quantile q, between 0 and 1, of a sorted non-empty
+// list of numbers, interpolating linearly between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// histogramEdges returns the edges of the buckets of a histogram: either the
+// explicit edges given as a list, or the edges of count buckets of equal
+// width between the minimum and the maximum of the values.
+//
+// Parameters:
+//
+//	buckets any - the number of buckets, or the list of their edges.
+//	values []float64 - the values of the histogram.
+//
+// Returns:
+//
+//	[]float64 - the ascending edges, one more than the number of buckets.
+//	error - an error if the buckets are invalid.
+func histogramEdges(buckets any, values []float64) ([]float64, error) {
+	if kind := reflect.ValueOf(buckets).Kind(); kind == reflect.Slice || kind == reflect.Array {
+		edges, err := toFloatList(buckets)
+		if err != nil {
+			return nil, err
+		}
+		if len(edges) < 2 {
+			return nil, errors.New("at least two bucket edges are required")
+		}
+		if !sort.SliceIsSorted(edges, func(i, j int) bool { return edges[i] <= edges[j] }) {
+			return nil, errors.New("bucket edges must be strictly increasing")
+		}
+		return edges, nil
+	}
+
+	count, err := cast.ToIntE(buckets)
+	if err != nil {
+		return nil, sprout.NewErrConvertFailed("int", buckets, err)
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("number of buckets must be positive, got %d", count)
+	}
+	if len(values) == 0 {
+		return nil, errEmptyList
+	}
+
+	low, high := slices.Min(values), slices.Max(values)
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = cleanFloatPrecision(low + float64(i)*(high-low)/float64(count))
+	}
+	edges[count] = high
+	return edges, nil
+}
+
+// histogram counts the values falling in each bucket delimited by the edges,
+// ignoring the values outside the edges.
+func histogram(edges, values []float64) []Bucket {
+	result := make([]Bucket, len(edges)-1)
+	for i := range result {
+		result[i] = Bucket{Min: edges[i], Max: edges[i+1]}
+	}
+
+	last := len(result) - 1
+	for _, value := range values {
+		if value < edges[0] || value > edges[last+1] {
+			continue
+		}
+		// The bucket is the last one whose lower edge is <= value.
+		index := min(sort.SearchFloat64s(edges, math.Nextafter(value, math.Inf(1)))-1, last)
+		result[index].Count++
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFloatList(t *testing.T) {
+	values, err := toFloatList([]any{1, int8(2), uint(3), 4.5, "5.5", true})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3, 4.5, 5.5, 1}, values)
+
+	values, err = toFloatList([2]int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, values)
+
+	_, err = toFloatList(nil)
+	require.ErrorContains(t, err, "expected a list of numbers but got <nil>")
+
+	_, err = toFloatList([]any{[]int{1}})
+	require.ErrorContains(t, err, "failed to convert")
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4}
+
+	assert.InDelta(t, 1.0, quantile(sorted, 0), 1e-9)
+	assert.InDelta(t, 2.5, quantile(sorted, 0.5), 1e-9)
+	assert.InDelta(t, 3.25, quantile(sorted, 0.75), 1e-9)
+	assert.InDelta(t, 4.0, quantile(sorted, 1), 1e-9)
+	assert.InDelta(t, 7.0, quantile([]float64{7}, 0.3), 1e-9)
+}
+
+func TestHistogram(t *testing.T) {
+	edges, err := histogramEdges(4, []float64{0, 1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.5, 1, 1.5, 2}, edges)
+
+	buckets := histogram(edges, []float64{0, 0.49, 0.5, 2, 2.1, -1})
+	assert.Equal(t, []Bucket{
+		{Min: 0, Max: 0.5, Count: 2},
+		{Min: 0.5, Max: 1, Count: 1},
+		{Min: 1, Max: 1.5, Count: 0},
+		{Min: 1.5, Max: 2, Count: 1},
+	}, buckets)
+
+	_, err = histogramEdges("a", nil)
+	require.ErrorContains(t, err, "failed to convert: a to int")
+}
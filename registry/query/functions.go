@@ -0,0 +1,70 @@
+package query
+
+import "fmt"
+
+// JSONPath selects the values matching a JSONPath expression, as defined by
+// RFC 9535, in a document. The document is either decoded data, like maps,
+// slices and structs, or a JSON string. Selected values keep their type, so
+// the result can be used with `range`.
+//
+// Filter tests like `[?@.enabled]` check that the member exists; use
+// `[?@.enabled == true]` to select on a boolean value.
+//
+// Parameters:
+//
+//	expression string - the JSONPath expression, starting with `$`.
+//	document any - the data to query, or a JSON string.
+//
+// Returns:
+//
+//	[]any - the selected values, in document order, empty when nothing matches.
+//	error - an error if the expression is invalid or the JSON cannot be decoded.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: jsonpath].
+//
+// [Sprout Documentation: jsonpath]: https://docs.atom.codes/sprout/registries/query#jsonpath
+func (qr *QueryRegistry) JSONPath(expression string, document any) ([]any, error) {
+	data, err := parseDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath error: %w", err)
+	}
+
+	result, err := evaluateJSONPath(expression, data)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath error: %w", err)
+	}
+	return result, nil
+}
+
+// JMESPath evaluates a JMESPath expression against a document. The document
+// is either decoded data, like maps, slices and structs, or a JSON string.
+// The result is a single value, which is a list for projections and filters,
+// and nil when nothing matches. All built-in functions of the JMESPath
+// specification are supported; the keys of maps are visited in sorted
+// order.
+//
+// Parameters:
+//
+//	expression string - the JMESPath expression.
+//	document any - the data to query, or a JSON string.
+//
+// Returns:
+//
+//	any - the result of the expression.
+//	error - an error if the expression is invalid, a function is called with invalid arguments, or the JSON cannot be decoded.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: jmespath].
+//
+// [Sprout Documentation: jmespath]: https://docs.atom.codes/sprout/registries/query#jmespath
+func (qr *QueryRegistry) JMESPath(expression string, document any) (any, error) {
+	data, err := parseDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("jmespath error: %w", err)
+	}
+
+	result, err := evaluateJMESPath(expression, data)
+	if err != nil {
+		return nil, fmt.Errorf("jmespath error: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/query"
+)
+
+const itemsJSON = `{"items": [{"name": "api", "enabled": true, "port": 8080}, {"name": "worker", "enabled": false}, {"name": "web", "enabled": true, "port": 80}]}`
+
+type item struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Port    int    `json:"port,omitempty"`
+}
+
+func TestJSONPath(t *testing.T) {
+	data := map[string]any{
+		"raw": itemsJSON,
+		"data": map[string]any{
+			"items": []item{{"api", true, 8080}, {"worker", false, 0}, {"web", true, 80}},
+		},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestFilterExistence", Input: `{{ range jsonpath "$.items[?(@.enabled)].name" .data }}{{ . }};{{ end }}`, Data: data, ExpectedOutput: "api;worker;web;"},
+		{Name: "TestFilterBoolean", Input: `{{ range jsonpath "$.items[?@.enabled == true].name" .data }}{{ . }};{{ end }}`, Data: data, ExpectedOutput: "api;web;"},
+		{Name: "TestRawJSON", Input: `{{ range jsonpath "$.items[?@.port].name" .raw }}{{ . }};{{ end }}`, Data: data, ExpectedOutput: "api;web;"},
+		{Name: "TestTypedValues", Input: `{{ range jsonpath "$.items[*].port" .data }}{{ printf "%T=%v" . . }};{{ end }}`, Data: data, ExpectedOutput: "int=8080;int=80;"},
+		{Name: "TestDescendants", Input: `{{ jsonpath "$..name" .raw }}`, Data: data, ExpectedOutput: "[api worker web]"},
+		{Name: "TestNoMatch", Input: `{{ jsonpath "$.missing" .data | len }}`, Data: data, ExpectedOutput: "0"},
+		{Name: "TestInvalidExpression", Input: `{{ jsonpath "items" .data }}`, Data: data, ExpectedErr: "jsonpath error: at position 0: query must start with $"},
+		{Name: "TestInvalidJSON", Input: `{{ jsonpath "$" "{" }}`, ExpectedErr: "jsonpath error: invalid JSON document"},
+	}
+
+	pesticide.RunTestCases(t, query.NewRegistry(), tc)
+}
+
+func TestJMESPath(t *testing.T) {
+	data := map[string]any{
+		"raw": itemsJSON,
+		"data": map[string]any{
+			"items": []item{{"api", true, 8080}, {"worker", false, 0}, {"web", true, 80}},
+		},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestFilter", Input: `{{ range jmespath "items[?enabled].name" .data }}{{ . }};{{ end }}`, Data: data, ExpectedOutput: "api;web;"},
+		{Name: "TestRawJSON", Input: "{{ jmespath \"items[?port > `100`].name | [0]\" .raw }}", Data: data, ExpectedOutput: "api"},
+		{Name: "TestMultiSelect", Input: `{{ range jmespath "items[?enabled].{n: name, p: port}" .data }}{{ .n }}:{{ .p }};{{ end }}`, Data: data, ExpectedOutput: "api:8080;web:80;"},
+		{Name: "TestFunctions", Input: `{{ jmespath "join(', ', sort_by(items, &name)[*].name)" .data }}`, Data: data, ExpectedOutput: "api, web, worker"},
+		{Name: "TestTypedValues", Input: `{{ printf "%T" (jmespath "items[0].port" .data) }}`, Data: data, ExpectedOutput: "int"},
+		{Name: "TestNoMatch", Input: `{{ jmespath "missing" .data }}`, Data: data, ExpectedOutput: "<no value>"},
+		{Name: "TestInvalidExpression", Input: `{{ jmespath "items[" .data }}`, Data: data, ExpectedErr: "jmespath error: at position 6"},
+		{Name: "TestInvalidArguments", Input: `{{ jmespath "length(items[0].port)" .data }}`, Data: data, ExpectedErr: "jmespath error: length(): argument 1 must be of type string or array or object, got number"},
+		{Name: "TestInvalidJSON", Input: `{{ jmespath "@" "{" }}`, ExpectedErr: "jmespath error: invalid JSON document"},
+	}
+
+	pesticide.RunTestCases(t, query.NewRegistry(), tc)
+}
@@ -0,0 +1,209 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// parseDocument returns the document to query. Strings and byte slices are
+// decoded as JSON, other values are converted to maps and slices by
+// normalizeValue.
+func parseDocument(document any) (any, error) {
+	var raw []byte
+	switch v := document.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return normalizeValue(reflect.ValueOf(document)), nil
+	}
+
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+	return out, nil
+}
+
+// normalizeValue converts maps to map[string]any, slices and arrays to []any
+// and structs to map[string]any keyed by their JSON names, so queries only
+// traverse these types. Other values are kept as is, so results keep their
+// original type.
+func normalizeValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = normalizeValue(iter.Value())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			list[i] = normalizeValue(v.Index(i))
+		}
+		return list
+	case reflect.Struct:
+		if _, isMarshaler := v.Interface().(json.Marshaler); isMarshaler {
+			return v.Interface()
+		}
+		return normalizeStruct(v)
+	default:
+		return v.Interface()
+	}
+}
+
+// normalizeStruct converts the exported fields of a struct to a map, using
+// the names of their `json` tag and omitting the fields tagged with "-".
+// Embedded structs without tag are flattened.
+func normalizeStruct(v reflect.Value) map[string]any {
+	m := make(map[string]any, v.NumField())
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if strings.Contains(options, "omitempty") && v.Field(i).IsZero() {
+			continue
+		}
+
+		value := normalizeValue(v.Field(i))
+		if name == "" && field.Anonymous {
+			if embedded, ok := value.(map[string]any); ok {
+				for key, item := range embedded {
+					if _, exists := m[key]; !exists {
+						m[key] = item
+					}
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		m[name] = value
+	}
+	return m
+}
+
+// toNumber returns the value of a number, whatever its Go type.
+func toNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// toString returns the value of a string, whatever its Go type.
+func toString(value any) (string, bool) {
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+	return "", false
+}
+
+// toBool returns the value of a boolean, whatever its Go type.
+func toBool(value any) (bool, bool) {
+	if b, ok := value.(bool); ok {
+		return b, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Bool {
+		return rv.Bool(), true
+	}
+	return false, false
+}
+
+// valuesEqual reports whether two values are equal in the JSON data model:
+// numbers are compared by value and lists and maps deeply.
+func valuesEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+	if x, ok := toString(a); ok {
+		y, ok := toString(b)
+		return ok && x == y
+	}
+	if x, ok := toBool(a); ok {
+		y, ok := toBool(b)
+		return ok && x == y
+	}
+
+	switch x := a.(type) {
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !valuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, exists := y[key]
+			if !exists || !valuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
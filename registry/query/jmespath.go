@@ -0,0 +1,1256 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jmespathTokenType is the type of a token of a JMESPath expression.
+type jmespathTokenType int
+
+const (
+	jmespathEOF jmespathTokenType = iota
+	jmespathUnquotedIdentifier
+	jmespathQuotedIdentifier
+	jmespathLiteral
+	jmespathNumber
+	jmespathDot
+	jmespathStar
+	jmespathFlatten
+	jmespathFilter
+	jmespathLbracket
+	jmespathRbracket
+	jmespathLbrace
+	jmespathRbrace
+	jmespathLparen
+	jmespathRparen
+	jmespathComma
+	jmespathColon
+	jmespathPipe
+	jmespathOr
+	jmespathAnd
+	jmespathNot
+	jmespathCurrent
+	jmespathExpref
+	jmespathEQ
+	jmespathNE
+	jmespathLT
+	jmespathLTE
+	jmespathGT
+	jmespathGTE
+)
+
+// jmespathBindingPowers are the binding powers of the tokens, as defined by
+// the reference implementation of JMESPath.
+var jmespathBindingPowers = map[jmespathTokenType]int{
+	jmespathPipe:     1,
+	jmespathOr:       2,
+	jmespathAnd:      3,
+	jmespathEQ:       5,
+	jmespathNE:       5,
+	jmespathLT:       5,
+	jmespathLTE:      5,
+	jmespathGT:       5,
+	jmespathGTE:      5,
+	jmespathFlatten:  9,
+	jmespathStar:     20,
+	jmespathFilter:   21,
+	jmespathDot:      40,
+	jmespathNot:      45,
+	jmespathLbrace:   50,
+	jmespathLbracket: 55,
+	jmespathLparen:   60,
+}
+
+// jmespathProjectionStop is the binding power under which a token ends the
+// right-hand side of a projection.
+const jmespathProjectionStop = 10
+
+type jmespathToken struct {
+	kind  jmespathTokenType
+	text  string
+	value any // value of literals, numbers and identifiers
+	pos   int
+}
+
+// jmespathNodeType is the type of a node of the AST of a JMESPath expression.
+type jmespathNodeType int
+
+const (
+	jmespathField jmespathNodeType = iota
+	jmespathSubexpression
+	jmespathIndex
+	jmespathSlice
+	jmespathIndexExpression
+	jmespathProjection
+	jmespathValueProjection
+	jmespathFilterProjection
+	jmespathFlattenNode
+	jmespathIdentity
+	jmespathLiteralNode
+	jmespathMultiSelectList
+	jmespathMultiSelectHash
+	jmespathKeyValuePair
+	jmespathOrNode
+	jmespathAndNode
+	jmespathNotNode
+	jmespathComparator
+	jmespathPipeNode
+	jmespathFunction
+	jmespathExprefNode
+)
+
+type jmespathNode struct {
+	kind     jmespathNodeType
+	value    any // field name, index, literal value, comparator or function name
+	children []*jmespathNode
+}
+
+// jmespathReference is the value of an expression reference, evaluated by the
+// functions taking one, like sort_by.
+type jmespathReference struct {
+	node *jmespathNode
+}
+
+// evaluateJMESPath evaluates a JMESPath expression against a document.
+func evaluateJMESPath(expression string, document any) (any, error) {
+	node, err := parseJMESPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	return node.evaluate(document)
+}
+
+// jmespathLex splits an expression into tokens.
+func jmespathLex(expression string) ([]jmespathToken, error) {
+	var tokens []jmespathToken
+	simple := map[byte]jmespathTokenType{
+		'.': jmespathDot, '*': jmespathStar, ']': jmespathRbracket, ',': jmespathComma,
+		':': jmespathColon, '@': jmespathCurrent, '(': jmespathLparen, ')': jmespathRparen,
+		'{': jmespathLbrace, '}': jmespathRbrace,
+	}
+
+	for pos := 0; pos < len(expression); {
+		c := expression[pos]
+		start := pos
+		emit := func(kind jmespathTokenType, length int) {
+			tokens = append(tokens, jmespathToken{kind: kind, text: expression[start : start+length], pos: start})
+			pos += length
+		}
+		next := byte(0)
+		if pos+1 < len(expression) {
+			next = expression[pos+1]
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case simple[c] != jmespathEOF:
+			emit(simple[c], 1)
+		case c == '[':
+			switch next {
+			case '?':
+				emit(jmespathFilter, 2)
+			case ']':
+				emit(jmespathFlatten, 2)
+			default:
+				emit(jmespathLbracket, 1)
+			}
+		case c == '&':
+			if next == '&' {
+				emit(jmespathAnd, 2)
+			} else {
+				emit(jmespathExpref, 1)
+			}
+		case c == '|':
+			if next == '|' {
+				emit(jmespathOr, 2)
+			} else {
+				emit(jmespathPipe, 1)
+			}
+		case c == '!':
+			if next == '=' {
+				emit(jmespathNE, 2)
+			} else {
+				emit(jmespathNot, 1)
+			}
+		case c == '<':
+			if next == '=' {
+				emit(jmespathLTE, 2)
+			} else {
+				emit(jmespathLT, 1)
+			}
+		case c == '>':
+			if next == '=' {
+				emit(jmespathGTE, 2)
+			} else {
+				emit(jmespathGT, 1)
+			}
+		case c == '=':
+			if next != '=' {
+				return nil, fmt.Errorf("at position %d: unexpected \"=\", use \"==\" to compare", pos)
+			}
+			emit(jmespathEQ, 2)
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			for pos < len(expression) && isJMESPathIdentifierChar(expression[pos]) {
+				pos++
+			}
+			text := expression[start:pos]
+			tokens = append(tokens, jmespathToken{kind: jmespathUnquotedIdentifier, text: text, value: text, pos: start})
+		case c == '-' || (c >= '0' && c <= '9'):
+			pos++
+			for pos < len(expression) && expression[pos] >= '0' && expression[pos] <= '9' {
+				pos++
+			}
+			n, err := strconv.Atoi(expression[start:pos])
+			if err != nil {
+				return nil, fmt.Errorf("at position %d: invalid number %q", start, expression[start:pos])
+			}
+			tokens = append(tokens, jmespathToken{kind: jmespathNumber, text: expression[start:pos], value: n, pos: start})
+		case c == '"':
+			end, err := jmespathDelimited(expression, pos, '"')
+			if err != nil {
+				return nil, err
+			}
+			var name string
+			if err := json.Unmarshal([]byte(expression[start:end]), &name); err != nil {
+				return nil, fmt.Errorf("at position %d: invalid quoted identifier %s", start, expression[start:end])
+			}
+			tokens = append(tokens, jmespathToken{kind: jmespathQuotedIdentifier, text: expression[start:end], value: name, pos: start})
+			pos = end
+		case c == '\'':
+			end, err := jmespathDelimited(expression, pos, '\'')
+			if err != nil {
+				return nil, err
+			}
+			raw := strings.ReplaceAll(expression[start+1:end-1], `\'`, `'`)
+			tokens = append(tokens, jmespathToken{kind: jmespathLiteral, text: expression[start:end], value: raw, pos: start})
+			pos = end
+		case c == '`':
+			end, err := jmespathDelimited(expression, pos, '`')
+			if err != nil {
+				return nil, err
+			}
+			raw := strings.ReplaceAll(expression[start+1:end-1], "\\`", "`")
+			var value any
+			if err := json.Unmarshal([]byte(raw), &value); err != nil {
+				return nil, fmt.Errorf("at position %d: invalid JSON literal %s", start, expression[start:end])
+			}
+			tokens = append(tokens, jmespathToken{kind: jmespathLiteral, text: expression[start:end], value: value, pos: start})
+			pos = end
+		default:
+			r, _ := utf8.DecodeRuneInString(expression[pos:])
+			return nil, fmt.Errorf("at position %d: unexpected character %q", pos, r)
+		}
+	}
+
+	return append(tokens, jmespathToken{kind: jmespathEOF, pos: len(expression)}), nil
+}
+
+func isJMESPathIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// jmespathDelimited returns the position after the closing delimiter of the
+// token starting at pos, skipping escaped characters.
+func jmespathDelimited(expression string, pos int, delimiter byte) (int, error) {
+	for i := pos + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case delimiter:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("at position %d: unterminated %c", pos, delimiter)
+}
+
+// jmespathParser is a top-down operator precedence parser, following the
+// reference implementation of JMESPath.
+type jmespathParser struct {
+	tokens []jmespathToken
+	index  int
+}
+
+// parseJMESPath parses a JMESPath expression.
+func parseJMESPath(expression string) (*jmespathNode, error) {
+	tokens, err := jmespathLex(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &jmespathParser{tokens: tokens}
+	node, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.current().kind != jmespathEOF {
+		return nil, p.errorf("unexpected token %q", p.current().text)
+	}
+	return node, nil
+}
+
+func (p *jmespathParser) current() jmespathToken {
+	return p.tokens[p.index]
+}
+
+func (p *jmespathParser) lookahead(n int) jmespathTokenType {
+	if p.index+n >= len(p.tokens) {
+		return jmespathEOF
+	}
+	return p.tokens[p.index+n].kind
+}
+
+func (p *jmespathParser) advance() {
+	if p.index < len(p.tokens)-1 {
+		p.index++
+	}
+}
+
+func (p *jmespathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at position %d: %s", p.current().pos, fmt.Sprintf(format, args...))
+}
+
+// match consumes the next token, which must be of the given type.
+func (p *jmespathParser) match(kind jmespathTokenType, expected string) error {
+	if p.current().kind != kind {
+		if p.current().kind == jmespathEOF {
+			return p.errorf("expected %s, found end of expression", expected)
+		}
+		return p.errorf("expected %s, found %q", expected, p.current().text)
+	}
+	p.advance()
+	return nil
+}
+
+func (p *jmespathParser) expression(bindingPower int) (*jmespathNode, error) {
+	token := p.current()
+	p.advance()
+	left, err := p.nud(token)
+	if err != nil {
+		return nil, err
+	}
+
+	for bindingPower < jmespathBindingPowers[p.current().kind] {
+		token := p.current()
+		p.advance()
+		if left, err = p.led(token, left); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+// nud parses the token at the start of an expression.
+func (p *jmespathParser) nud(token jmespathToken) (*jmespathNode, error) {
+	identity := &jmespathNode{kind: jmespathIdentity}
+
+	switch token.kind {
+	case jmespathLiteral:
+		return &jmespathNode{kind: jmespathLiteralNode, value: token.value}, nil
+	case jmespathUnquotedIdentifier:
+		return &jmespathNode{kind: jmespathField, value: token.value}, nil
+	case jmespathQuotedIdentifier:
+		if p.current().kind == jmespathLparen {
+			return nil, p.errorf("quoted identifiers cannot be used as function names")
+		}
+		return &jmespathNode{kind: jmespathField, value: token.value}, nil
+	case jmespathStar:
+		right := identity
+		if p.current().kind != jmespathRbracket {
+			var err error
+			if right, err = p.projectionRHS(jmespathBindingPowers[jmespathStar]); err != nil {
+				return nil, err
+			}
+		}
+		return &jmespathNode{kind: jmespathValueProjection, children: []*jmespathNode{identity, right}}, nil
+	case jmespathFilter:
+		return p.led(token, identity)
+	case jmespathLbrace:
+		return p.multiSelectHash()
+	case jmespathLparen:
+		node, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		return node, p.match(jmespathRparen, `")"`)
+	case jmespathFlatten:
+		left := &jmespathNode{kind: jmespathFlattenNode, children: []*jmespathNode{identity}}
+		right, err := p.projectionRHS(jmespathBindingPowers[jmespathFlatten])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathProjection, children: []*jmespathNode{left, right}}, nil
+	case jmespathNot:
+		operand, err := p.expression(jmespathBindingPowers[jmespathNot])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathNotNode, children: []*jmespathNode{operand}}, nil
+	case jmespathLbracket:
+		switch {
+		case p.current().kind == jmespathNumber || p.current().kind == jmespathColon:
+			right, err := p.indexExpression()
+			if err != nil {
+				return nil, err
+			}
+			return p.projectIfSlice(identity, right)
+		case p.current().kind == jmespathStar && p.lookahead(1) == jmespathRbracket:
+			p.advance()
+			p.advance()
+			right, err := p.projectionRHS(jmespathBindingPowers[jmespathStar])
+			if err != nil {
+				return nil, err
+			}
+			return &jmespathNode{kind: jmespathProjection, children: []*jmespathNode{identity, right}}, nil
+		default:
+			return p.multiSelectList()
+		}
+	case jmespathCurrent:
+		return identity, nil
+	case jmespathExpref:
+		operand, err := p.expression(jmespathBindingPowers[jmespathExpref])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathExprefNode, children: []*jmespathNode{operand}}, nil
+	case jmespathEOF:
+		return nil, fmt.Errorf("at position %d: unexpected end of expression", token.pos)
+	default:
+		return nil, fmt.Errorf("at position %d: unexpected token %q", token.pos, token.text)
+	}
+}
+
+// led parses the token following the left-hand side of an expression.
+func (p *jmespathParser) led(token jmespathToken, left *jmespathNode) (*jmespathNode, error) {
+	switch token.kind {
+	case jmespathDot:
+		if p.current().kind == jmespathStar {
+			p.advance()
+			right, err := p.projectionRHS(jmespathBindingPowers[jmespathDot])
+			if err != nil {
+				return nil, err
+			}
+			return &jmespathNode{kind: jmespathValueProjection, children: []*jmespathNode{left, right}}, nil
+		}
+		right, err := p.dotRHS(jmespathBindingPowers[jmespathDot])
+		if err != nil {
+			return nil, err
+		}
+		if left.kind == jmespathSubexpression {
+			left.children = append(left.children, right)
+			return left, nil
+		}
+		return &jmespathNode{kind: jmespathSubexpression, children: []*jmespathNode{left, right}}, nil
+	case jmespathPipe, jmespathOr, jmespathAnd:
+		right, err := p.expression(jmespathBindingPowers[token.kind])
+		if err != nil {
+			return nil, err
+		}
+		kind := map[jmespathTokenType]jmespathNodeType{jmespathPipe: jmespathPipeNode, jmespathOr: jmespathOrNode, jmespathAnd: jmespathAndNode}[token.kind]
+		return &jmespathNode{kind: kind, children: []*jmespathNode{left, right}}, nil
+	case jmespathLparen:
+		if left.kind != jmespathField {
+			return nil, fmt.Errorf("at position %d: invalid function call", token.pos)
+		}
+		name := left.value.(string)
+		if _, ok := jmespathFunctions[name]; !ok {
+			return nil, fmt.Errorf("at position %d: unknown function %s()", token.pos, name)
+		}
+		var args []*jmespathNode
+		for p.current().kind != jmespathRparen {
+			arg, err := p.expression(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.current().kind == jmespathComma {
+				p.advance()
+				if p.current().kind == jmespathRparen {
+					return nil, p.errorf("expected an argument")
+				}
+			} else if p.current().kind != jmespathRparen {
+				return nil, p.errorf(`expected "," or ")"`)
+			}
+		}
+		p.advance()
+		return &jmespathNode{kind: jmespathFunction, value: name, children: args}, nil
+	case jmespathFilter:
+		condition, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.match(jmespathRbracket, `"]"`); err != nil {
+			return nil, err
+		}
+		right := &jmespathNode{kind: jmespathIdentity}
+		if p.current().kind != jmespathFlatten {
+			if right, err = p.projectionRHS(jmespathBindingPowers[jmespathFilter]); err != nil {
+				return nil, err
+			}
+		}
+		return &jmespathNode{kind: jmespathFilterProjection, children: []*jmespathNode{left, right, condition}}, nil
+	case jmespathEQ, jmespathNE, jmespathLT, jmespathLTE, jmespathGT, jmespathGTE:
+		right, err := p.expression(jmespathBindingPowers[token.kind])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathComparator, value: token.text, children: []*jmespathNode{left, right}}, nil
+	case jmespathFlatten:
+		left = &jmespathNode{kind: jmespathFlattenNode, children: []*jmespathNode{left}}
+		right, err := p.projectionRHS(jmespathBindingPowers[jmespathFlatten])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathProjection, children: []*jmespathNode{left, right}}, nil
+	case jmespathLbracket:
+		if p.current().kind == jmespathNumber || p.current().kind == jmespathColon {
+			right, err := p.indexExpression()
+			if err != nil {
+				return nil, err
+			}
+			return p.projectIfSlice(left, right)
+		}
+		if err := p.match(jmespathStar, `a number, ":" or "*"`); err != nil {
+			return nil, err
+		}
+		if err := p.match(jmespathRbracket, `"]"`); err != nil {
+			return nil, err
+		}
+		right, err := p.projectionRHS(jmespathBindingPowers[jmespathStar])
+		if err != nil {
+			return nil, err
+		}
+		return &jmespathNode{kind: jmespathProjection, children: []*jmespathNode{left, right}}, nil
+	default:
+		return nil, fmt.Errorf("at position %d: unexpected token %q", token.pos, token.text)
+	}
+}
+
+// indexExpression parses an index or a slice, after the opening bracket.
+func (p *jmespathParser) indexExpression() (*jmespathNode, error) {
+	if p.current().kind == jmespathColon || p.lookahead(1) == jmespathColon {
+		return p.sliceExpression()
+	}
+	node := &jmespathNode{kind: jmespathIndex, value: p.current().value}
+	p.advance()
+	return node, p.match(jmespathRbracket, `"]"`)
+}
+
+// sliceExpression parses the bounds and the step of a slice.
+func (p *jmespathParser) sliceExpression() (*jmespathNode, error) {
+	var parts [3]*int
+	index := 0
+	for p.current().kind != jmespathRbracket && index < 3 {
+		switch p.current().kind {
+		case jmespathColon:
+			index++
+			if index == 3 {
+				return nil, p.errorf("too many colons in slice")
+			}
+		case jmespathNumber:
+			n := p.current().value.(int)
+			parts[index] = &n
+		default:
+			return nil, p.errorf("expected a number or \":\" in slice, found %q", p.current().text)
+		}
+		p.advance()
+	}
+	if parts[2] != nil && *parts[2] == 0 {
+		return nil, p.errorf("slice step cannot be 0")
+	}
+	return &jmespathNode{kind: jmespathSlice, value: parts}, p.match(jmespathRbracket, `"]"`)
+}
+
+// projectIfSlice wraps an index expression in a projection when it is a slice.
+func (p *jmespathParser) projectIfSlice(left, right *jmespathNode) (*jmespathNode, error) {
+	node := &jmespathNode{kind: jmespathIndexExpression, children: []*jmespathNode{left, right}}
+	if right.kind != jmespathSlice {
+		return node, nil
+	}
+	rhs, err := p.projectionRHS(jmespathBindingPowers[jmespathStar])
+	if err != nil {
+		return nil, err
+	}
+	return &jmespathNode{kind: jmespathProjection, children: []*jmespathNode{node, rhs}}, nil
+}
+
+// projectionRHS parses the expression applied to each element of a projection.
+func (p *jmespathParser) projectionRHS(bindingPower int) (*jmespathNode, error) {
+	switch kind := p.current().kind; {
+	case jmespathBindingPowers[kind] < jmespathProjectionStop:
+		return &jmespathNode{kind: jmespathIdentity}, nil
+	case kind == jmespathLbracket || kind == jmespathFilter:
+		return p.expression(bindingPower)
+	case kind == jmespathDot:
+		p.advance()
+		return p.dotRHS(bindingPower)
+	default:
+		return nil, p.errorf("unexpected token %q after projection", p.current().text)
+	}
+}
+
+// dotRHS parses the expression following a dot.
+func (p *jmespathParser) dotRHS(bindingPower int) (*jmespathNode, error) {
+	switch p.current().kind {
+	case jmespathQuotedIdentifier, jmespathUnquotedIdentifier, jmespathStar:
+		return p.expression(bindingPower)
+	case jmespathLbracket:
+		p.advance()
+		return p.multiSelectList()
+	case jmespathLbrace:
+		p.advance()
+		return p.multiSelectHash()
+	case jmespathEOF:
+		return nil, p.errorf("expected an identifier after \".\", found end of expression")
+	default:
+		return nil, p.errorf("expected an identifier after \".\", found %q", p.current().text)
+	}
+}
+
+// multiSelectList parses a list of expressions, after the opening bracket.
+func (p *jmespathParser) multiSelectList() (*jmespathNode, error) {
+	node := &jmespathNode{kind: jmespathMultiSelectList}
+	for {
+		expr, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, expr)
+		if p.current().kind == jmespathRbracket {
+			p.advance()
+			return node, nil
+		}
+		if err := p.match(jmespathComma, `"," or "]"`); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// multiSelectHash parses a map of expressions, after the opening brace.
+func (p *jmespathParser) multiSelectHash() (*jmespathNode, error) {
+	node := &jmespathNode{kind: jmespathMultiSelectHash}
+	for {
+		key := p.current()
+		if key.kind != jmespathUnquotedIdentifier && key.kind != jmespathQuotedIdentifier {
+			return nil, p.errorf("expected a key in multi-select hash, found %q", key.text)
+		}
+		p.advance()
+		if err := p.match(jmespathColon, `":"`); err != nil {
+			return nil, err
+		}
+		value, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, &jmespathNode{kind: jmespathKeyValuePair, value: key.value, children: []*jmespathNode{value}})
+
+		if p.current().kind == jmespathRbrace {
+			p.advance()
+			return node, nil
+		}
+		if err := p.match(jmespathComma, `"," or "}"`); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// evaluate evaluates the node against a value.
+func (n *jmespathNode) evaluate(value any) (any, error) {
+	switch n.kind {
+	case jmespathField:
+		if m, ok := value.(map[string]any); ok {
+			return m[n.value.(string)], nil
+		}
+		return nil, nil
+	case jmespathSubexpression, jmespathIndexExpression:
+		var err error
+		for _, child := range n.children {
+			if value, err = child.evaluate(value); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case jmespathIndex:
+		list, ok := value.([]any)
+		if !ok {
+			return nil, nil
+		}
+		index := n.value.(int)
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil, nil
+		}
+		return list[index], nil
+	case jmespathSlice:
+		list, ok := value.([]any)
+		if !ok {
+			return nil, nil
+		}
+		parts := n.value.([3]*int)
+		result := []any{}
+		for _, i := range sliceIndexes(len(list), parts[0], parts[1], parts[2]) {
+			result = append(result, list[i])
+		}
+		return result, nil
+	case jmespathProjection:
+		base, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := base.([]any)
+		if !ok {
+			return nil, nil
+		}
+		return n.children[1].project(list, nil)
+	case jmespathValueProjection:
+		base, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := base.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		return n.children[1].project(jmespathValues(m), nil)
+	case jmespathFilterProjection:
+		base, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := base.([]any)
+		if !ok {
+			return nil, nil
+		}
+		return n.children[1].project(list, n.children[2])
+	case jmespathFlattenNode:
+		base, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := base.([]any)
+		if !ok {
+			return nil, nil
+		}
+		result := []any{}
+		for _, item := range list {
+			if nested, ok := item.([]any); ok {
+				result = append(result, nested...)
+			} else {
+				result = append(result, item)
+			}
+		}
+		return result, nil
+	case jmespathIdentity:
+		return value, nil
+	case jmespathLiteralNode:
+		return n.value, nil
+	case jmespathMultiSelectList:
+		if value == nil {
+			return nil, nil
+		}
+		result := make([]any, len(n.children))
+		for i, child := range n.children {
+			item, err := child.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	case jmespathMultiSelectHash:
+		if value == nil {
+			return nil, nil
+		}
+		result := make(map[string]any, len(n.children))
+		for _, pair := range n.children {
+			item, err := pair.children[0].evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+			result[pair.value.(string)] = item
+		}
+		return result, nil
+	case jmespathOrNode, jmespathAndNode:
+		left, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		if jmespathTruthy(left) == (n.kind == jmespathOrNode) {
+			return left, nil
+		}
+		return n.children[1].evaluate(value)
+	case jmespathNotNode:
+		operand, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		return !jmespathTruthy(operand), nil
+	case jmespathComparator:
+		left, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		right, err := n.children[1].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		return jmespathCompare(n.value.(string), left, right), nil
+	case jmespathPipeNode:
+		left, err := n.children[0].evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+		return n.children[1].evaluate(left)
+	case jmespathFunction:
+		args := make([]any, len(n.children))
+		for i, child := range n.children {
+			arg, err := child.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		return callJMESPathFunction(n.value.(string), args)
+	case jmespathExprefNode:
+		return jmespathReference{n.children[0]}, nil
+	}
+	return nil, fmt.Errorf("unknown node type %d", n.kind)
+}
+
+// project evaluates the node against each element kept by the condition,
+// ignoring null results.
+func (n *jmespathNode) project(elements []any, condition *jmespathNode) (any, error) {
+	result := []any{}
+	for _, element := range elements {
+		if condition != nil {
+			keep, err := condition.evaluate(element)
+			if err != nil {
+				return nil, err
+			}
+			if !jmespathTruthy(keep) {
+				continue
+			}
+		}
+		item, err := n.evaluate(element)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// jmespathValues returns the values of a map, sorted by key.
+func jmespathValues(m map[string]any) []any {
+	keys := jmespathKeys(m)
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = m[key.(string)]
+	}
+	return values
+}
+
+// jmespathKeys returns the sorted keys of a map.
+func jmespathKeys(m map[string]any) []any {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	result := make([]any, len(keys))
+	for i, key := range keys {
+		result[i] = key
+	}
+	return result
+}
+
+// jmespathTruthy reports whether a value is true: null, false, empty strings,
+// lists and maps are false, other values, including 0, are true.
+func jmespathTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	if b, ok := toBool(value); ok {
+		return b
+	}
+	if s, ok := toString(value); ok {
+		return s != ""
+	}
+	return true
+}
+
+// jmespathCompare applies a comparator. Ordering comparators only apply to
+// numbers and return null for other values.
+func jmespathCompare(op string, left, right any) any {
+	switch op {
+	case "==":
+		return valuesEqual(left, right)
+	case "!=":
+		return !valuesEqual(left, right)
+	}
+
+	x, ok := toNumber(left)
+	if !ok {
+		return nil
+	}
+	y, ok := toNumber(right)
+	if !ok {
+		return nil
+	}
+	switch op {
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	case ">":
+		return x > y
+	default:
+		return x >= y
+	}
+}
+
+// jmespathType returns the JMESPath type of a value.
+func jmespathType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case jmespathReference:
+		return "expref"
+	}
+	if _, ok := toNumber(value); ok {
+		return "number"
+	}
+	if _, ok := toString(value); ok {
+		return "string"
+	}
+	if _, ok := toBool(value); ok {
+		return "boolean"
+	}
+	return "object"
+}
+
+// jmespathSignature describes the arguments of a function: the accepted types
+// of each parameter, and whether the last one is variadic.
+type jmespathSignature struct {
+	params   [][]string
+	variadic bool
+	call     func(args []any) (any, error)
+}
+
+var jmespathFunctions map[string]jmespathSignature
+
+func init() {
+	number := []string{"number"}
+	str := []string{"string"}
+	array := []string{"array"}
+	object := []string{"object"}
+	anyType := []string{"any"}
+	expref := []string{"expref"}
+
+	jmespathFunctions = map[string]jmespathSignature{
+		"abs": {params: [][]string{number}, call: func(args []any) (any, error) {
+			return math.Abs(jmespathNumberArg(args[0])), nil
+		}},
+		"avg": {params: [][]string{{"array-number"}}, call: func(args []any) (any, error) {
+			list := args[0].([]any)
+			if len(list) == 0 {
+				return nil, nil
+			}
+			return jmespathSum(list) / float64(len(list)), nil
+		}},
+		"ceil": {params: [][]string{number}, call: func(args []any) (any, error) {
+			return math.Ceil(jmespathNumberArg(args[0])), nil
+		}},
+		"contains": {params: [][]string{{"array", "string"}, anyType}, call: func(args []any) (any, error) {
+			if s, ok := toString(args[0]); ok {
+				search, ok := toString(args[1])
+				return ok && strings.Contains(s, search), nil
+			}
+			return slices.ContainsFunc(args[0].([]any), func(item any) bool { return valuesEqual(item, args[1]) }), nil
+		}},
+		"ends_with": {params: [][]string{str, str}, call: func(args []any) (any, error) {
+			return strings.HasSuffix(jmespathStringArg(args[0]), jmespathStringArg(args[1])), nil
+		}},
+		"floor": {params: [][]string{number}, call: func(args []any) (any, error) {
+			return math.Floor(jmespathNumberArg(args[0])), nil
+		}},
+		"join": {params: [][]string{str, {"array-string"}}, call: func(args []any) (any, error) {
+			list := args[1].([]any)
+			parts := make([]string, len(list))
+			for i, item := range list {
+				parts[i] = jmespathStringArg(item)
+			}
+			return strings.Join(parts, jmespathStringArg(args[0])), nil
+		}},
+		"keys": {params: [][]string{object}, call: func(args []any) (any, error) {
+			return jmespathKeys(args[0].(map[string]any)), nil
+		}},
+		"length": {params: [][]string{{"string", "array", "object"}}, call: func(args []any) (any, error) {
+			switch v := args[0].(type) {
+			case []any:
+				return float64(len(v)), nil
+			case map[string]any:
+				return float64(len(v)), nil
+			}
+			return float64(utf8.RuneCountInString(jmespathStringArg(args[0]))), nil
+		}},
+		"map": {params: [][]string{expref, array}, call: func(args []any) (any, error) {
+			node := args[0].(jmespathReference).node
+			list := args[1].([]any)
+			result := make([]any, len(list))
+			for i, item := range list {
+				value, err := node.evaluate(item)
+				if err != nil {
+					return nil, err
+				}
+				result[i] = value
+			}
+			return result, nil
+		}},
+		"max": {params: [][]string{{"array-number", "array-string"}}, call: func(args []any) (any, error) {
+			return jmespathExtremum(args[0].([]any), 1), nil
+		}},
+		"max_by": {params: [][]string{array, expref}, call: func(args []any) (any, error) {
+			return jmespathExtremumBy("max_by", args[0].([]any), args[1].(jmespathReference), 1)
+		}},
+		"merge": {params: [][]string{object}, variadic: true, call: func(args []any) (any, error) {
+			result := make(map[string]any)
+			for _, arg := range args {
+				for key, value := range arg.(map[string]any) {
+					result[key] = value
+				}
+			}
+			return result, nil
+		}},
+		"min": {params: [][]string{{"array-number", "array-string"}}, call: func(args []any) (any, error) {
+			return jmespathExtremum(args[0].([]any), -1), nil
+		}},
+		"min_by": {params: [][]string{array, expref}, call: func(args []any) (any, error) {
+			return jmespathExtremumBy("min_by", args[0].([]any), args[1].(jmespathReference), -1)
+		}},
+		"not_null": {params: [][]string{anyType}, variadic: true, call: func(args []any) (any, error) {
+			for _, arg := range args {
+				if arg != nil {
+					return arg, nil
+				}
+			}
+			return nil, nil
+		}},
+		"reverse": {params: [][]string{{"string", "array"}}, call: func(args []any) (any, error) {
+			if list, ok := args[0].([]any); ok {
+				reversed := slices.Clone(list)
+				slices.Reverse(reversed)
+				return reversed, nil
+			}
+			runes := []rune(jmespathStringArg(args[0]))
+			slices.Reverse(runes)
+			return string(runes), nil
+		}},
+		"sort": {params: [][]string{{"array-number", "array-string"}}, call: func(args []any) (any, error) {
+			sorted := slices.Clone(args[0].([]any))
+			slices.SortStableFunc(sorted, jmespathOrder)
+			return sorted, nil
+		}},
+		"sort_by": {params: [][]string{array, expref}, call: func(args []any) (any, error) {
+			list := args[0].([]any)
+			keys, err := jmespathSortKeys("sort_by", list, args[1].(jmespathReference))
+			if err != nil {
+				return nil, err
+			}
+			indexes := make([]int, len(list))
+			for i := range indexes {
+				indexes[i] = i
+			}
+			slices.SortStableFunc(indexes, func(a, b int) int { return jmespathOrder(keys[a], keys[b]) })
+			sorted := make([]any, len(list))
+			for i, index := range indexes {
+				sorted[i] = list[index]
+			}
+			return sorted, nil
+		}},
+		"starts_with": {params: [][]string{str, str}, call: func(args []any) (any, error) {
+			return strings.HasPrefix(jmespathStringArg(args[0]), jmespathStringArg(args[1])), nil
+		}},
+		"sum": {params: [][]string{{"array-number"}}, call: func(args []any) (any, error) {
+			return jmespathSum(args[0].([]any)), nil
+		}},
+		"to_array": {params: [][]string{anyType}, call: func(args []any) (any, error) {
+			if list, ok := args[0].([]any); ok {
+				return list, nil
+			}
+			return []any{args[0]}, nil
+		}},
+		"to_number": {params: [][]string{anyType}, call: func(args []any) (any, error) {
+			if n, ok := toNumber(args[0]); ok {
+				return n, nil
+			}
+			if s, ok := toString(args[0]); ok {
+				if n, err := strconv.ParseFloat(s, 64); err == nil {
+					return n, nil
+				}
+			}
+			return nil, nil
+		}},
+		"to_string": {params: [][]string{anyType}, call: func(args []any) (any, error) {
+			if s, ok := toString(args[0]); ok {
+				return s, nil
+			}
+			out, err := json.Marshal(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("to_string(): %w", err)
+			}
+			return string(out), nil
+		}},
+		"type": {params: [][]string{anyType}, call: func(args []any) (any, error) {
+			return jmespathType(args[0]), nil
+		}},
+		"values": {params: [][]string{object}, call: func(args []any) (any, error) {
+			return jmespathValues(args[0].(map[string]any)), nil
+		}},
+	}
+}
+
+// callJMESPathFunction checks the arguments against the signature of the
+// function and calls it.
+func callJMESPathFunction(name string, args []any) (any, error) {
+	signature := jmespathFunctions[name]
+	if signature.variadic {
+		if len(args) < len(signature.params) {
+			return nil, fmt.Errorf("%s() takes at least %d arguments, got %d", name, len(signature.params), len(args))
+		}
+	} else if len(args) != len(signature.params) {
+		return nil, fmt.Errorf("%s() takes %d arguments, got %d", name, len(signature.params), len(args))
+	}
+
+	for i, arg := range args {
+		types := signature.params[min(i, len(signature.params)-1)]
+		if !slices.ContainsFunc(types, func(t string) bool { return jmespathHasType(arg, t) }) {
+			return nil, fmt.Errorf("%s(): argument %d must be of type %s, got %s", name, i+1, strings.Join(types, " or "), jmespathType(arg))
+		}
+	}
+	return signature.call(args)
+}
+
+// jmespathHasType reports whether a value matches a type of a signature.
+func jmespathHasType(value any, t string) bool {
+	switch t {
+	case "any":
+		return true
+	case "array-number", "array-string":
+		list, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		itemType := strings.TrimPrefix(t, "array-")
+		for _, item := range list {
+			if jmespathType(item) != itemType {
+				return false
+			}
+		}
+		return true
+	default:
+		return jmespathType(value) == t
+	}
+}
+
+func jmespathNumberArg(value any) float64 {
+	n, _ := toNumber(value)
+	return n
+}
+
+func jmespathStringArg(value any) string {
+	s, _ := toString(value)
+	return s
+}
+
+func jmespathSum(list []any) float64 {
+	sum := 0.0
+	for _, item := range list {
+		sum += jmespathNumberArg(item)
+	}
+	return sum
+}
+
+// jmespathOrder orders two numbers or two strings.
+func jmespathOrder(a, b any) int {
+	if x, ok := toNumber(a); ok {
+		y, _ := toNumber(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(jmespathStringArg(a), jmespathStringArg(b))
+}
+
+// jmespathExtremum returns the greatest element of the list when sign is 1,
+// the smallest when sign is -1, or null when the list is empty.
+func jmespathExtremum(list []any, sign int) any {
+	var result any
+	for _, item := range list {
+		if result == nil || jmespathOrder(item, result)*sign > 0 {
+			result = item
+		}
+	}
+	return result
+}
+
+// jmespathExtremumBy returns the element of the list with the greatest key
+// when sign is 1, the smallest when sign is -1, or null when the list is
+// empty.
+func jmespathExtremumBy(name string, list []any, expref jmespathReference, sign int) (any, error) {
+	keys, err := jmespathSortKeys(name, list, expref)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	best := -1
+	for i, item := range list {
+		if best < 0 || jmespathOrder(keys[i], keys[best])*sign > 0 {
+			result, best = item, i
+		}
+	}
+	return result, nil
+}
+
+// jmespathSortKeys evaluates the expression reference against each element
+// of the list. The keys must all be numbers or all be strings.
+func jmespathSortKeys(name string, list []any, expref jmespathReference) ([]any, error) {
+	keys := make([]any, len(list))
+	keyType := ""
+	for i, item := range list {
+		key, err := expref.node.evaluate(item)
+		if err != nil {
+			return nil, err
+		}
+		t := jmespathType(key)
+		if (t != "number" && t != "string") || (keyType != "" && t != keyType) {
+			return nil, fmt.Errorf("%s(): expression must return only numbers or only strings, got %s", name, t)
+		}
+		keyType = t
+		keys[i] = key
+	}
+	return keys, nil
+}
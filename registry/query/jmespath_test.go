@@ -0,0 +1,205 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const peopleJSON = `{
+  "people": [
+    {"name": "bob", "age": 30, "tags": ["admin", "dev"], "address": {"city": "Paris"}},
+    {"name": "alice", "age": 25, "tags": ["dev"]},
+    {"name": "carol", "age": 35, "tags": [], "address": {"city": "Lyon"}}
+  ],
+  "ops": {"web": {"port": 80}, "db": {"port": 5432}},
+  "nested": [[0, 1], 2, [3, [4]]],
+  "first": "a",
+  "empty": ""
+}`
+
+func TestEvaluateJMESPath(t *testing.T) {
+	var document any
+	require.NoError(t, json.Unmarshal([]byte(peopleJSON), &document))
+
+	tests := []struct {
+		expression string
+		expected   any
+	}{
+		// Basic expressions
+		{"first", "a"},
+		{"missing", nil},
+		{"people[0].name", "bob"},
+		{"people[-1].name", "carol"},
+		{"people[5].name", nil},
+		{`"people"[1]."name"`, "alice"},
+		{"people[0].address.city", "Paris"},
+		{"@.first", "a"},
+		{"first.name", nil},
+
+		// Slices
+		{"people[:2].name", []any{"bob", "alice"}},
+		{"people[::-1].name", []any{"carol", "alice", "bob"}},
+		{"people[1:].age", []any{25.0, 35.0}},
+		{"first[0:1]", nil},
+
+		// Projections
+		{"people[*].name", []any{"bob", "alice", "carol"}},
+		{"people[*].address.city", []any{"Paris", "Lyon"}},
+		{"ops.*.port", []any{5432.0, 80.0}},
+		{"people[*].tags[]", []any{"admin", "dev", "dev"}},
+		{"nested[]", []any{0.0, 1.0, 2.0, 3.0, []any{4.0}}},
+		{"nested[][]", []any{0.0, 1.0, 2.0, 3.0, 4.0}},
+		{"people[*].tags[0]", []any{"admin", "dev"}},
+		{"people[*].name | [0]", "bob"},
+		{"first[*]", nil},
+
+		// Filters
+		{"people[?age > `26`].name", []any{"bob", "carol"}},
+		{"people[?address].name", []any{"bob", "carol"}},
+		{"people[?!address].name", []any{"alice"}},
+		{"people[?name == 'alice'].age | [0]", 25.0},
+		{"people[?age >= `30` && contains(tags, 'admin')].name", []any{"bob"}},
+		{"people[?age < `26` || name == 'carol'].name", []any{"alice", "carol"}},
+		{"people[?tags].name", []any{"bob", "alice"}},
+		{"people[?name > 'b'].name", []any{}},
+		{"[?@ == `1`]", nil},
+
+		// Multi-selects and literals
+		{"people[0].[name, age]", []any{"bob", 30.0}},
+		{"people[*].{n: name, c: address.city}", []any{
+			map[string]any{"n": "bob", "c": "Paris"},
+			map[string]any{"n": "alice", "c": nil},
+			map[string]any{"n": "carol", "c": "Lyon"},
+		}},
+		{"[first, empty || 'default']", []any{"a", "default"}},
+		{"missing.[a, b]", nil},
+		{"`{\"a\": [1, true]}`", map[string]any{"a": []any{1.0, true}}},
+		{`'it\'s'`, "it's"},
+		{"empty && first", ""},
+		{"first && empty", ""},
+		{"!empty", true},
+		{"(first || empty) == 'a'", true},
+		{"people[0].age == `30`", true},
+		{"people[0].tags == ['admin', 'dev']", true},
+		{"people[0].name < `1`", nil},
+
+		// Functions
+		{"length(people)", 3.0},
+		{"length('héllo')", 5.0},
+		{"max(people[*].age)", 35.0},
+		{"min(people[*].name)", "alice"},
+		{"max(`[]`)", nil},
+		{"sum(people[*].age)", 90.0},
+		{"avg(people[*].age)", 30.0},
+		{"abs(`-2.5`)", 2.5},
+		{"ceil(`1.2`)", 2.0},
+		{"floor(`1.8`)", 1.0},
+		{"sort(people[*].name)", []any{"alice", "bob", "carol"}},
+		{"sort_by(people, &age)[*].name", []any{"alice", "bob", "carol"}},
+		{"max_by(people, &age).name", "carol"},
+		{"min_by(people, &name).name", "alice"},
+		{"map(&length(tags), people)", []any{2.0, 1.0, 0.0}},
+		{"join(', ', people[*].name)", "bob, alice, carol"},
+		{"keys(ops)", []any{"db", "web"}},
+		{"values(ops)[*].port", []any{5432.0, 80.0}},
+		{"merge(ops.web, `{\"host\": \"x\"}`)", map[string]any{"port": 80.0, "host": "x"}},
+		{"not_null(missing, empty, first)", ""},
+		{"reverse(people[*].age)", []any{35.0, 25.0, 30.0}},
+		{"reverse('abc')", "cba"},
+		{"starts_with(first, 'a')", true},
+		{"ends_with(first, 'b')", false},
+		{"contains('foobar', 'oba')", true},
+		{"to_array(first)", []any{"a"}},
+		{"to_string(people[0].tags)", `["admin","dev"]`},
+		{"to_string(first)", "a"},
+		{"to_number('1.5')", 1.5},
+		{"to_number('abc')", nil},
+		{"type(people)", "array"},
+		{"type(ops)", "object"},
+		{"type(missing)", "null"},
+		{"type(`true`)", "boolean"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			result, err := evaluateJMESPath(test.expression, document)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestEvaluateJMESPathTypedValues(t *testing.T) {
+	type user struct {
+		Name   string `json:"name"`
+		Age    int    `json:"age"`
+		Active bool   `json:"active"`
+	}
+
+	data := normalizeValue(reflect.ValueOf(map[string]any{
+		"users": []user{{"bob", 30, true}, {"alice", 25, false}},
+	}))
+
+	result, err := evaluateJMESPath("users[?active].age", data)
+	require.NoError(t, err)
+	assert.Equal(t, []any{30}, result)
+
+	result, err = evaluateJMESPath("sort_by(users, &age)[0].name", data)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result)
+
+	result, err = evaluateJMESPath("users[?age > `26`].name", data)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"bob"}, result)
+
+	result, err = evaluateJMESPath("sum(users[*].age)", data)
+	require.NoError(t, err)
+	assert.Equal(t, 55.0, result)
+}
+
+func TestEvaluateJMESPathErrors(t *testing.T) {
+	var document any
+	require.NoError(t, json.Unmarshal([]byte(peopleJSON), &document))
+
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "unexpected end of expression"},
+		{"people[", `expected a number, ":" or "*", found end of expression`},
+		{"people[0", `expected "]", found end of expression`},
+		{"people.", `expected an identifier after ".", found end of expression`},
+		{"people.[", "unexpected end of expression"},
+		{"a = b", `unexpected "=", use "==" to compare`},
+		{"a b", `unexpected token "b"`},
+		{"a ~ b", "at position 2: unexpected character '~'"},
+		{"'abc", "unterminated '"},
+		{"`{abc`", "invalid JSON literal"},
+		{`"\q"`, "invalid quoted identifier"},
+		{"[0:1:0]", "slice step cannot be 0"},
+		{"[0:1:2:3]", "too many colons in slice"},
+		{"{a b}", `expected ":"`},
+		{"{1: a}", "expected a key in multi-select hash"},
+		{"[a, b", `expected "," or "]"`},
+		{`"length"(a)`, "quoted identifiers cannot be used as function names"},
+		{"unknown(a)", "unknown function unknown()"},
+		{"length(a, b)", "length() takes 1 arguments, got 2"},
+		{"length(a,)", "expected an argument"},
+		{"merge()", "merge() takes at least 1 arguments, got 0"},
+		{"length(`1`)", "length(): argument 1 must be of type string or array or object, got number"},
+		{"sum(people[*].name)", "sum(): argument 1 must be of type array-number, got array"},
+		{"sort_by(people, &address)", "sort_by(): expression must return only numbers or only strings, got object"},
+		{"sort_by(people, &tags[0])", "sort_by(): expression must return only numbers or only strings, got null"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := evaluateJMESPath(test.expression, document)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
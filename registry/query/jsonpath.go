@@ -0,0 +1,988 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// jsonpathQuery is a parsed JSONPath query, absolute when it starts with `$`
+// and relative to the current node of a filter when it starts with `@`.
+type jsonpathQuery struct {
+	relative bool
+	segments []jsonpathSegment
+}
+
+// jsonpathSegment selects the children of the input nodes, or all their
+// descendants when the segment starts with `..`.
+type jsonpathSegment struct {
+	descendant bool
+	selectors  []jsonpathSelector
+}
+
+// jsonpathSelector appends the nodes it selects from a value to the output.
+type jsonpathSelector interface {
+	selectNodes(value, root any, out []any) []any
+}
+
+type (
+	jsonpathNameSelector     struct{ name string }
+	jsonpathWildcardSelector struct{}
+	jsonpathIndexSelector    struct{ index int }
+	jsonpathSliceSelector    struct{ start, end, step *int }
+	jsonpathFilterSelector   struct{ expr jsonpathLogical }
+)
+
+// jsonpathLogical is a logical expression of a filter selector.
+type jsonpathLogical interface {
+	test(current, root any) bool
+}
+
+type (
+	jsonpathOr      struct{ operands []jsonpathLogical }
+	jsonpathAnd     struct{ operands []jsonpathLogical }
+	jsonpathNot     struct{ operand jsonpathLogical }
+	jsonpathExists  struct{ query *jsonpathQuery }
+	jsonpathCompare struct {
+		op          string
+		left, right jsonpathComparable
+	}
+)
+
+// jsonpathComparable is an operand of a comparison. It returns false when it
+// evaluates to Nothing, like a query selecting no node.
+type jsonpathComparable interface {
+	value(current, root any) (any, bool)
+}
+
+type (
+	jsonpathLiteral       struct{ v any }
+	jsonpathSingularQuery struct{ query *jsonpathQuery }
+)
+
+// jsonpathFunction is a call to a function extension, usable as a comparable
+// or as a logical expression depending on its result type.
+type jsonpathFunction struct {
+	name string
+	args []jsonpathArgument
+}
+
+// jsonpathArgument is an argument of a function, either a query producing a
+// list of nodes or a comparable producing a value.
+type jsonpathArgument struct {
+	query      *jsonpathQuery
+	comparable jsonpathComparable
+}
+
+// jsonpathFunctionTypes holds the parameter kinds and the result kind of the
+// function extensions of RFC 9535: "value", "nodes" or "logical".
+var jsonpathFunctionTypes = map[string]struct {
+	params []string
+	result string
+}{
+	"length": {[]string{"value"}, "value"},
+	"count":  {[]string{"nodes"}, "value"},
+	"match":  {[]string{"value", "value"}, "logical"},
+	"search": {[]string{"value", "value"}, "logical"},
+	"value":  {[]string{"nodes"}, "value"},
+}
+
+// evaluateJSONPath evaluates a JSONPath query against a document and returns
+// the values of the selected nodes.
+func evaluateJSONPath(expression string, document any) ([]any, error) {
+	q, err := parseJSONPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	return q.evaluate(document, document), nil
+}
+
+// evaluate returns the values of the nodes selected by the query.
+func (q *jsonpathQuery) evaluate(current, root any) []any {
+	nodes := []any{root}
+	if q.relative {
+		nodes = []any{current}
+	}
+
+	for _, segment := range q.segments {
+		var next []any
+		for _, node := range nodes {
+			if segment.descendant {
+				next = segment.selectDescendants(node, root, next)
+				continue
+			}
+			for _, selector := range segment.selectors {
+				next = selector.selectNodes(node, root, next)
+			}
+		}
+		nodes = next
+	}
+
+	if nodes == nil {
+		return []any{}
+	}
+	return nodes
+}
+
+// selectDescendants applies the selectors to the node and all its
+// descendants, in document order.
+func (s jsonpathSegment) selectDescendants(node, root any, out []any) []any {
+	for _, selector := range s.selectors {
+		out = selector.selectNodes(node, root, out)
+	}
+	for _, child := range jsonpathChildren(node) {
+		out = s.selectDescendants(child, root, out)
+	}
+	return out
+}
+
+// jsonpathChildren returns the children of a node: the items of a list or the
+// values of a map, sorted by key.
+func jsonpathChildren(node any) []any {
+	switch v := node.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		children := make([]any, len(keys))
+		for i, key := range keys {
+			children[i] = v[key]
+		}
+		return children
+	default:
+		return nil
+	}
+}
+
+func (s jsonpathNameSelector) selectNodes(value, _ any, out []any) []any {
+	if m, ok := value.(map[string]any); ok {
+		if child, exists := m[s.name]; exists {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+func (jsonpathWildcardSelector) selectNodes(value, _ any, out []any) []any {
+	return append(out, jsonpathChildren(value)...)
+}
+
+func (s jsonpathIndexSelector) selectNodes(value, _ any, out []any) []any {
+	list, ok := value.([]any)
+	if !ok {
+		return out
+	}
+	index := s.index
+	if index < 0 {
+		index += len(list)
+	}
+	if index >= 0 && index < len(list) {
+		out = append(out, list[index])
+	}
+	return out
+}
+
+func (s jsonpathSliceSelector) selectNodes(value, _ any, out []any) []any {
+	list, ok := value.([]any)
+	if !ok {
+		return out
+	}
+	for _, i := range sliceIndexes(len(list), s.start, s.end, s.step) {
+		out = append(out, list[i])
+	}
+	return out
+}
+
+func (s jsonpathFilterSelector) selectNodes(value, root any, out []any) []any {
+	for _, child := range jsonpathChildren(value) {
+		if s.expr.test(child, root) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// sliceIndexes returns the indexes selected by a slice of a list of the given
+// length, following the array slice semantics shared by JSONPath and
+// JMESPath. Missing bounds and step are nil.
+func sliceIndexes(length int, start, end, step *int) []int {
+	s := 1
+	if step != nil {
+		s = *step
+	}
+	if s == 0 {
+		return nil
+	}
+
+	normalize := func(bound *int, fallback int) int {
+		if bound == nil {
+			return fallback
+		}
+		if *bound < 0 {
+			return *bound + length
+		}
+		return *bound
+	}
+
+	var indexes []int
+	if s > 0 {
+		lower := min(max(normalize(start, 0), 0), length)
+		upper := min(max(normalize(end, length), 0), length)
+		for i := lower; i < upper; i += s {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+
+	upper := min(max(normalize(start, length-1), -1), length-1)
+	lower := min(max(normalize(end, -length-1), -1), length-1)
+	for i := upper; i > lower; i += s {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func (e jsonpathOr) test(current, root any) bool {
+	for _, operand := range e.operands {
+		if operand.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e jsonpathAnd) test(current, root any) bool {
+	for _, operand := range e.operands {
+		if !operand.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e jsonpathNot) test(current, root any) bool {
+	return !e.operand.test(current, root)
+}
+
+func (e jsonpathExists) test(current, root any) bool {
+	return len(e.query.evaluate(current, root)) > 0
+}
+
+func (e jsonpathCompare) test(current, root any) bool {
+	left, leftOK := e.left.value(current, root)
+	right, rightOK := e.right.value(current, root)
+
+	switch e.op {
+	case "==":
+		return jsonpathEqual(left, leftOK, right, rightOK)
+	case "!=":
+		return !jsonpathEqual(left, leftOK, right, rightOK)
+	}
+	if !leftOK || !rightOK {
+		return false
+	}
+
+	switch e.op {
+	case "<":
+		return jsonpathLess(left, right)
+	case ">":
+		return jsonpathLess(right, left)
+	case "<=":
+		return jsonpathLess(left, right) || valuesEqual(left, right)
+	case ">=":
+		return jsonpathLess(right, left) || valuesEqual(left, right)
+	}
+	return false
+}
+
+// jsonpathEqual compares two comparables, Nothing being only equal to Nothing.
+func jsonpathEqual(left any, leftOK bool, right any, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return leftOK == rightOK
+	}
+	return valuesEqual(left, right)
+}
+
+// jsonpathLess orders numbers and strings. Other values are not ordered.
+func jsonpathLess(left, right any) bool {
+	if x, ok := toNumber(left); ok {
+		y, ok := toNumber(right)
+		return ok && x < y
+	}
+	if x, ok := toString(left); ok {
+		y, ok := toString(right)
+		return ok && x < y
+	}
+	return false
+}
+
+func (e jsonpathLiteral) value(_, _ any) (any, bool) {
+	return e.v, true
+}
+
+func (e jsonpathSingularQuery) value(current, root any) (any, bool) {
+	nodes := e.query.evaluate(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+// value evaluates a function returning a value.
+func (f *jsonpathFunction) value(current, root any) (any, bool) {
+	switch f.name {
+	case "length":
+		v, ok := f.args[0].value(current, root)
+		if !ok {
+			return nil, false
+		}
+		if s, ok := toString(v); ok {
+			return float64(utf8.RuneCountInString(s)), true
+		}
+		switch v := v.(type) {
+		case []any:
+			return float64(len(v)), true
+		case map[string]any:
+			return float64(len(v)), true
+		}
+		return nil, false
+	case "count":
+		return float64(len(f.args[0].query.evaluate(current, root))), true
+	case "value":
+		nodes := f.args[0].query.evaluate(current, root)
+		if len(nodes) != 1 {
+			return nil, false
+		}
+		return nodes[0], true
+	}
+	return nil, false
+}
+
+// test evaluates a function returning a logical value.
+func (f *jsonpathFunction) test(current, root any) bool {
+	input, ok := f.args[0].value(current, root)
+	if !ok {
+		return false
+	}
+	pattern, ok := f.args[1].value(current, root)
+	if !ok {
+		return false
+	}
+	s, ok := toString(input)
+	if !ok {
+		return false
+	}
+	p, ok := toString(pattern)
+	if !ok {
+		return false
+	}
+
+	if f.name == "match" {
+		p = `\A(?:` + p + `)\z`
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(s)
+}
+
+// value returns the value of an argument. A query argument used as a value
+// must select a single node.
+func (a jsonpathArgument) value(current, root any) (any, bool) {
+	if a.comparable != nil {
+		return a.comparable.value(current, root)
+	}
+	return jsonpathSingularQuery{a.query}.value(current, root)
+}
+
+// jsonpathParser parses JSONPath queries as defined by RFC 9535.
+type jsonpathParser struct {
+	input string
+	pos   int
+}
+
+// parseJSONPath parses a JSONPath query.
+func parseJSONPath(expression string) (*jsonpathQuery, error) {
+	p := &jsonpathParser{input: expression}
+	if p.peek() != '$' {
+		return nil, p.errorf("query must start with $")
+	}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlanks()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return q, nil
+}
+
+func (p *jsonpathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonpathParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *jsonpathParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *jsonpathParser) skipBlanks() {
+	for !p.eof() && strings.IndexByte(" \t\n\r", p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips the blanks and the given token if it is next.
+func (p *jsonpathParser) consume(token string) bool {
+	p.skipBlanks()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// parseQuery parses the identifier of the root or current node followed by
+// its segments.
+func (p *jsonpathParser) parseQuery() (*jsonpathQuery, error) {
+	q := &jsonpathQuery{relative: p.peek() == '@'}
+	p.pos++
+
+	for {
+		// Blanks are allowed between segments, but must not swallow the
+		// blanks before an operator of a filter expression
+		save := p.pos
+		p.skipBlanks()
+		switch {
+		case strings.HasPrefix(p.input[p.pos:], ".."):
+			p.pos += 2
+			segment, err := p.parseSegmentAfterDot(true)
+			if err != nil {
+				return nil, err
+			}
+			q.segments = append(q.segments, segment)
+		case p.peek() == '.':
+			p.pos++
+			segment, err := p.parseSegmentAfterDot(false)
+			if err != nil {
+				return nil, err
+			}
+			q.segments = append(q.segments, segment)
+		case p.peek() == '[':
+			selectors, err := p.parseBracketedSelection()
+			if err != nil {
+				return nil, err
+			}
+			q.segments = append(q.segments, jsonpathSegment{selectors: selectors})
+		default:
+			p.pos = save
+			return q, nil
+		}
+	}
+}
+
+// parseSegmentAfterDot parses a wildcard, a member name or, after `..`, a
+// bracketed selection.
+func (p *jsonpathParser) parseSegmentAfterDot(descendant bool) (jsonpathSegment, error) {
+	segment := jsonpathSegment{descendant: descendant}
+	switch {
+	case p.peek() == '*':
+		p.pos++
+		segment.selectors = []jsonpathSelector{jsonpathWildcardSelector{}}
+	case descendant && p.peek() == '[':
+		selectors, err := p.parseBracketedSelection()
+		if err != nil {
+			return segment, err
+		}
+		segment.selectors = selectors
+	default:
+		name := p.parseMemberName()
+		if name == "" {
+			return segment, p.errorf("expected a member name")
+		}
+		segment.selectors = []jsonpathSelector{jsonpathNameSelector{name}}
+	}
+	return segment, nil
+}
+
+// parseMemberName parses a member name shorthand: a letter, an underscore or
+// a non-ASCII character, followed by the same or digits.
+func (p *jsonpathParser) parseMemberName() string {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		isFirst := r == '_' || r >= 0x80 || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isFirst && (p.pos == start || r < '0' || r > '9') {
+			break
+		}
+		p.pos += size
+	}
+	return p.input[start:p.pos]
+}
+
+// parseBracketedSelection parses a comma-separated list of selectors between
+// brackets.
+func (p *jsonpathParser) parseBracketedSelection() ([]jsonpathSelector, error) {
+	p.pos++ // [
+	var selectors []jsonpathSelector
+	for {
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf(`expected "," or "]"`)
+		}
+	}
+}
+
+// parseSelector parses a name, wildcard, index, slice or filter selector.
+func (p *jsonpathParser) parseSelector() (jsonpathSelector, error) {
+	p.skipBlanks()
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jsonpathNameSelector{name}, nil
+	case c == '*':
+		p.pos++
+		return jsonpathWildcardSelector{}, nil
+	case c == '?':
+		p.pos++
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		return jsonpathFilterSelector{expr}, nil
+	case c == ':' || c == '-' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	case c == 0:
+		return nil, p.errorf("expected a selector, found end of query")
+	default:
+		return nil, p.errorf("unexpected %q in selector", c)
+	}
+}
+
+// parseIndexOrSlice parses an index selector or a slice selector.
+func (p *jsonpathParser) parseIndexOrSlice() (jsonpathSelector, error) {
+	var bounds [3]*int
+	for i := range bounds {
+		p.skipBlanks()
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			n, err := p.parseInteger()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &n
+		}
+		if i == 0 && !p.consume(":") {
+			if bounds[0] == nil {
+				return nil, p.errorf("expected an index")
+			}
+			return jsonpathIndexSelector{*bounds[0]}, nil
+		}
+		if i == 1 && !p.consume(":") {
+			break
+		}
+	}
+	return jsonpathSliceSelector{bounds[0], bounds[1], bounds[2]}, nil
+}
+
+// parseInteger parses an integer without leading zeros.
+func (p *jsonpathParser) parseInteger() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	literal := p.input[start:p.pos]
+	digits := strings.TrimPrefix(literal, "-")
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') || literal == "-0" {
+		return 0, p.errorf("invalid integer %q", literal)
+	}
+	n, err := strconv.Atoi(literal)
+	if err != nil {
+		return 0, p.errorf("invalid integer %q", literal)
+	}
+	return n, nil
+}
+
+// parseString parses a single- or double-quoted string literal.
+func (p *jsonpathParser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string literal")
+		}
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return sb.String(), nil
+		case c == '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string literal")
+			}
+			escape := p.input[p.pos]
+			p.pos++
+			switch escape {
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '/', '\\', '\'', '"':
+				if (escape == '\'' || escape == '"') && escape != quote {
+					return "", p.errorf("invalid escape sequence \\%c", escape)
+				}
+				sb.WriteByte(escape)
+			case 'u':
+				r, err := p.parseUnicodeEscape()
+				if err != nil {
+					return "", err
+				}
+				sb.WriteRune(r)
+			default:
+				return "", p.errorf("invalid escape sequence \\%c", escape)
+			}
+		case c < 0x20:
+			return "", p.errorf("invalid control character in string literal")
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+// parseUnicodeEscape parses the hexadecimal digits of a \u escape sequence,
+// combining surrogate pairs.
+func (p *jsonpathParser) parseUnicodeEscape() (rune, error) {
+	hex := func() (rune, error) {
+		if p.pos+4 > len(p.input) {
+			return 0, p.errorf("invalid unicode escape sequence")
+		}
+		n, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 16)
+		if err != nil {
+			return 0, p.errorf("invalid unicode escape sequence")
+		}
+		p.pos += 4
+		return rune(n), nil
+	}
+
+	r, err := hex()
+	if err != nil || !utf16.IsSurrogate(r) {
+		return r, err
+	}
+	if !strings.HasPrefix(p.input[p.pos:], `\u`) {
+		return 0, p.errorf("invalid unicode surrogate pair")
+	}
+	p.pos += 2
+	low, err := hex()
+	if err != nil {
+		return 0, err
+	}
+	pair := utf16.DecodeRune(r, low)
+	if pair == utf8.RuneError {
+		return 0, p.errorf("invalid unicode surrogate pair")
+	}
+	return pair, nil
+}
+
+func (p *jsonpathParser) parseLogicalOr() (jsonpathLogical, error) {
+	first, err := p.parseLogicalAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []jsonpathLogical{first}
+	for p.consume("||") {
+		operand, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return jsonpathOr{operands}, nil
+}
+
+func (p *jsonpathParser) parseLogicalAnd() (jsonpathLogical, error) {
+	first, err := p.parseBasicExpr()
+	if err != nil {
+		return nil, err
+	}
+	operands := []jsonpathLogical{first}
+	for p.consume("&&") {
+		operand, err := p.parseBasicExpr()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return jsonpathAnd{operands}, nil
+}
+
+// parseBasicExpr parses a parenthesized expression, a negation, a test or a
+// comparison.
+func (p *jsonpathParser) parseBasicExpr() (jsonpathLogical, error) {
+	if p.consume("!") {
+		if p.consume("(") {
+			expr, err := p.parseLogicalOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.consume(")") {
+				return nil, p.errorf(`expected ")"`)
+			}
+			return jsonpathNot{expr}, nil
+		}
+
+		operand, query, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case query != nil:
+			return jsonpathNot{jsonpathExists{query}}, nil
+		case isJSONPathFunction(operand, "logical"):
+			return jsonpathNot{operand.(*jsonpathFunction)}, nil
+		default:
+			return nil, p.errorf(`expected a query or a function after "!"`)
+		}
+	}
+
+	if p.consume("(") {
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf(`expected ")"`)
+		}
+		return expr, nil
+	}
+
+	left, leftQuery, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.parseComparisonOperator()
+	if op == "" {
+		switch {
+		case leftQuery != nil:
+			return jsonpathExists{leftQuery}, nil
+		case isJSONPathFunction(left, "logical"):
+			return left.(*jsonpathFunction), nil
+		default:
+			return nil, p.errorf("expected a comparison operator")
+		}
+	}
+
+	right, rightQuery, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if left, err = p.comparable(left, leftQuery); err != nil {
+		return nil, err
+	}
+	if right, err = p.comparable(right, rightQuery); err != nil {
+		return nil, err
+	}
+	return jsonpathCompare{op: op, left: left, right: right}, nil
+}
+
+// comparable returns the operand of a comparison, which must be a literal, a
+// singular query or a function returning a value.
+func (p *jsonpathParser) comparable(operand jsonpathComparable, query *jsonpathQuery) (jsonpathComparable, error) {
+	if query != nil {
+		if !query.isSingular() {
+			return nil, p.errorf("queries in comparisons must select a single node")
+		}
+		return jsonpathSingularQuery{query}, nil
+	}
+	if f, ok := operand.(*jsonpathFunction); ok && !isJSONPathFunction(f, "value") {
+		return nil, p.errorf("function %s() cannot be compared", f.name)
+	}
+	return operand, nil
+}
+
+// isSingular reports whether the query can only select a single node: it only
+// holds name and index selectors, outside of descendant segments.
+func (q *jsonpathQuery) isSingular() bool {
+	for _, segment := range q.segments {
+		if segment.descendant || len(segment.selectors) != 1 {
+			return false
+		}
+		switch segment.selectors[0].(type) {
+		case jsonpathNameSelector, jsonpathIndexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isJSONPathFunction reports whether the operand is a function with the given
+// result kind.
+func isJSONPathFunction(operand jsonpathComparable, result string) bool {
+	f, ok := operand.(*jsonpathFunction)
+	return ok && jsonpathFunctionTypes[f.name].result == result
+}
+
+func (p *jsonpathParser) parseComparisonOperator() string {
+	p.skipBlanks()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// parseOperand parses a query, a literal or a function call. Queries are
+// returned separately as their use depends on the context.
+func (p *jsonpathParser) parseOperand() (jsonpathComparable, *jsonpathQuery, error) {
+	p.skipBlanks()
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		q, err := p.parseQuery()
+		return nil, q, err
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return jsonpathLiteral{s}, nil, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		n, err := p.parseNumber()
+		return jsonpathLiteral{n}, nil, err
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for !p.eof() && (p.peek() == '_' || (p.peek() >= 'a' && p.peek() <= 'z') || (p.peek() >= '0' && p.peek() <= '9')) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if p.peek() == '(' {
+			f, err := p.parseFunctionCall(name)
+			return f, nil, err
+		}
+		switch name {
+		case "true":
+			return jsonpathLiteral{true}, nil, nil
+		case "false":
+			return jsonpathLiteral{false}, nil, nil
+		case "null":
+			return jsonpathLiteral{nil}, nil, nil
+		}
+		p.pos = start
+		return nil, nil, p.errorf("unexpected %q", name)
+	case c == 0:
+		return nil, nil, p.errorf("expected an expression, found end of query")
+	default:
+		return nil, nil, p.errorf("unexpected %q in expression", c)
+	}
+}
+
+// parseNumber parses a JSON number literal.
+func (p *jsonpathParser) parseNumber() (float64, error) {
+	start := p.pos
+	for !p.eof() && strings.IndexByte("0123456789+-.eE", p.peek()) >= 0 {
+		p.pos++
+	}
+	literal := p.input[start:p.pos]
+	digits := strings.TrimPrefix(literal, "-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' && digits[1] != 'e' && digits[1] != 'E' {
+		return 0, p.errorf("invalid number %q", literal)
+	}
+	n, err := strconv.ParseFloat(literal, 64)
+	if err != nil || math.IsInf(n, 0) || strings.HasSuffix(literal, ".") || strings.HasPrefix(digits, ".") {
+		return 0, p.errorf("invalid number %q", literal)
+	}
+	return n, nil
+}
+
+// parseFunctionCall parses the arguments of a function extension and checks
+// them against its signature.
+func (p *jsonpathParser) parseFunctionCall(name string) (*jsonpathFunction, error) {
+	signature, ok := jsonpathFunctionTypes[name]
+	if !ok {
+		return nil, p.errorf("unknown function %s()", name)
+	}
+	p.pos++ // (
+
+	f := &jsonpathFunction{name: name}
+	if !p.consume(")") {
+		for {
+			operand, query, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			f.args = append(f.args, jsonpathArgument{query: query, comparable: operand})
+			if p.consume(")") {
+				break
+			}
+			if !p.consume(",") {
+				return nil, p.errorf(`expected "," or ")"`)
+			}
+		}
+	}
+
+	if len(f.args) != len(signature.params) {
+		return nil, p.errorf("function %s() expects %d arguments, got %d", name, len(signature.params), len(f.args))
+	}
+	for i, param := range signature.params {
+		arg := f.args[i]
+		switch {
+		case param == "nodes" && arg.query == nil:
+			return nil, p.errorf("argument %d of function %s() must be a query", i+1, name)
+		case param == "value" && arg.query != nil && !arg.query.isSingular():
+			return nil, p.errorf("argument %d of function %s() must be a singular query", i+1, name)
+		case param == "value" && arg.comparable != nil && !isJSONPathValue(arg.comparable):
+			return nil, p.errorf("argument %d of function %s() must be a value", i+1, name)
+		}
+	}
+	return f, nil
+}
+
+// isJSONPathValue reports whether the operand produces a value.
+func isJSONPathValue(operand jsonpathComparable) bool {
+	if _, ok := operand.(*jsonpathFunction); ok {
+		return isJSONPathFunction(operand, "value")
+	}
+	return true
+}
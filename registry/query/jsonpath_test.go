@@ -0,0 +1,165 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storeJSON = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  }
+}`
+
+func TestEvaluateJSONPath(t *testing.T) {
+	var store any
+	require.NoError(t, json.Unmarshal([]byte(storeJSON), &store))
+
+	tests := []struct {
+		expression string
+		expected   []any
+	}{
+		// Examples of RFC 9535
+		{"$.store.book[*].author", []any{"Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"}},
+		{"$..author", []any{"Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"}},
+		{"$.store.*.color", []any{"red"}},
+		{"$.store..price", []any{399.0, 8.95, 12.99, 8.99, 22.99}},
+		{"$..book[2].title", []any{"Moby Dick"}},
+		{"$..book[-1].title", []any{"The Lord of the Rings"}},
+		{"$..book[0,1].title", []any{"Sayings of the Century", "Sword of Honour"}},
+		{"$..book[:2].title", []any{"Sayings of the Century", "Sword of Honour"}},
+		{"$..book[?@.isbn].title", []any{"Moby Dick", "The Lord of the Rings"}},
+		{"$..book[?@.price<10].title", []any{"Sayings of the Century", "Moby Dick"}},
+		{"$..book[?(@.price < 10)].title", []any{"Sayings of the Century", "Moby Dick"}},
+
+		// Selectors
+		{"$", []any{store}},
+		{"$['store']['bicycle']['color']", []any{"red"}},
+		{`$["store"].bicycle["color", 'price']`, []any{"red", 399.0}},
+		{"$.store.bicycle.*", []any{"red", 399.0}},
+		{"$.store.book[1::2].author", []any{"Evelyn Waugh", "J. R. R. Tolkien"}},
+		{"$.store.book[::-1].price", []any{22.99, 8.99, 12.99, 8.95}},
+		{"$.store.book[-2:].price", []any{8.99, 22.99}},
+		{"$.store.book[0:0]", []any{}},
+		{"$.store.book[10]", []any{}},
+		{"$.store.missing", []any{}},
+		{"$.store.bicycle[0]", []any{}},
+		{"$..[?@.color].price", []any{399.0}},
+		{"$.store .bicycle .color", []any{"red"}},
+
+		// Filters
+		{"$.store.book[?@.category == 'fiction' && @.price > 20].title", []any{"The Lord of the Rings"}},
+		{"$.store.book[?@.price < 9 || @.price > 20].price", []any{8.95, 8.99, 22.99}},
+		{"$.store.book[?!@.isbn].price", []any{8.95, 12.99}},
+		{"$.store.book[?!(@.price < 10)].price", []any{12.99, 22.99}},
+		{"$.store.book[?@.price >= 12.99 && @.price <= 22.99].price", []any{12.99, 22.99}},
+		{"$.store.book[?@.isbn != null].price", []any{8.95, 12.99, 8.99, 22.99}},
+		{"$.store.book[?@.isbn == null].price", []any{}},
+		{"$.store.book[?@.missing == @.other].price", []any{8.95, 12.99, 8.99, 22.99}},
+		{"$.store.book[?@.author > 'J'].author", []any{"Nigel Rees", "J. R. R. Tolkien"}},
+		{"$.store.book[?@.price < $.store.bicycle.price && @.price > 20].price", []any{22.99}},
+		{"$.store.book[?length(@.author) == 10].author", []any{"Nigel Rees"}},
+		{"$.store[?count(@.*) == 2].color", []any{"red"}},
+		{"$.store.book[?match(@.isbn, '0-553-.*')].title", []any{"Moby Dick"}},
+		{"$.store.book[?match(@.isbn, '0-553')].title", []any{}},
+		{"$.store.book[?search(@.author, 'R+[.]')].author", []any{"J. R. R. Tolkien"}},
+		{"$.store.book[?value(@..isbn) == '0-395-19395-8'].title", []any{"The Lord of the Rings"}},
+		{"$.store.book[?@.price == 8.95].title", []any{"Sayings of the Century"}},
+		{`$.store.book[?@.title == "Moby Dick"].price`, []any{8.99}},
+		{`$[?@ == true]`, []any{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			result, err := evaluateJSONPath(test.expression, store)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestEvaluateJSONPathTypedValues(t *testing.T) {
+	type item struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+		Count   int    `json:"count,omitempty"`
+		Secret  string `json:"-"`
+	}
+
+	data := map[string]any{
+		"items": []item{{Name: "a", Enabled: true, Count: 2}, {Name: "b", Secret: "x"}},
+		"ids":   []int{1, 2, 3},
+	}
+
+	result, err := evaluateJSONPath("$.items[?@.enabled == true].name", normalizeValue(reflect.ValueOf(data)))
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a"}, result)
+
+	result, err = evaluateJSONPath("$.items[?@.count].count", normalizeValue(reflect.ValueOf(data)))
+	require.NoError(t, err)
+	assert.Equal(t, []any{2}, result)
+
+	result, err = evaluateJSONPath("$.ids[?@ > 1]", normalizeValue(reflect.ValueOf(data)))
+	require.NoError(t, err)
+	assert.Equal(t, []any{2, 3}, result)
+
+	result, err = evaluateJSONPath("$.items[*].Secret", normalizeValue(reflect.ValueOf(data)))
+	require.NoError(t, err)
+	assert.Equal(t, []any{}, result)
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "at position 0: query must start with $"},
+		{"store.book", "query must start with $"},
+		{"$.", "expected a member name"},
+		{"$.1", "expected a member name"},
+		{"$[", "expected a selector, found end of query"},
+		{"$[1", `expected "," or "]"`},
+		{"$[01]", `invalid integer "01"`},
+		{"$[-0]", `invalid integer "-0"`},
+		{"$['abc]", "unterminated string literal"},
+		{`$['\q']`, `invalid escape sequence \q`},
+		{`$['\"']`, `invalid escape sequence \"`},
+		{`$['\uD800']`, "invalid unicode surrogate pair"},
+		{"$[?@.a ==]", `unexpected ']' in expression`},
+		{"$[?@.a ==", "expected an expression, found end of query"},
+		{"$[?@.a == foo]", `unexpected "foo"`},
+		{"$[?'a']", "expected a comparison operator"},
+		{"$[?@..a == 1]", "queries in comparisons must select a single node"},
+		{"$[?@.* == 1]", "queries in comparisons must select a single node"},
+		{"$[?!@.a == 1]", `expected "," or "]"`},
+		{"$[?!1]", `expected a query or a function after "!"`},
+		{"$[?(@.a]", `expected ")"`},
+		{"$[?unknown(@.a)]", "unknown function unknown()"},
+		{"$[?length(@.a)]", "expected a comparison operator"},
+		{"$[?match(@.a, 'x') == true]", "function match() cannot be compared"},
+		{"$[?length(@.a, 1) == 1]", "function length() expects 1 arguments, got 2"},
+		{"$[?count(1) == 1]", "argument 1 of function count() must be a query"},
+		{"$[?length(@.*) == 1]", "argument 1 of function length() must be a singular query"},
+		{"$[?length(match(@.a, 'x')) == 1]", "argument 1 of function length() must be a value"},
+		{"$.a b", `unexpected "b"`},
+		{"$[?@.a == 1.]", `invalid number "1."`},
+		{"$[?@.a == 01]", `invalid number "01"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := parseJSONPath(test.expression)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
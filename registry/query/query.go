@@ -0,0 +1,39 @@
+// Package query provides functions to select values in nested data with
+// JSONPath (RFC 9535) and JMESPath expressions. Both work on decoded data,
+// like the output of `fromJSON` or `dict`, and on raw JSON strings.
+package query
+
+import (
+	"github.com/go-sprout/sprout"
+)
+
+type QueryRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+}
+
+// NewRegistry creates a new instance of query registry.
+func NewRegistry() *QueryRegistry {
+	return &QueryRegistry{}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (qr *QueryRegistry) UID() string {
+	return "go-sprout/sprout.query"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (qr *QueryRegistry) LinkHandler(fh sprout.Handler) error {
+	qr.handler = fh
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (qr *QueryRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "jsonpath", qr.JSONPath)
+	sprout.AddFunction(funcsMap, "jmespath", qr.JMESPath)
+	return nil
+}
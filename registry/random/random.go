@@ -42,6 +42,10 @@ func NewRegistry() *RandomRegistry {
 	return &RandomRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (rr *RandomRegistry) UID() string {
 	return "go-sprout/sprout.random"
@@ -11,6 +11,10 @@ func NewRegistry() *ReflectRegistry {
 	return &ReflectRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (rr *ReflectRegistry) UID() string {
 	return "go-sprout/sprout.reflect"
@@ -18,6 +18,10 @@ func NewRegistry() *RegexRegistry {
 	return &RegexRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (rr *RegexRegistry) UID() string {
 	return "go-sprout/sprout.regex"
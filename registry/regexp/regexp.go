@@ -23,6 +23,10 @@ func NewRegistry() *RegexpRegistry {
 	return &RegexpRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (rr *RegexpRegistry) UID() string {
 	return "go-sprout/sprout.regexp"
@@ -11,6 +11,10 @@ func NewRegistry() *SemverRegistry {
 	return &SemverRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (sr *SemverRegistry) UID() string {
 	return "go-sprout/sprout.semver"
@@ -3,6 +3,7 @@ package slices
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -809,3 +810,663 @@ func (sr *SlicesRegistry) Until(count int) []int {
 func (sr *SlicesRegistry) UntilStep(start, stop, step int) []int {
 	return helpers.UntilStep(start, stop, step)
 }
+
+// Filter returns the items of a list for which the selector returns a truthy
+// value. The selector is either a dig-style path to a field of the items, or
+// the name of a template function prefixed with "fn:", called with each item.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to filter.
+//
+// Returns:
+//
+//	[]any - the items for which the selector is truthy.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: filter].
+//
+// [Sprout Documentation: filter]: https://docs.atom.codes/sprout/registries/slices#filter
+func (sr *SlicesRegistry) Filter(selector string, list any) ([]any, error) {
+	matched, _, err := sr.partition("filter", selector, list)
+	return matched, err
+}
+
+// Reject returns the items of a list for which the selector returns a falsy
+// value, the opposite of Filter.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to filter.
+//
+// Returns:
+//
+//	[]any - the items for which the selector is falsy.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: reject].
+//
+// [Sprout Documentation: reject]: https://docs.atom.codes/sprout/registries/slices#reject
+func (sr *SlicesRegistry) Reject(selector string, list any) ([]any, error) {
+	_, rejected, err := sr.partition("reject", selector, list)
+	return rejected, err
+}
+
+// Partition splits a list in two: the items for which the selector returns a
+// truthy value, and the others, both in the order of the list.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to split.
+//
+// Returns:
+//
+//	[][]any - the list of truthy items and the list of falsy items.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: partition].
+//
+// [Sprout Documentation: partition]: https://docs.atom.codes/sprout/registries/slices#partition
+func (sr *SlicesRegistry) Partition(selector string, list any) ([][]any, error) {
+	matched, rejected, err := sr.partition("partition", selector, list)
+	if err != nil {
+		return nil, err
+	}
+	return [][]any{matched, rejected}, nil
+}
+
+// Map returns the list of the values returned by the selector for each item
+// of a list, such as a field of a list of maps.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to transform.
+//
+// Returns:
+//
+//	[]any - the selected values, in the order of the list.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: map].
+//
+// [Sprout Documentation: map]: https://docs.atom.codes/sprout/registries/slices#map
+func (sr *SlicesRegistry) Map(selector string, list any) ([]any, error) {
+	_, values, err := sr.selectAll("map", selector, list)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Reduce combines the items of a list into a single value, calling the named
+// template function with the accumulated value and each item in turn, the
+// accumulated value starting at initial.
+//
+// Parameters:
+//
+//	function string - the name of a template function taking two arguments.
+//	initial any - the initial accumulated value.
+//	list any - the list to reduce.
+//
+// Returns:
+//
+//	any - the final accumulated value.
+//	error - error if the list is nil or not a slice/array, the function does
+//	        not exist, or a call fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: reduce].
+//
+// [Sprout Documentation: reduce]: https://docs.atom.codes/sprout/registries/slices#reduce
+func (sr *SlicesRegistry) Reduce(function string, initial any, list any) (any, error) {
+	items, err := sr.listItems("reduce", list)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := sr.lookupFunction(function)
+	if !ok {
+		return nil, fmt.Errorf("cannot reduce with %q: not a template function", function)
+	}
+
+	accumulator := initial
+	for _, item := range items {
+		if accumulator, err = sr.callFunction(function, fn, accumulator, item); err != nil {
+			return nil, err
+		}
+	}
+	return accumulator, nil
+}
+
+// SortBy returns a copy of a list sorted by the values returned by the
+// selector for each item. Numbers are compared by value, strings, booleans and
+// times by their natural order, and items with equal values keep their order.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to sort.
+//
+// Returns:
+//
+//	[]any - the sorted list.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: sortBy].
+//
+// [Sprout Documentation: sortBy]: https://docs.atom.codes/sprout/registries/slices#sortby
+func (sr *SlicesRegistry) SortBy(selector string, list any) ([]any, error) {
+	items, keys, err := sr.selectAll("sortBy", selector, list)
+	if err != nil {
+		return nil, err
+	}
+	return sr.sortByValues(items, [][]any{keys}, []bool{false}, []func(a, b any) int{sr.compareValues}), nil
+}
+
+// GroupBy groups the items of a list by the value returned by the selector,
+// converted to a string. Each group keeps the order of the list.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to group.
+//
+// Returns:
+//
+//	map[string][]any - the items grouped by the selected values.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: groupBy].
+//
+// [Sprout Documentation: groupBy]: https://docs.atom.codes/sprout/registries/slices#groupby
+func (sr *SlicesRegistry) GroupBy(selector string, list any) (map[string][]any, error) {
+	items, keys, err := sr.selectAll("groupBy", selector, list)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]any)
+	for i, item := range items {
+		key := sr.groupKey(keys[i])
+		result[key] = append(result[key], item)
+	}
+	return result, nil
+}
+
+// KeyBy indexes the items of a list by the value returned by the selector,
+// converted to a string. When several items share a value, the last one wins.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to index.
+//
+// Returns:
+//
+//	map[string]any - the items indexed by the selected values.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: keyBy].
+//
+// [Sprout Documentation: keyBy]: https://docs.atom.codes/sprout/registries/slices#keyby
+func (sr *SlicesRegistry) KeyBy(selector string, list any) (map[string]any, error) {
+	items, keys, err := sr.selectAll("keyBy", selector, list)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(items))
+	for i, item := range items {
+		result[sr.groupKey(keys[i])] = item
+	}
+	return result, nil
+}
+
+// CountBy counts the items of a list by the value returned by the selector,
+// converted to a string.
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list to count.
+//
+// Returns:
+//
+//	map[string]int - the number of items for each selected value.
+//	error - error if the list is nil or not a slice/array, or the selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: countBy].
+//
+// [Sprout Documentation: countBy]: https://docs.atom.codes/sprout/registries/slices#countby
+func (sr *SlicesRegistry) CountBy(selector string, list any) (map[string]int, error) {
+	_, keys, err := sr.selectAll("countBy", selector, list)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int)
+	for _, key := range keys {
+		result[sr.groupKey(key)]++
+	}
+	return result, nil
+}
+
+// Union returns the items found in any of the lists, without duplicates, in
+// the order of their first appearance.
+//
+// Parameters:
+//
+//	lists ...any - the lists to combine.
+//
+// Returns:
+//
+//	[]any - the items of all the lists, each appearing once.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: union].
+//
+// [Sprout Documentation: union]: https://docs.atom.codes/sprout/registries/slices#union
+func (sr *SlicesRegistry) Union(lists ...any) ([]any, error) {
+	result := []any{}
+	for _, list := range lists {
+		items, err := sr.listItems("union", list)
+		if err != nil {
+			return nil, err
+		}
+		result = sr.appendUnique(result, items...)
+	}
+	return result, nil
+}
+
+// Intersect returns the items of the first list found in all the other
+// lists, without duplicates, in the order of the first list.
+//
+// Parameters:
+//
+//	lists ...any - the lists to intersect.
+//
+// Returns:
+//
+//	[]any - the items common to all the lists.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: intersect].
+//
+// [Sprout Documentation: intersect]: https://docs.atom.codes/sprout/registries/slices#intersect
+func (sr *SlicesRegistry) Intersect(lists ...any) ([]any, error) {
+	itemsOfLists, err := sr.listsItems("intersect", lists)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemsOfLists) == 0 {
+		return []any{}, nil
+	}
+
+	result := []any{}
+	for _, item := range itemsOfLists[0] {
+		if sr.inList(result, item) {
+			continue
+		}
+		if !slices.ContainsFunc(itemsOfLists[1:], func(other []any) bool { return !sr.inList(other, item) }) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// Difference returns the items of the list found in none of the other lists,
+// without duplicates, in the order of the list.
+//
+// Parameters:
+//
+//	list any - the list to take the items from.
+//	others ...any - the lists of the items to exclude.
+//
+// Returns:
+//
+//	[]any - the items only found in the list.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: difference].
+//
+// [Sprout Documentation: difference]: https://docs.atom.codes/sprout/registries/slices#difference
+func (sr *SlicesRegistry) Difference(list any, others ...any) ([]any, error) {
+	items, err := sr.listItems("difference", list)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := sr.Union(others...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+	for _, item := range items {
+		if !sr.inList(excluded, item) {
+			result = sr.appendUnique(result, item)
+		}
+	}
+	return result, nil
+}
+
+// SymmetricDifference returns the items found in only one of the two lists,
+// without duplicates: the items of the first list, then those of the second.
+//
+// Parameters:
+//
+//	a any - the first list.
+//	b any - the second list.
+//
+// Returns:
+//
+//	[]any - the items found in exactly one of the lists.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: symmetricDifference].
+//
+// [Sprout Documentation: symmetricDifference]: https://docs.atom.codes/sprout/registries/slices#symmetricdifference
+func (sr *SlicesRegistry) SymmetricDifference(a, b any) ([]any, error) {
+	onlyInA, err := sr.Difference(a, b)
+	if err != nil {
+		return nil, err
+	}
+	onlyInB, err := sr.Difference(b, a)
+	if err != nil {
+		return nil, err
+	}
+	return append(onlyInA, onlyInB...), nil
+}
+
+// IsSubset checks whether every item of the subset is found in the list.
+//
+// Parameters:
+//
+//	subset any - the items to look for.
+//	list any - the list to search in.
+//
+// Returns:
+//
+//	bool - true if every item of the subset is in the list, otherwise false.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: isSubset].
+//
+// [Sprout Documentation: isSubset]: https://docs.atom.codes/sprout/registries/slices#issubset
+func (sr *SlicesRegistry) IsSubset(subset any, list any) (bool, error) {
+	itemsOfLists, err := sr.listsItems("isSubset", []any{subset, list})
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range itemsOfLists[0] {
+		if !sr.inList(itemsOfLists[1], item) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Zip groups the items of the lists sharing the same index, stopping at the
+// end of the shortest list.
+//
+// Parameters:
+//
+//	lists ...any - the lists to zip.
+//
+// Returns:
+//
+//	[][]any - for each index, the list of the items of every list.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: zip].
+//
+// [Sprout Documentation: zip]: https://docs.atom.codes/sprout/registries/slices#zip
+func (sr *SlicesRegistry) Zip(lists ...any) ([][]any, error) {
+	itemsOfLists, err := sr.listsItems("zip", lists)
+	if err != nil {
+		return nil, err
+	}
+	return sr.transpose(itemsOfLists), nil
+}
+
+// Unzip is the inverse of Zip: it turns a list of lists into the lists of
+// the items sharing the same index, stopping at the end of the shortest list.
+//
+// Parameters:
+//
+//	list any - the list of lists to unzip.
+//
+// Returns:
+//
+//	[][]any - for each index, the list of the items of every inner list.
+//	error - error if the list or one of its items is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: unzip].
+//
+// [Sprout Documentation: unzip]: https://docs.atom.codes/sprout/registries/slices#unzip
+func (sr *SlicesRegistry) Unzip(list any) ([][]any, error) {
+	items, err := sr.listItems("unzip", list)
+	if err != nil {
+		return nil, err
+	}
+	itemsOfLists, err := sr.listsItems("unzip", items)
+	if err != nil {
+		return nil, err
+	}
+	return sr.transpose(itemsOfLists), nil
+}
+
+// Product returns the cartesian product of the lists: every combination made
+// of one item of each list, the items of the last list varying first.
+//
+// Parameters:
+//
+//	lists ...any - the lists to combine.
+//
+// Returns:
+//
+//	[][]any - the combinations of items.
+//	error - error if a list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: product].
+//
+// [Sprout Documentation: product]: https://docs.atom.codes/sprout/registries/slices#product
+func (sr *SlicesRegistry) Product(lists ...any) ([][]any, error) {
+	itemsOfLists, err := sr.listsItems("product", lists)
+	if err != nil {
+		return nil, err
+	}
+
+	result := [][]any{{}}
+	for _, items := range itemsOfLists {
+		next := make([][]any, 0, len(result)*len(items))
+		for _, combination := range result {
+			for _, item := range items {
+				next = append(next, append(slices.Clip(combination), item))
+			}
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// Window returns the sliding windows of the given size over a list: every
+// run of size consecutive items, in order.
+//
+// Parameters:
+//
+//	size int - the number of items of each window.
+//	list any - the list to slide over.
+//
+// Returns:
+//
+//	[][]any - the windows, none when the list is shorter than the size.
+//	error - error if the size is not positive, or the list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: window].
+//
+// [Sprout Documentation: window]: https://docs.atom.codes/sprout/registries/slices#window
+func (sr *SlicesRegistry) Window(size int, list any) ([][]any, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("window size must be positive, got %d", size)
+	}
+	items, err := sr.listItems("window", list)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]any, 0, max(len(items)-size+1, 0))
+	for start := 0; start+size <= len(items); start++ {
+		result = append(result, slices.Clone(items[start:start+size]))
+	}
+	return result, nil
+}
+
+// Pairs returns the pairs of consecutive items of a list, the same as a
+// window of size 2.
+//
+// Parameters:
+//
+//	list any - the list to pair.
+//
+// Returns:
+//
+//	[][]any - the pairs of consecutive items.
+//	error - error if the list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: pairs].
+//
+// [Sprout Documentation: pairs]: https://docs.atom.codes/sprout/registries/slices#pairs
+func (sr *SlicesRegistry) Pairs(list any) ([][]any, error) {
+	return sr.Window(2, list)
+}
+
+// Sort returns a copy of a list sorted in ascending order, keeping the types
+// of its items. Numbers of any type are compared by value, times and booleans
+// by their natural order, and strings in natural order, so "item2" comes
+// before "item10". When all the strings of the list are semantic versions,
+// they are compared as versions instead. Nil items come first, and items of
+// different types are compared by their string representation.
+//
+// Parameters:
+//
+//	list any - the list to sort.
+//
+// Returns:
+//
+//	[]any - the sorted list.
+//	error - error if the list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: sort].
+//
+// [Sprout Documentation: sort]: https://docs.atom.codes/sprout/registries/slices#sort
+func (sr *SlicesRegistry) Sort(list any) ([]any, error) {
+	items, err := sr.listItems("sort", list)
+	if err != nil {
+		return nil, err
+	}
+	return sr.sortByValues(items, [][]any{items}, []bool{false}, []func(a, b any) int{sr.sortComparator(items)}), nil
+}
+
+// SortDesc returns a copy of a list sorted in descending order, comparing the
+// items like Sort. Equal items keep their order.
+//
+// Parameters:
+//
+//	list any - the list to sort.
+//
+// Returns:
+//
+//	[]any - the sorted list.
+//	error - error if the list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: sortDesc].
+//
+// [Sprout Documentation: sortDesc]: https://docs.atom.codes/sprout/registries/slices#sortdesc
+func (sr *SlicesRegistry) SortDesc(list any) ([]any, error) {
+	items, err := sr.listItems("sortDesc", list)
+	if err != nil {
+		return nil, err
+	}
+	return sr.sortByValues(items, [][]any{items}, []bool{true}, []func(a, b any) int{sr.sortComparator(items)}), nil
+}
+
+// SortByKeys returns a copy of a list sorted by several keys: items are
+// compared by the value of the first key, then by the following keys when
+// the values are equal. Each key is a selector, like in SortBy, sorting in
+// descending order when prefixed with a dash.
+//
+// Parameters:
+//
+//	keys ...string - the selectors of the sort keys, prefixed with - to sort in descending order.
+//	list any - the list to sort (last argument).
+//
+// Returns:
+//
+//	[]any - the sorted list.
+//	error - error if a key is not a string, the list is nil or not a slice/array, or a selector fails.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: sortByKeys].
+//
+// [Sprout Documentation: sortByKeys]: https://docs.atom.codes/sprout/registries/slices#sortbykeys
+func (sr *SlicesRegistry) SortByKeys(args ...any) ([]any, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("sortByKeys requires at least two arguments")
+	}
+
+	items, err := sr.listItems("sortByKeys", args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	keys := args[:len(args)-1]
+	values := make([][]any, len(keys))
+	descending := make([]bool, len(keys))
+	compare := make([]func(a, b any) int, len(keys))
+	for i, key := range keys {
+		selector, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("all keys must be strings, got %T at position %d", key, i)
+		}
+		selector, descending[i] = strings.CutPrefix(selector, "-")
+		if _, values[i], err = sr.selectAll("sortByKeys", selector, items); err != nil {
+			return nil, err
+		}
+		compare[i] = sr.sortComparator(values[i])
+	}
+	return sr.sortByValues(items, values, descending, compare), nil
+}
+
+// NaturalSort returns a copy of a list sorted in natural order: the string
+// representations of the items are compared with their runs of digits
+// compared by numeric value, so "item2" comes before "item10".
+//
+// Parameters:
+//
+//	list any - the list to sort.
+//
+// Returns:
+//
+//	[]any - the sorted list.
+//	error - error if the list is nil or not a slice/array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: naturalSort].
+//
+// [Sprout Documentation: naturalSort]: https://docs.atom.codes/sprout/registries/slices#naturalsort
+func (sr *SlicesRegistry) NaturalSort(list any) ([]any, error) {
+	items, err := sr.listItems("naturalSort", list)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(items))
+	for i, item := range items {
+		values[i] = helpers.ToString(item)
+	}
+	return sr.sortByValues(items, [][]any{values}, []bool{false}, []func(a, b any) int{func(a, b any) int {
+		return sr.naturalCompare(a.(string), b.(string))
+	}}), nil
+}
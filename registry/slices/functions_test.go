@@ -2,6 +2,7 @@ package slices_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-sprout/sprout/pesticide"
 	"github.com/go-sprout/sprout/registry/slices"
@@ -293,3 +294,321 @@ func TestUntilStep(t *testing.T) {
 
 	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
 }
+
+type service struct {
+	Name string
+	Port int
+}
+
+func services() []any {
+	return []any{
+		map[string]any{"name": "api", "port": 8080, "enabled": true, "meta": map[string]any{"team": "core"}},
+		map[string]any{"name": "web", "port": 80, "enabled": false, "meta": map[string]any{"team": "front"}},
+		map[string]any{"name": "db", "port": 5432, "enabled": true, "meta": map[string]any{"team": "core"}},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ filter "enabled" .V | map "name" }}`, ExpectedOutput: "[api db]", Data: map[string]any{"V": services()}},
+		{Name: "TestNestedPath", Input: `{{ filter "meta.team" .V | len }}`, ExpectedOutput: "3", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ filter "fn:trim" .V }}`, ExpectedOutput: "[ a b]", Data: map[string]any{"V": []string{" a", "  ", "b"}}},
+		{Name: "TestTypedMaps", Input: `{{ filter "on" .V }}`, ExpectedOutput: "[map[on:true]]", Data: map[string]any{"V": []map[string]bool{{"on": true}, {"on": false}}}},
+		{Name: "TestStructs", Input: `{{ filter "Port" .V }}`, ExpectedOutput: "[{api 8080}]", Data: map[string]any{"V": []service{{"api", 8080}, {"none", 0}}}},
+		{Name: "TestFieldNamedLikeFunction", Input: `{{ filter "trim" .V }}`, ExpectedOutput: "[map[trim:true]]", Data: map[string]any{"V": []any{map[string]any{"trim": true}, map[string]any{"trim": false}}}},
+		{Name: "TestLeadingDot", Input: `{{ filter ".trim" .V }}`, ExpectedOutput: "[map[trim:true]]", Data: map[string]any{"V": []any{map[string]any{"trim": true}, map[string]any{"trim": false}}}},
+		{Name: "TestFieldWithPrefix", Input: `{{ filter ".fn:trim" .V }}`, ExpectedOutput: "[map[fn:trim:true]]", Data: map[string]any{"V": []any{map[string]any{"fn:trim": true}, map[string]any{"fn:trim": false}}}},
+		{Name: "TestEscapedDot", Input: `{{ filter "example\\.com" .V }}`, ExpectedOutput: "[map[example.com:1]]", Data: map[string]any{"V": []any{map[string]any{"example.com": 1}, map[string]any{"example": 1}}}},
+		{Name: "TestMissingField", Input: `{{ filter "missing" .V }}`, ExpectedOutput: "[]", Data: map[string]any{"V": services()}},
+		{Name: "TestNil", Input: `{{ filter "name" .V }}`, ExpectedErr: "cannot filter nil", Data: map[string]any{"V": nil}},
+		{Name: "TestNotAList", Input: `{{ filter "name" 1 }}`, ExpectedErr: "cannot filter on type int"},
+		{Name: "TestPathOnScalar", Input: `{{ filter "name" (list 1 2) }}`, ExpectedErr: `cannot filter by "name": item 0: cannot select "name" on type int`},
+		{Name: "TestInvalidPath", Input: `{{ filter "a..b" (list 1) }}`, ExpectedErr: `empty key segment in path "a..b" (consecutive or leading/trailing dots)`},
+		{Name: "TestUnknownFunction", Input: `{{ filter "fn:unknown" (list 1) }}`, ExpectedErr: `cannot filter by "fn:unknown": "unknown" is not a template function`},
+		{Name: "TestFunctionError", Input: `{{ filter "fn:toUpper" (list 1) }}`, ExpectedErr: `cannot filter by "fn:toUpper": item 0: function "toUpper"`},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestReject(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ reject "enabled" .V | map "name" }}`, ExpectedOutput: "[web]", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ reject "fn:trim" .V | len }}`, ExpectedOutput: "1", Data: map[string]any{"V": []string{" a", "  ", "b"}}},
+		{Name: "TestNil", Input: `{{ reject "name" .V }}`, ExpectedErr: "cannot reject nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestPartition(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ $p := partition "enabled" .V }}{{ index $p 0 | map "name" }} {{ index $p 1 | map "name" }}`, ExpectedOutput: "[api db] [web]", Data: map[string]any{"V": services()}},
+		{Name: "TestEmpty", Input: `{{ partition "enabled" .V }}`, ExpectedOutput: "[[] []]", Data: map[string]any{"V": []any{}}},
+		{Name: "TestNotAList", Input: `{{ partition "name" "a" }}`, ExpectedErr: "cannot partition on type string"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestMap(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ map "name" .V }}`, ExpectedOutput: "[api web db]", Data: map[string]any{"V": services()}},
+		{Name: "TestNestedPath", Input: `{{ map "meta.team" .V }}`, ExpectedOutput: "[core front core]", Data: map[string]any{"V": services()}},
+		{Name: "TestMissingField", Input: `{{ map "meta.owner" .V }}`, ExpectedOutput: "[<nil> <nil> <nil>]", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ map "fn:toUpper" .V }}`, ExpectedOutput: "[A B]", Data: map[string]any{"V": []string{"a", "b"}}},
+		{Name: "TestStructs", Input: `{{ map "Name" .V }}`, ExpectedOutput: "[api web]", Data: map[string]any{"V": []service{{"api", 8080}, {"web", 80}}}},
+		{Name: "TestPointers", Input: `{{ map "Port" .V }}`, ExpectedOutput: "[8080 <nil>]", Data: map[string]any{"V": []*service{{"api", 8080}, nil}}},
+		{Name: "TestArray", Input: `{{ map "a" .V }}`, ExpectedOutput: "[1 2]", Data: map[string]any{"V": [2]map[string]int{{"a": 1}, {"a": 2}}}},
+		{Name: "TestNonStringKeys", Input: `{{ map "a" .V }}`, ExpectedErr: `cannot select "a" on type map[int]int`, Data: map[string]any{"V": []map[int]int{{1: 1}}}},
+		{Name: "TestNil", Input: `{{ map "name" .V }}`, ExpectedErr: "cannot map nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestReduce(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestConcat", Input: `{{ reduce "concat" (list) .V }}`, ExpectedOutput: "[1 2 3]", Data: map[string]any{"V": [][]int{{1, 2}, {3}}}},
+		{Name: "TestEmptyList", Input: `{{ reduce "concat" "initial" .V }}`, ExpectedOutput: "initial", Data: map[string]any{"V": []any{}}},
+		{Name: "TestNotAFunction", Input: `{{ reduce "name" 0 .V }}`, ExpectedErr: `cannot reduce with "name": not a template function`, Data: map[string]any{"V": services()}},
+		{Name: "TestCallError", Input: `{{ reduce "toUpper" "" .V }}`, ExpectedErr: `function "toUpper"`, Data: map[string]any{"V": []string{"a"}}},
+		{Name: "TestNil", Input: `{{ reduce "concat" 0 .V }}`, ExpectedErr: "cannot reduce nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestSortBy(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestNumbers", Input: `{{ sortBy "port" .V | map "name" }}`, ExpectedOutput: "[web db api]", Data: map[string]any{"V": services()}},
+		{Name: "TestStrings", Input: `{{ sortBy "name" .V | map "name" }}`, ExpectedOutput: "[api db web]", Data: map[string]any{"V": services()}},
+		{Name: "TestStable", Input: `{{ sortBy "meta.team" .V | map "name" }}`, ExpectedOutput: "[api db web]", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ sortBy "fn:toLower" .V }}`, ExpectedOutput: "[A b C]", Data: map[string]any{"V": []string{"b", "C", "A"}}},
+		{Name: "TestMixedNumbers", Input: `{{ sortBy "v" .V | map "v" }}`, ExpectedOutput: "[<nil> 2 10.5 11]", Data: map[string]any{"V": []any{map[string]any{"v": 11}, map[string]any{"v": 10.5}, map[string]any{}, map[string]any{"v": uint(2)}}}},
+		{Name: "TestStringsBytewise", Input: `{{ list "10" "9" "1.10.0" "1.2.0" | sortBy "." }}`, ExpectedOutput: "[1.10.0 1.2.0 10 9]"},
+		{Name: "TestStructs", Input: `{{ sortBy "Port" .V }}`, ExpectedOutput: "[{web 80} {api 8080}]", Data: map[string]any{"V": []service{{"api", 8080}, {"web", 80}}}},
+		{Name: "TestNil", Input: `{{ sortBy "name" .V }}`, ExpectedErr: "cannot sortBy nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestGroupBy(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ range $team, $items := groupBy "meta.team" .V }}{{ $team }}={{ map "name" $items }} {{ end }}`, ExpectedOutput: "core=[api db] front=[web] ", Data: map[string]any{"V": services()}},
+		{Name: "TestBooleans", Input: `{{ $g := groupBy "enabled" .V }}{{ index $g "true" | len }} {{ index $g "false" | len }}`, ExpectedOutput: "2 1", Data: map[string]any{"V": services()}},
+		{Name: "TestMissingField", Input: `{{ groupBy "missing" .V }}`, ExpectedOutput: "map[:[map[a:1] map[b:2]]]", Data: map[string]any{"V": []any{map[string]any{"a": 1}, map[string]any{"b": 2}}}},
+		{Name: "TestFunction", Input: `{{ groupBy "fn:toLower" .V }}`, ExpectedOutput: "map[a:[a A] b:[B]]", Data: map[string]any{"V": []string{"a", "B", "A"}}},
+		{Name: "TestNil", Input: `{{ groupBy "name" .V }}`, ExpectedErr: "cannot groupBy nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestKeyBy(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ (keyBy "name" .V).db.port }}`, ExpectedOutput: "5432", Data: map[string]any{"V": services()}},
+		{Name: "TestLastWins", Input: `{{ keyBy "meta.team" .V | len }} {{ (keyBy "meta.team" .V).core.name }}`, ExpectedOutput: "2 db", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ keyBy "fn:toUpper" .V }}`, ExpectedOutput: "map[A:a B:b]", Data: map[string]any{"V": []string{"a", "b"}}},
+		{Name: "TestNil", Input: `{{ keyBy "name" .V }}`, ExpectedErr: "cannot keyBy nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestCountBy(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFieldPath", Input: `{{ countBy "meta.team" .V }}`, ExpectedOutput: "map[core:2 front:1]", Data: map[string]any{"V": services()}},
+		{Name: "TestFunction", Input: `{{ countBy "fn:toLower" .V }}`, ExpectedOutput: "map[a:2 b:1]", Data: map[string]any{"V": []string{"a", "B", "A"}}},
+		{Name: "TestEmpty", Input: `{{ countBy "name" .V }}`, ExpectedOutput: "map[]", Data: map[string]any{"V": []any{}}},
+		{Name: "TestNotAList", Input: `{{ countBy "name" .V }}`, ExpectedErr: "cannot countBy on type map", Data: map[string]any{"V": map[string]any{}}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestUnion(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ union (list 1 2 3) (list 3 4 1) (list 5) }}`, ExpectedOutput: "[1 2 3 4 5]"},
+		{Name: "TestDuplicatesInList", Input: `{{ union (list "a" "a" "b") }}`, ExpectedOutput: "[a b]"},
+		{Name: "TestTypedSlices", Input: `{{ union .A .B }}`, ExpectedOutput: "[a b c]", Data: map[string]any{"A": []string{"a", "b"}, "B": [2]string{"c", "a"}}},
+		{Name: "TestNonComparable", Input: `{{ union .A .B }}`, ExpectedOutput: "[map[a:1] [1 2] map[b:2]]", Data: map[string]any{"A": []any{map[string]any{"a": 1}, []int{1, 2}}, "B": []any{[]int{1, 2}, map[string]any{"b": 2}, map[string]any{"a": 1}}}},
+		{Name: "TestNoList", Input: `{{ union }}`, ExpectedOutput: "[]"},
+		{Name: "TestNil", Input: `{{ union (list 1) .V }}`, ExpectedErr: "cannot union nil", Data: map[string]any{"V": nil}},
+		{Name: "TestNotAList", Input: `{{ union (list 1) 2 }}`, ExpectedErr: "cannot union on type int"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestIntersect(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ intersect (list 1 2 3 4) (list 4 3 1) (list 1 4 9) }}`, ExpectedOutput: "[1 4]"},
+		{Name: "TestDuplicates", Input: `{{ intersect (list "a" "b" "a") (list "a") }}`, ExpectedOutput: "[a]"},
+		{Name: "TestNonComparable", Input: `{{ intersect .A .B }}`, ExpectedOutput: "[map[a:1]]", Data: map[string]any{"A": []any{map[string]any{"a": 1}, map[string]any{"b": 2}}, "B": []map[string]any{{"a": 1}}}},
+		{Name: "TestSingleList", Input: `{{ intersect (list 2 1 2) }}`, ExpectedOutput: "[2 1]"},
+		{Name: "TestNoList", Input: `{{ intersect }}`, ExpectedOutput: "[]"},
+		{Name: "TestDisjoint", Input: `{{ intersect (list 1) (list 2) }}`, ExpectedOutput: "[]"},
+		{Name: "TestNil", Input: `{{ intersect .V (list 1) }}`, ExpectedErr: "cannot intersect nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestDifference(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ difference (list 1 2 3 4 2) (list 2) (list 4 5) }}`, ExpectedOutput: "[1 3]"},
+		{Name: "TestNoOther", Input: `{{ difference (list 1 1 2) }}`, ExpectedOutput: "[1 2]"},
+		{Name: "TestNonComparable", Input: `{{ difference .A .B }}`, ExpectedOutput: "[[1]]", Data: map[string]any{"A": []any{[]int{1}, []int{2}}, "B": []any{[]int{2}}}},
+		{Name: "TestNil", Input: `{{ difference .V }}`, ExpectedErr: "cannot difference nil", Data: map[string]any{"V": nil}},
+		{Name: "TestOtherNotAList", Input: `{{ difference (list 1) "a" }}`, ExpectedErr: "cannot union on type string"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ symmetricDifference (list 1 2 3) (list 3 4 2 5) }}`, ExpectedOutput: "[1 4 5]"},
+		{Name: "TestEqual", Input: `{{ symmetricDifference (list "a" "b") (list "b" "a") }}`, ExpectedOutput: "[]"},
+		{Name: "TestNil", Input: `{{ symmetricDifference (list 1) .V }}`, ExpectedErr: "nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestIsSubset(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestSubset", Input: `{{ isSubset (list 1 3) (list 1 2 3) }}`, ExpectedOutput: "true"},
+		{Name: "TestNotSubset", Input: `{{ isSubset (list 1 4) (list 1 2 3) }}`, ExpectedOutput: "false"},
+		{Name: "TestEmpty", Input: `{{ isSubset (list) (list 1) }}`, ExpectedOutput: "true"},
+		{Name: "TestPipe", Input: `{{ list "a" "b" | isSubset (list "b") }}`, ExpectedOutput: "true"},
+		{Name: "TestNonComparable", Input: `{{ isSubset .A .B }}`, ExpectedOutput: "true", Data: map[string]any{"A": []any{map[string]int{"a": 1}}, "B": []any{map[string]int{"a": 1}, 2}}},
+		{Name: "TestNotAList", Input: `{{ isSubset 1 (list 1) }}`, ExpectedErr: "cannot isSubset on type int"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestZip(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ zip (list "a" "b" "c") (list 1 2 3) }}`, ExpectedOutput: "[[a 1] [b 2] [c 3]]"},
+		{Name: "TestShortest", Input: `{{ zip (list "a" "b" "c") .V (list true false) }}`, ExpectedOutput: "[[a 1 true] [b 2 false]]", Data: map[string]any{"V": []int{1, 2, 3}}},
+		{Name: "TestNoList", Input: `{{ zip }}`, ExpectedOutput: "[]"},
+		{Name: "TestNil", Input: `{{ zip (list 1) .V }}`, ExpectedErr: "cannot zip nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestUnzip(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ unzip (list (list "a" 1) (list "b" 2)) }}`, ExpectedOutput: "[[a b] [1 2]]"},
+		{Name: "TestZipRoundTrip", Input: `{{ zip (list 1 2) (list 3 4) | unzip }}`, ExpectedOutput: "[[1 2] [3 4]]"},
+		{Name: "TestTypedSlices", Input: `{{ unzip .V }}`, ExpectedOutput: "[[1 3] [2 4]]", Data: map[string]any{"V": [][]int{{1, 2, 9}, {3, 4}}}},
+		{Name: "TestEmpty", Input: `{{ unzip (list) }}`, ExpectedOutput: "[]"},
+		{Name: "TestItemNotAList", Input: `{{ unzip (list (list 1) 2) }}`, ExpectedErr: "cannot unzip on type int"},
+		{Name: "TestNil", Input: `{{ unzip .V }}`, ExpectedErr: "cannot unzip nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestProduct(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestLists", Input: `{{ product (list "a" "b") (list 1 2) }}`, ExpectedOutput: "[[a 1] [a 2] [b 1] [b 2]]"},
+		{Name: "TestThreeLists", Input: `{{ product (list 1 2) (list 3) (list 4 5) }}`, ExpectedOutput: "[[1 3 4] [1 3 5] [2 3 4] [2 3 5]]"},
+		{Name: "TestEmptyList", Input: `{{ product (list 1 2) (list) }}`, ExpectedOutput: "[]"},
+		{Name: "TestNoList", Input: `{{ product }}`, ExpectedOutput: "[[]]"},
+		{Name: "TestNotAList", Input: `{{ product (list 1) "a" }}`, ExpectedErr: "cannot product on type string"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestWindow(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestWindows", Input: `{{ window 3 (list 1 2 3 4 5) }}`, ExpectedOutput: "[[1 2 3] [2 3 4] [3 4 5]]"},
+		{Name: "TestSizeOfList", Input: `{{ window 2 .V }}`, ExpectedOutput: "[[a b]]", Data: map[string]any{"V": []string{"a", "b"}}},
+		{Name: "TestShorterList", Input: `{{ window 4 (list 1 2) }}`, ExpectedOutput: "[]"},
+		{Name: "TestInvalidSize", Input: `{{ window 0 (list 1 2) }}`, ExpectedErr: "window size must be positive, got 0"},
+		{Name: "TestNil", Input: `{{ window 1 .V }}`, ExpectedErr: "cannot window nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestPairs(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestPairs", Input: `{{ pairs (list "a" "b" "c") }}`, ExpectedOutput: "[[a b] [b c]]"},
+		{Name: "TestSingleItem", Input: `{{ pairs (list 1) }}`, ExpectedOutput: "[]"},
+		{Name: "TestNotAList", Input: `{{ pairs 1 }}`, ExpectedErr: "cannot window on type int"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestSort(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tc := []pesticide.TestCase{
+		{Name: "TestNumbers", Input: `{{ sort .V }}`, ExpectedOutput: "[1 2 10 25]", Data: map[string]any{"V": []int{10, 2, 25, 1}}},
+		{Name: "TestMixedNumbers", Input: `{{ sort .V }}`, ExpectedOutput: "[-1 2.5 3 10]", Data: map[string]any{"V": []any{10, 2.5, int64(-1), uint(3)}}},
+		{Name: "TestKeepsTypes", Input: `{{ range sort .V }}{{ printf "%T " . }}{{ end }}`, ExpectedOutput: "int float64 ", Data: map[string]any{"V": []any{2.5, 1}}},
+		{Name: "TestNaturalStrings", Input: `{{ sort .V }}`, ExpectedOutput: "[item1 item2 item10]", Data: map[string]any{"V": []string{"item10", "item2", "item1"}}},
+		{Name: "TestSemver", Input: `{{ sort .V }}`, ExpectedOutput: "[1.0.0-rc.1 1.0.0 v1.2.0 1.10.0]", Data: map[string]any{"V": []string{"1.10.0", "1.0.0", "v1.2.0", "1.0.0-rc.1"}}},
+		{Name: "TestSemverMixedWithStrings", Input: `{{ sort .V }}`, ExpectedOutput: "[1.0.0 1.0.0-final 1.0.0-rc.1 latest]", Data: map[string]any{"V": []string{"latest", "1.0.0-rc.1", "1.0.0", "1.0.0-final"}}},
+		{Name: "TestTimes", Input: `{{ range sort .V }}{{ .Day }} {{ end }}`, ExpectedOutput: "1 2 3 ", Data: map[string]any{"V": []time.Time{now.AddDate(0, 0, 2), now, now.AddDate(0, 0, 1)}}},
+		{Name: "TestNil", Input: `{{ sort .V }}`, ExpectedOutput: "[<nil> 1 2]", Data: map[string]any{"V": []any{2, nil, 1}}},
+		{Name: "TestEmpty", Input: `{{ sort .V }}`, ExpectedOutput: "[]", Data: map[string]any{"V": []any{}}},
+		{Name: "TestNilList", Input: `{{ sort .V }}`, ExpectedErr: "cannot sort nil", Data: map[string]any{"V": nil}},
+		{Name: "TestNotAList", Input: `{{ sort 1 }}`, ExpectedErr: "cannot sort on type int"},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestSortDesc(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestNumbers", Input: `{{ sortDesc .V }}`, ExpectedOutput: "[25 10 2 1]", Data: map[string]any{"V": []int{10, 2, 25, 1}}},
+		{Name: "TestStrings", Input: `{{ list "b10" "a" "b9" | sortDesc }}`, ExpectedOutput: "[b10 b9 a]"},
+		{Name: "TestStable", Input: `{{ sortDesc .V }}`, ExpectedOutput: "[v1.0.0 1.0.0]", Data: map[string]any{"V": []string{"v1.0.0", "1.0.0"}}},
+		{Name: "TestNilList", Input: `{{ sortDesc .V }}`, ExpectedErr: "cannot sortDesc nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestSortByKeys(t *testing.T) {
+	tasks := []any{
+		map[string]any{"name": "deploy", "priority": 2},
+		map[string]any{"name": "build", "priority": 1},
+		map[string]any{"name": "test", "priority": 2},
+		map[string]any{"name": "lint", "priority": 1},
+	}
+
+	tc := []pesticide.TestCase{
+		{Name: "TestTwoKeys", Input: `{{ sortByKeys "priority" "name" .V | map "name" }}`, ExpectedOutput: "[build lint deploy test]", Data: map[string]any{"V": tasks}},
+		{Name: "TestDescendingKey", Input: `{{ sortByKeys "priority" "-name" .V | map "name" }}`, ExpectedOutput: "[lint build test deploy]", Data: map[string]any{"V": tasks}},
+		{Name: "TestDescendingFirstKey", Input: `{{ sortByKeys "-priority" .V | map "name" }}`, ExpectedOutput: "[deploy test build lint]", Data: map[string]any{"V": tasks}},
+		{Name: "TestStructs", Input: `{{ sortByKeys "-Port" "Name" .V }}`, ExpectedOutput: "[{b 443} {c 443} {a 80}]", Data: map[string]any{"V": []service{{"c", 443}, {"a", 80}, {"b", 443}}}},
+		{Name: "TestFunction", Input: `{{ list "b" "a" "B" "A" | sortByKeys "fn:toLower" "-." }}`, ExpectedOutput: "[a A b B]"},
+		{Name: "TestNoKey", Input: `{{ sortByKeys .V }}`, ExpectedErr: "sortByKeys requires at least two arguments", Data: map[string]any{"V": tasks}},
+		{Name: "TestInvalidKey", Input: `{{ sortByKeys 1 .V }}`, ExpectedErr: "all keys must be strings, got int at position 0", Data: map[string]any{"V": tasks}},
+		{Name: "TestNilList", Input: `{{ sortByKeys "name" .V }}`, ExpectedErr: "cannot sortByKeys nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
+
+func TestNaturalSort(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestStrings", Input: `{{ naturalSort .V }}`, ExpectedOutput: "[file1.txt file2.txt file10.txt]", Data: map[string]any{"V": []string{"file10.txt", "file2.txt", "file1.txt"}}},
+		{Name: "TestMixedTypes", Input: `{{ list "node10" 3 "node9" | naturalSort }}`, ExpectedOutput: "[3 node9 node10]"},
+		{Name: "TestKeepsTypes", Input: `{{ range naturalSort .V }}{{ printf "%T " . }}{{ end }}`, ExpectedOutput: "int string ", Data: map[string]any{"V": []any{"a", 1}}},
+		{Name: "TestNilList", Input: `{{ naturalSort .V }}`, ExpectedErr: "cannot naturalSort nil", Data: map[string]any{"V": nil}},
+	}
+
+	pesticide.RunTestCases(t, slices.NewRegistry(), tc)
+}
@@ -1,7 +1,17 @@
 package slices
 
 import (
+	"cmp"
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/go-sprout/sprout/internal/helpers"
+	"github.com/go-sprout/sprout/internal/runtime"
 )
 
 // inList checks if the needle is present in the haystack slice.
@@ -82,3 +92,522 @@ func (sr *SlicesRegistry) flattenSlice(value reflect.Value, remainingDeep int) [
 
 	return result
 }
+
+// listItems converts a slice or an array to a list of its items, so the
+// functions working on lists accept []any as well as typed slices.
+//
+// Parameters:
+//
+//	fn string - the name of the calling function, used in error messages.
+//	list any - the slice or array to convert.
+//
+// Returns:
+//
+//	[]any - the items of the list.
+//	error - an error if the list is nil or not a slice/array.
+func (sr *SlicesRegistry) listItems(fn string, list any) ([]any, error) {
+	if list == nil {
+		return nil, fmt.Errorf("cannot %s nil", fn)
+	}
+
+	valueOfList := reflect.ValueOf(list)
+	switch valueOfList.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]any, valueOfList.Len())
+		for i := range items {
+			items[i] = valueOfList.Index(i).Interface()
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("cannot %s on type %s", fn, valueOfList.Kind())
+	}
+}
+
+// listsItems converts each of the lists to the list of its items.
+//
+// Parameters:
+//
+//	fn string - the name of the calling function, used in error messages.
+//	lists []any - the slices or arrays to convert.
+//
+// Returns:
+//
+//	[][]any - the items of each list.
+//	error - an error if a list is nil or not a slice/array.
+func (sr *SlicesRegistry) listsItems(fn string, lists []any) ([][]any, error) {
+	result := make([][]any, len(lists))
+	for i, list := range lists {
+		items, err := sr.listItems(fn, list)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = items
+	}
+	return result, nil
+}
+
+// appendUnique appends to the list the items it does not contain yet, using
+// the comparison of inList.
+func (sr *SlicesRegistry) appendUnique(list []any, items ...any) []any {
+	for _, item := range items {
+		if !sr.inList(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// transpose groups the items of the lists sharing the same index, stopping at
+// the end of the shortest list.
+func (sr *SlicesRegistry) transpose(lists [][]any) [][]any {
+	if len(lists) == 0 {
+		return [][]any{}
+	}
+
+	length := len(lists[0])
+	for _, list := range lists[1:] {
+		length = min(length, len(list))
+	}
+
+	result := make([][]any, length)
+	for i := range result {
+		result[i] = make([]any, len(lists))
+		for j, list := range lists {
+			result[i][j] = list[i]
+		}
+	}
+	return result
+}
+
+// lookupFunction returns the template function registered under the given
+// name in the handler linked to the registry.
+//
+// Parameters:
+//
+//	name string - the name of the function.
+//
+// Returns:
+//
+//	any - the function.
+//	bool - true if the function exists, otherwise false.
+func (sr *SlicesRegistry) lookupFunction(name string) (any, bool) {
+	if sr.handler == nil {
+		return nil, false
+	}
+	fn, ok := sr.handler.RawFunctions()[name]
+	return fn, ok
+}
+
+// callFunction calls a template function with the given arguments, returning
+// its result or its error. Panics, like arguments of the wrong type, are
+// recovered as errors.
+//
+// Parameters:
+//
+//	name string - the name of the function, used in error messages.
+//	fn any - the function to call.
+//	args ...any - the arguments of the call.
+//
+// Returns:
+//
+//	any - the result of the function.
+//	error - an error if the call fails.
+func (sr *SlicesRegistry) callFunction(name string, fn any, args ...any) (any, error) {
+	result, err := runtime.SafeCall(fn, args...)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", name, err)
+	}
+	return result, nil
+}
+
+// functionSelectorPrefix is the prefix of the selectors calling a template
+// function, like "fn:toUpper".
+const functionSelectorPrefix = "fn:"
+
+// selector resolves the selector of the higher-order functions into a function
+// extracting a value from a list item. The selector is a dig-style path to a
+// field of the item, where `\.` escapes a literal dot, or the name of a
+// template function prefixed with "fn:", called with the item. A single dot
+// selects the item itself, and a leading dot is ignored, so ".fn:a" selects
+// the field "fn:a".
+//
+// Parameters:
+//
+//	selector string - the field path, or the prefixed function name.
+//
+// Returns:
+//
+//	func(any) (any, error) - the function extracting the value from an item.
+//	error - an error if the path is invalid or the function does not exist.
+//
+// Example:
+//
+//	sel, _ := sr.selector("meta.name")
+//	fmt.Println(sel(map[string]any{"meta": map[string]any{"name": "a"}})) // Output: a <nil>
+func (sr *SlicesRegistry) selector(selector string) (func(item any) (any, error), error) {
+	if selector == "." {
+		return func(item any) (any, error) { return item, nil }, nil
+	}
+
+	if name, ok := strings.CutPrefix(selector, functionSelectorPrefix); ok {
+		fn, ok := sr.lookupFunction(name)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a template function", name)
+		}
+		return func(item any) (any, error) {
+			return sr.callFunction(name, fn, item)
+		}, nil
+	}
+
+	keys, err := helpers.SplitEscapedPath(strings.TrimPrefix(selector, "."), ".", "dots")
+	if err != nil {
+		return nil, err
+	}
+	return func(item any) (any, error) {
+		return sr.selectPath(item, keys)
+	}, nil
+}
+
+// selectPath follows the keys of a path through maps with string keys and
+// struct fields. A missing key or a nil value selects nil.
+//
+// Parameters:
+//
+//	item any - the value to select into.
+//	keys []string - the keys of the path.
+//
+// Returns:
+//
+//	any - the selected value.
+//	error - an error if a value of the path is neither a map nor a struct.
+func (sr *SlicesRegistry) selectPath(item any, keys []string) (any, error) {
+	current := reflect.ValueOf(item)
+	for _, key := range keys {
+		for current.Kind() == reflect.Interface || current.Kind() == reflect.Pointer {
+			if current.IsNil() {
+				return nil, nil
+			}
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Invalid:
+			return nil, nil
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("cannot select %q on type %s", key, current.Type())
+			}
+			current = current.MapIndex(reflect.ValueOf(key).Convert(current.Type().Key()))
+		case reflect.Struct:
+			field, ok := current.Type().FieldByName(key)
+			if !ok || !field.IsExported() {
+				return nil, nil
+			}
+			current = current.FieldByIndex(field.Index)
+		default:
+			return nil, fmt.Errorf("cannot select %q on type %s", key, current.Type())
+		}
+	}
+
+	if !current.IsValid() {
+		return nil, nil
+	}
+	return current.Interface(), nil
+}
+
+// selectAll applies the selector to every item of a list.
+//
+// Parameters:
+//
+//	fn string - the name of the calling function, used in error messages.
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list of items.
+//
+// Returns:
+//
+//	[]any - the items of the list.
+//	[]any - the selected value of each item.
+//	error - an error if the list is invalid or the selector fails.
+func (sr *SlicesRegistry) selectAll(fn, selector string, list any) ([]any, []any, error) {
+	items, err := sr.listItems(fn, list)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sel, err := sr.selector(selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot %s by %q: %w", fn, selector, err)
+	}
+
+	values := make([]any, len(items))
+	for i, item := range items {
+		if values[i], err = sel(item); err != nil {
+			return nil, nil, fmt.Errorf("cannot %s by %q: item %d: %w", fn, selector, i, err)
+		}
+	}
+	return items, values, nil
+}
+
+// partition splits the items of a list between those for which the selector
+// returns a truthy value and the others.
+//
+// Parameters:
+//
+//	fn string - the name of the calling function, used in error messages.
+//	selector string - the field path, or the prefixed function name.
+//	list any - the list of items.
+//
+// Returns:
+//
+//	[]any - the items with a truthy selected value.
+//	[]any - the items with a falsy selected value.
+//	error - an error if the list is invalid or the selector fails.
+func (sr *SlicesRegistry) partition(fn, selector string, list any) ([]any, []any, error) {
+	items, values, err := sr.selectAll(fn, selector, list)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched, rejected := make([]any, 0, len(items)), make([]any, 0, len(items))
+	for i, item := range items {
+		if helpers.Empty(values[i]) {
+			rejected = append(rejected, item)
+		} else {
+			matched = append(matched, item)
+		}
+	}
+	return matched, rejected, nil
+}
+
+// groupKey converts a selected value to the string key of a group, nil
+// values being grouped under the empty string.
+func (sr *SlicesRegistry) groupKey(value any) string {
+	if value == nil {
+		return ""
+	}
+	return helpers.ToString(value)
+}
+
+// compareValues compares two values for sorting: numbers of any type are
+// compared by value, strings, booleans and times by their natural order, and
+// nil values come first. Values of other or different types are compared by
+// their string representation.
+//
+// Parameters:
+//
+//	a, b any - the values to compare.
+//
+// Returns:
+//
+//	int - a negative number if a < b, zero if a == b, a positive number if a > b.
+func (sr *SlicesRegistry) compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if x, ok := sr.numberValue(a); ok {
+		if y, ok := sr.numberValue(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case y:
+				return -1
+			default:
+				return 1
+			}
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+
+	return strings.Compare(helpers.ToString(a), helpers.ToString(b))
+}
+
+// sortComparator returns the function comparing the values of a sort key for
+// the type-aware sorts. Values are compared like compareValues, except for
+// semantic versions and strings: when all the strings of the key are valid
+// versions, with an optional "v" prefix, they are compared as versions, and
+// otherwise in natural order. Choosing once for the whole key keeps the order
+// consistent, as both orders disagree on pre-releases.
+//
+// Parameters:
+//
+//	values []any - the values of the key for every item.
+//
+// Returns:
+//
+//	func(a, b any) int - the function comparing two values of the key.
+//
+// Example:
+//
+//	compare := sr.sortComparator([]any{"1.0.0-rc.1", "1.0.0"})
+//	fmt.Println(compare("1.0.0-rc.1", "1.0.0")) // Output: -1
+func (sr *SlicesRegistry) sortComparator(values []any) func(a, b any) int {
+	versions := make(map[string]*semver.Version)
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		version, err := semver.StrictNewVersion(strings.TrimPrefix(s, "v"))
+		if err != nil {
+			versions = nil
+			break
+		}
+		versions[s] = version
+	}
+
+	return func(a, b any) int {
+		switch x := a.(type) {
+		case string:
+			if y, ok := b.(string); ok {
+				if vx, vy := versions[x], versions[y]; vx != nil && vy != nil {
+					if c := vx.Compare(vy); c != 0 {
+						return c
+					}
+				}
+				return sr.naturalCompare(x, y)
+			}
+		case *semver.Version:
+			if y, ok := b.(*semver.Version); ok && x != nil && y != nil {
+				return x.Compare(y)
+			}
+		}
+		return sr.compareValues(a, b)
+	}
+}
+
+// naturalCompare compares two strings in natural order: runs of digits are
+// compared by their numeric value, so "file2" comes before "file10", and the
+// other characters byte by byte. Strings equal in natural order, like "01"
+// and "1", are compared byte by byte to keep the order total.
+//
+// Parameters:
+//
+//	a, b string - the strings to compare.
+//
+// Returns:
+//
+//	int - a negative number if a < b, zero if a == b, a positive number if a > b.
+//
+// Example:
+//
+//	fmt.Println(sr.naturalCompare("file10", "file2")) // Output: 1
+func (sr *SlicesRegistry) naturalCompare(a, b string) int {
+	x, y := a, b
+	for x != "" && y != "" {
+		if !isDigit(x[0]) || !isDigit(y[0]) {
+			if x[0] != y[0] {
+				return cmp.Compare(x[0], y[0])
+			}
+			x, y = x[1:], y[1:]
+			continue
+		}
+
+		// Compare the numbers without their leading zeros: the longest
+		// number is the greatest, and numbers of the same length compare
+		// like strings.
+		digitsX, digitsY := leadingDigits(x), leadingDigits(y)
+		numberX, numberY := strings.TrimLeft(digitsX, "0"), strings.TrimLeft(digitsY, "0")
+		if c := cmp.Compare(len(numberX), len(numberY)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(numberX, numberY); c != 0 {
+			return c
+		}
+		x, y = x[len(digitsX):], y[len(digitsY):]
+	}
+
+	if c := cmp.Compare(len(x), len(y)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// isDigit reports whether the byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// leadingDigits returns the run of ASCII digits at the start of the string.
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return s[:end]
+}
+
+// sortByValues sorts the items by their values, a list holding the value of
+// each item for every sort key, compared in turn with the compare function of
+// the key.
+// The sort is stable, and descending reverses the order of each key.
+//
+// Parameters:
+//
+//	items []any - the items to sort.
+//	values [][]any - for each sort key, the value of each item.
+//	descending []bool - for each sort key, whether to sort in descending order.
+//	compare []func(a, b any) int - for each sort key, the function comparing two values.
+//
+// Returns:
+//
+//	[]any - a sorted copy of the items.
+func (sr *SlicesRegistry) sortByValues(items []any, values [][]any, descending []bool, compare []func(a, b any) int) []any {
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		for key := range values {
+			c := compare[key](values[key][a], values[key][b])
+			if descending[key] {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	result := make([]any, len(items))
+	for i, index := range indexes {
+		result[i] = items[index]
+	}
+	return result
+}
+
+// numberValue converts a value of any integer or floating-point type to a
+// float64.
+func (sr *SlicesRegistry) numberValue(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
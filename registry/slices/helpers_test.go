@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsComparable(t *testing.T) {
@@ -95,3 +97,95 @@ func TestSlicesRegistry_flattenSlice(t *testing.T) {
 		assert.Equal(t, tt.expected, r.flattenSlice(reflect.ValueOf(tt.input), tt.depth))
 	}
 }
+
+func TestSlicesRegistry_selectPath(t *testing.T) {
+	r := NewRegistry()
+	type inner struct{ Value int }
+	type outer struct {
+		Inner  *inner
+		hidden int
+	}
+
+	value, err := r.selectPath(map[string]any{"a": map[string]int{"b": 1}}, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	value, err = r.selectPath(outer{Inner: &inner{Value: 2}}, []string{"Inner", "Value"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+
+	value, err = r.selectPath(outer{}, []string{"Inner", "Value"})
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = r.selectPath(outer{hidden: 1}, []string{"hidden"})
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = r.selectPath(map[string]any{"a": "b"}, []string{"a", "b"})
+	require.ErrorContains(t, err, `cannot select "b" on type string`)
+}
+
+func TestSlicesRegistry_compareValues(t *testing.T) {
+	r := NewRegistry()
+	now := time.Now()
+
+	assert.Equal(t, -1, r.compareValues(2, 10))
+	assert.Equal(t, 0, r.compareValues(int64(3), 3.0))
+	assert.Equal(t, 1, r.compareValues(uint8(4), float32(3.5)))
+	assert.Equal(t, -1, r.compareValues("10", "9"))
+	assert.Equal(t, -1, r.compareValues(false, true))
+	assert.Equal(t, 0, r.compareValues(true, true))
+	assert.Equal(t, 1, r.compareValues(now.Add(time.Second), now))
+	assert.Equal(t, -1, r.compareValues(nil, 0))
+	assert.Equal(t, 1, r.compareValues("a", nil))
+	assert.Equal(t, 0, r.compareValues(nil, nil))
+	assert.Equal(t, -1, r.compareValues(1, "a"))
+}
+
+func TestSlicesRegistry_transpose(t *testing.T) {
+	r := NewRegistry()
+
+	assert.Equal(t, [][]any{{1, "a"}, {2, "b"}}, r.transpose([][]any{{1, 2, 3}, {"a", "b"}}))
+	assert.Equal(t, [][]any{}, r.transpose([][]any{{1}, {}}))
+	assert.Equal(t, [][]any{}, r.transpose(nil))
+}
+
+func TestSlicesRegistry_appendUnique(t *testing.T) {
+	r := NewRegistry()
+
+	assert.Equal(t, []any{1, 2, "1"}, r.appendUnique([]any{1}, 2, 1, "1", 2))
+	assert.Equal(t, []any{[]int{1}}, r.appendUnique(nil, []int{1}, []int{1}))
+}
+
+func TestSlicesRegistry_sortComparator(t *testing.T) {
+	r := NewRegistry()
+
+	versions := r.sortComparator([]any{"v1.2.0", "1.10.0", "1.0.0-rc.1", "1.0.0", 3})
+	assert.Equal(t, -1, versions("v1.2.0", "1.10.0"))
+	assert.Equal(t, -1, versions("1.0.0-rc.1", "1.0.0"))
+	assert.Equal(t, 1, versions("v1.0.0", "1.0.0"), "Equal versions should be compared in natural order")
+	assert.Equal(t, -1, versions(1, 3))
+
+	natural := r.sortComparator([]any{"1.0.0-rc.1", "1.0.0", "1.0.0-final", "10", "9"})
+	assert.Equal(t, 1, natural("1.0.0-rc.1", "1.0.0"))
+	assert.Equal(t, 1, natural("1.0.0-rc.1", "1.0.0-final"))
+	assert.Equal(t, -1, natural("1.0.0", "1.0.0-final"))
+	assert.Equal(t, 1, natural("10", "9"))
+
+	assert.Equal(t, -1, r.sortComparator(nil)(semver.MustParse("1.2.0"), semver.MustParse("1.10.0")))
+}
+
+func TestSlicesRegistry_naturalCompare(t *testing.T) {
+	r := NewRegistry()
+
+	assert.Equal(t, -1, r.naturalCompare("file2", "file10"))
+	assert.Equal(t, 1, r.naturalCompare("file10b", "file10a"))
+	assert.Equal(t, -1, r.naturalCompare("a1b2", "a1b10"))
+	assert.Equal(t, -1, r.naturalCompare("abc", "abcd"))
+	assert.Equal(t, -1, r.naturalCompare("1.2.10", "1.10.2"))
+	assert.Equal(t, 0, r.naturalCompare("item007", "item007"))
+	assert.Equal(t, -1, r.naturalCompare("007", "7"))
+	assert.Equal(t, -1, r.naturalCompare("9", "a"))
+	assert.Equal(t, -1, r.naturalCompare("", "0"))
+}
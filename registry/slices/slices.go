@@ -11,6 +11,10 @@ func NewRegistry() *SlicesRegistry {
 	return &SlicesRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (sr *SlicesRegistry) UID() string {
 	return "go-sprout/sprout.slices"
@@ -46,6 +50,29 @@ func (sr *SlicesRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
 	sprout.AddFunction(funcsMap, "strSlice", sr.StrSlice)
 	sprout.AddFunction(funcsMap, "until", sr.Until)
 	sprout.AddFunction(funcsMap, "untilStep", sr.UntilStep)
+	sprout.AddFunction(funcsMap, "filter", sr.Filter)
+	sprout.AddFunction(funcsMap, "reject", sr.Reject)
+	sprout.AddFunction(funcsMap, "partition", sr.Partition)
+	sprout.AddFunction(funcsMap, "map", sr.Map)
+	sprout.AddFunction(funcsMap, "reduce", sr.Reduce)
+	sprout.AddFunction(funcsMap, "sortBy", sr.SortBy)
+	sprout.AddFunction(funcsMap, "sort", sr.Sort)
+	sprout.AddFunction(funcsMap, "sortDesc", sr.SortDesc)
+	sprout.AddFunction(funcsMap, "sortByKeys", sr.SortByKeys)
+	sprout.AddFunction(funcsMap, "naturalSort", sr.NaturalSort)
+	sprout.AddFunction(funcsMap, "groupBy", sr.GroupBy)
+	sprout.AddFunction(funcsMap, "keyBy", sr.KeyBy)
+	sprout.AddFunction(funcsMap, "countBy", sr.CountBy)
+	sprout.AddFunction(funcsMap, "union", sr.Union)
+	sprout.AddFunction(funcsMap, "intersect", sr.Intersect)
+	sprout.AddFunction(funcsMap, "difference", sr.Difference)
+	sprout.AddFunction(funcsMap, "symmetricDifference", sr.SymmetricDifference)
+	sprout.AddFunction(funcsMap, "isSubset", sr.IsSubset)
+	sprout.AddFunction(funcsMap, "zip", sr.Zip)
+	sprout.AddFunction(funcsMap, "unzip", sr.Unzip)
+	sprout.AddFunction(funcsMap, "product", sr.Product)
+	sprout.AddFunction(funcsMap, "window", sr.Window)
+	sprout.AddFunction(funcsMap, "pairs", sr.Pairs)
 	return nil
 }
 
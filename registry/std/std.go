@@ -11,6 +11,10 @@ func NewRegistry() *StdRegistry {
 	return &StdRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (sr *StdRegistry) UID() string {
 	return "go-sprout/sprout.std"
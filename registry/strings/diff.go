@@ -0,0 +1,216 @@
+package strings
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around changes.
+const diffContextLines = 3
+
+// lineEdit is a line of a diff: kept (' '), removed ('-') or added ('+').
+type lineEdit struct {
+	kind byte
+	line string
+}
+
+// noNewlineMarker follows a last line without newline in a unified diff, like
+// GNU diff does.
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// splitDiffLines splits a text into lines, keeping the newline ending each
+// line, so a last line without newline differs from the same line with one.
+func splitDiffLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script transforming the lines of a into
+// the lines of b, computed with the linear space variant of the Myers
+// algorithm: the middle snake of the shortest path splits the texts in two
+// smaller problems, so memory stays proportional to the number of lines.
+func diffLines(a, b []string) []lineEdit {
+	size := len(a) + len(b) + 3
+	d := &differ{a: a, b: b, forward: make([]int, size), backward: make([]int, size)}
+	d.compare(0, len(a), 0, len(b))
+	return d.edits
+}
+
+// differ holds the state of a diff: the compared lines, the furthest reaching
+// paths of the forward and backward searches, and the edits found so far.
+type differ struct {
+	a, b              []string
+	forward, backward []int
+	edits             []lineEdit
+}
+
+// compare appends the edits transforming a[aLo:aHi] into b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, lineEdit{' ', d.a[aLo]})
+		aLo, bLo = aLo+1, bLo+1
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi:
+		for _, line := range d.b[bLo:bHi] {
+			d.edits = append(d.edits, lineEdit{'+', line})
+		}
+	case bLo == bHi:
+		for _, line := range d.a[aLo:aHi] {
+			d.edits = append(d.edits, lineEdit{'-', line})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for _, line := range d.a[x:u] {
+			d.edits = append(d.edits, lineEdit{' ', line})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for _, line := range d.a[aHi : aHi+suffix] {
+		d.edits = append(d.edits, lineEdit{' ', line})
+	}
+}
+
+// middleSnake searches the shortest path from both ends of a[aLo:aHi] and
+// b[bLo:bHi] until they overlap, and returns the snake where they meet, from
+// (x, y) to (u, v). The texts must not be empty.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	// Diagonals k = x - y range from -limit to limit, shifted by offset.
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+	d.forward[offset+1], d.backward[offset+1] = 0, 0
+
+	for depth := 0; depth <= limit; depth++ {
+		for k := -depth; k <= depth; k += 2 {
+			var x int
+			if k == -depth || (k != depth && d.forward[offset+k-1] < d.forward[offset+k+1]) {
+				x = d.forward[offset+k+1]
+			} else {
+				x = d.forward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x, y = x+1, y+1
+			}
+			d.forward[offset+k] = x
+			// The backward search runs on reversed texts, where the
+			// diagonal k is delta - k.
+			if odd && delta-k >= -(depth-1) && delta-k <= depth-1 && x+d.backward[offset+delta-k] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		for k := -depth; k <= depth; k += 2 {
+			var x int
+			if k == -depth || (k != depth && d.backward[offset+k-1] < d.backward[offset+k+1]) {
+				x = d.backward[offset+k+1]
+			} else {
+				x = d.backward[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aHi-x-1] == d.b[bHi-y-1] {
+				x, y = x+1, y+1
+			}
+			d.backward[offset+k] = x
+			if !odd && delta-k >= -depth && delta-k <= depth && x+d.forward[offset+delta-k] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+
+	// Unreachable, the searches overlap after (n + m + 1) / 2 steps. Removing
+	// all the lines of a before adding the ones of b is still a valid edit.
+	return aHi, bLo, aHi, bLo
+}
+
+// formatUnifiedDiff formats the edits in the unified format, grouping the
+// changes in hunks surrounded by context lines. A line without newline is
+// followed by a marker, as the newline of the last line is part of the diff.
+func formatUnifiedDiff(edits []lineEdit) string {
+	var sb strings.Builder
+	sb.WriteString("--- a\n+++ b\n")
+
+	for start := 0; start < len(edits); {
+		// Find the next change, and the end of the hunk containing it: the
+		// hunk ends when two contexts of unchanged lines separate changes.
+		first := slices.IndexFunc(edits[start:], func(e lineEdit) bool { return e.kind != ' ' })
+		if first < 0 {
+			break
+		}
+		first += start
+		end, unchanged := first, 0
+		for i := first; i < len(edits) && unchanged <= 2*diffContextLines; i++ {
+			if edits[i].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged, end = 0, i+1
+			}
+		}
+
+		hunkStart := max(first-diffContextLines, start)
+		hunkEnd := min(end+diffContextLines, len(edits))
+
+		// Count the lines of both texts before and inside the hunk.
+		oldLine, newLine := 0, 0
+		for _, e := range edits[:hunkStart] {
+			if e.kind != '+' {
+				oldLine++
+			}
+			if e.kind != '-' {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, e := range edits[hunkStart:hunkEnd] {
+			if e.kind != '+' {
+				oldCount++
+			}
+			if e.kind != '-' {
+				newCount++
+			}
+		}
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, e := range edits[hunkStart:hunkEnd] {
+			sb.WriteByte(e.kind)
+			sb.WriteString(e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				sb.WriteByte('\n')
+				sb.WriteString(noNewlineMarker)
+			}
+		}
+		start = hunkEnd
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// hunkRange formats the range of a hunk, where line is the number of lines
+// before the hunk. Like GNU diff, an empty range starts at the line before.
+func hunkRange(line, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", line)
+	case 1:
+		return fmt.Sprintf("%d", line+1)
+	default:
+		return fmt.Sprintf("%d,%d", line+1, count)
+	}
+}
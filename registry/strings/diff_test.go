@@ -0,0 +1,148 @@
+package strings
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"Equal", "a\nb", "a\nb", " a| b"},
+		{"BothEmpty", "", "", ""},
+		{"FromEmpty", "", "a\nb", "+a|+b"},
+		{"ToEmpty", "a\nb", "", "-a|-b"},
+		{"Change", "a\nb\nc", "a\nx\nc", " a|-b|+x| c"},
+		{"Insert", "a\nc", "a\nb\nc", " a|+b| c"},
+		{"Delete", "a\nb\nc", "a\nc", " a|-b| c"},
+		{"FinalNewline", "a\nb", "a\nb\n", " a|-b|+b"},
+		{"Myers", "a\nb\nc\na\nb\nb\na\n", "c\nb\na\nb\na\nc\n", "-a|+c| b|-c| a| b|-b| a|+c"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var parts []string
+			for _, edit := range diffLines(splitDiffLines(test.a), splitDiffLines(test.b)) {
+				parts = append(parts, string(edit.kind)+strings.TrimSuffix(edit.line, "\n"))
+			}
+			assert.Equal(t, test.expected, strings.Join(parts, "|"))
+		})
+	}
+}
+
+func TestDiffLines_Shortest(t *testing.T) {
+	// lcs returns the length of the longest common subsequence of a and b.
+	lcs := func(a, b []string) int {
+		row := make([]int, len(b)+1)
+		for i := range a {
+			prev := 0
+			for j := range b {
+				current := row[j+1]
+				if a[i] == b[j] {
+					row[j+1] = prev + 1
+				} else {
+					row[j+1] = max(row[j+1], row[j])
+				}
+				prev = current
+			}
+		}
+		return row[len(b)]
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	randomLines := func() []string {
+		result := make([]string, rng.IntN(30))
+		for i := range result {
+			result[i] = string(rune('a' + rng.IntN(4)))
+		}
+		return result
+	}
+
+	for range 500 {
+		a, b := randomLines(), randomLines()
+		var oldLines, newLines []string
+		changes := 0
+		for _, edit := range diffLines(a, b) {
+			if edit.kind != '+' {
+				oldLines = append(oldLines, edit.line)
+			}
+			if edit.kind != '-' {
+				newLines = append(newLines, edit.line)
+			}
+			if edit.kind != ' ' {
+				changes++
+			}
+		}
+		assert.Equal(t, strings.Join(a, "|"), strings.Join(oldLines, "|"))
+		assert.Equal(t, strings.Join(b, "|"), strings.Join(newLines, "|"))
+		assert.Equal(t, len(a)+len(b)-2*lcs(a, b), changes, "The edit script should be the shortest")
+	}
+}
+
+func TestDiffLines_Large(t *testing.T) {
+	a := make([]string, 50000)
+	for i := range a {
+		a[i] = strconv.Itoa(i)
+	}
+	b := append([]string{"first"}, a[:25000]...)
+	b = append(b, a[25001:]...)
+
+	edits := diffLines(a, b)
+	assert.Len(t, edits, 50001)
+	assert.Equal(t, lineEdit{'+', "first"}, edits[0])
+	assert.Equal(t, lineEdit{'-', "25000"}, edits[25001])
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	lines := func(n int) []string {
+		result := make([]string, n)
+		for i := range result {
+			result[i] = string(rune('a'+i)) + "\n"
+		}
+		return result
+	}
+
+	old := lines(20)
+	updated := append([]string{}, old...)
+	updated[1] = "B\n"
+	updated[16] = "Q\n"
+	updated = append(updated[:10], updated[11:]...)
+
+	expected := `--- a
++++ b
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,13 +8,12 @@
+ h
+ i
+ j
+-k
+ l
+ m
+ n
+ o
+ p
+-q
++Q
+ r
+ s
+ t`
+	assert.Equal(t, expected, formatUnifiedDiff(diffLines(old, updated)))
+
+	assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1 @@\n+a", formatUnifiedDiff(diffLines(nil, []string{"a\n"})))
+	assert.Equal(t, "--- a\n+++ b\n@@ -1,2 +0,0 @@\n-a\n-b", formatUnifiedDiff(diffLines([]string{"a\n", "b\n"}, nil)))
+	assert.Equal(t, "--- a\n+++ b\n@@ -1,4 +1,3 @@\n a\n b\n-c\n d", formatUnifiedDiff(diffLines(lines(4), []string{"a\n", "b\n", "d\n"})))
+	assert.Equal(t, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b", formatUnifiedDiff(diffLines([]string{"a\n", "b"}, []string{"a\n", "b\n"})))
+}
@@ -3,6 +3,7 @@ package strings
 import (
 	"fmt"
 	mathrand "math/rand"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -977,3 +978,29 @@ func (sr *StringsRegistry) Unescape(charset, value string) (string, error) {
 
 	return result.String(), nil
 }
+
+// UnifiedDiff compares two texts line by line and returns their differences
+// in the unified diff format, with three lines of context around each
+// change. The texts are labeled `a` and `b` in the header. Like GNU diff, a
+// missing final newline is a difference, marked by `\ No newline at end of
+// file` after the last line.
+//
+// Parameters:
+//
+//	a string - the old text.
+//	b string - the new text.
+//
+// Returns:
+//
+//	string - the unified diff, or an empty string if the texts are equal.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: unifiedDiff].
+//
+// [Sprout Documentation: unifiedDiff]: https://docs.atom.codes/sprout/registries/strings#unifieddiff
+func (sr *StringsRegistry) UnifiedDiff(a, b string) string {
+	oldLines, newLines := splitDiffLines(a), splitDiffLines(b)
+	if slices.Equal(oldLines, newLines) {
+		return ""
+	}
+	return formatUnifiedDiff(diffLines(oldLines, newLines))
+}
@@ -585,3 +585,18 @@ func TestEscapeUnescapeRoundTrip(t *testing.T) {
 
 	pesticide.RunTestCases(t, strings.NewRegistry(), tc)
 }
+
+func TestUnifiedDiff(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestEqual", Input: `{{ unifiedDiff "a\nb" "a\nb" }}`, ExpectedOutput: ""},
+		{Name: "TestFinalNewline", Input: `{{ unifiedDiff "a\nb\n" "a\nb\n" }}`, ExpectedOutput: ""},
+		{Name: "TestAddedFinalNewline", Input: `{{ unifiedDiff "x" "x\n" }}`, ExpectedOutput: "--- a\n+++ b\n@@ -1 +1 @@\n-x\n\\ No newline at end of file\n+x"},
+		{Name: "TestRemovedFinalNewline", Input: `{{ unifiedDiff "a\nb\n" "a\nb" }}`, ExpectedOutput: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file"},
+		{Name: "TestNoFinalNewline", Input: `{{ unifiedDiff "a\nb" "a\nc" }}`, ExpectedOutput: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file"},
+		{Name: "TestChange", Input: `{{ unifiedDiff "replicas: 1\nimage: app:1.0\n" "replicas: 3\nimage: app:1.0\n" }}`, ExpectedOutput: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n-replicas: 1\n+replicas: 3\n image: app:1.0"},
+		{Name: "TestAddition", Input: `{{ unifiedDiff "" "a\n" }}`, ExpectedOutput: "--- a\n+++ b\n@@ -0,0 +1 @@\n+a"},
+		{Name: "TestData", Input: `{{ unifiedDiff .Old .New }}`, Data: map[string]any{"Old": "a\nb\nc\nd\n", "New": "a\nc\nd\ne\n"}, ExpectedOutput: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n a\n-b\n c\n d\n+e"},
+	}
+
+	pesticide.RunTestCases(t, strings.NewRegistry(), tc)
+}
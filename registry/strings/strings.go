@@ -60,6 +60,10 @@ func NewRegistry() *StringsRegistry {
 	return &StringsRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (sr *StringsRegistry) UID() string {
 	return "go-sprout/sprout.strings"
@@ -116,5 +120,6 @@ func (sr *StringsRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error
 	sprout.AddFunction(funcsMap, "seq", sr.Seq)
 	sprout.AddFunction(funcsMap, "escape", sr.Escape)
 	sprout.AddFunction(funcsMap, "unescape", sr.Unescape)
+	sprout.AddFunction(funcsMap, "unifiedDiff", sr.UnifiedDiff)
 	return nil
 }
@@ -0,0 +1,87 @@
+package templating
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sprout/sprout/internal/runtime"
+)
+
+// tplTemplateName is the name of the template rendered by `tpl`.
+const tplTemplateName = "tpl"
+
+// Include renders the named template with the given data and returns the
+// result as a string, making it usable in a pipeline unlike the `template`
+// action.
+//
+// Parameters:
+//
+//	name string - the name of the template to render.
+//	data any - the data passed as dot to the template.
+//
+// Returns:
+//
+//	string - the rendered template.
+//	error - when no template is linked, the template does not exist, its
+//	        rendering fails or the maximum nesting depth is exceeded.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: include].
+//
+// [Sprout Documentation: include]: https://docs.atom.codes/sprout/registries/templating#include
+func (tr *TemplatingRegistry) Include(name string, data any) (string, error) {
+	tmpl := tr.linkedTemplate()
+	if tmpl == nil {
+		return "", ErrNoTemplateLinked
+	}
+
+	if err := tr.checkDepth("include", runtime.CallDepth()); err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("cannot include template %q: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// Tpl renders the given string as a template with the given data, using the
+// same functions as the current template. When a template set is linked to
+// the registry, the named templates of the set are available too.
+//
+// Parameters:
+//
+//	text string - the template string to render.
+//	data any - the data passed as dot to the template.
+//
+// Returns:
+//
+//	string - the rendered template.
+//	error - when the template cannot be parsed or rendered, or the maximum
+//	        nesting depth is exceeded.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: tpl].
+//
+// [Sprout Documentation: tpl]: https://docs.atom.codes/sprout/registries/templating#tpl
+func (tr *TemplatingRegistry) Tpl(text string, data any) (string, error) {
+	if err := tr.checkDepth("tpl", runtime.CallDepth()); err != nil {
+		return "", err
+	}
+
+	set, err := tr.acquireTplSet()
+	if err != nil {
+		return "", err
+	}
+	defer tr.releaseTplSet(set, text)
+
+	tmpl := set.template.New(tplTemplateName)
+	if _, err := tmpl.Parse(text); err != nil {
+		return "", fmt.Errorf("cannot parse tpl template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("cannot execute tpl template: %w", err)
+	}
+	return buf.String(), nil
+}
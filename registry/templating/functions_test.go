@@ -0,0 +1,158 @@
+package templating_test
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sprout/sprout"
+	"github.com/go-sprout/sprout/pesticide"
+	sproutstrings "github.com/go-sprout/sprout/registry/strings"
+	"github.com/go-sprout/sprout/registry/templating"
+)
+
+// runLinkedTemplate renders the input with a templating registry linked to the
+// rendered template set.
+func runLinkedTemplate(t *testing.T, reg *templating.TemplatingRegistry, input string, data any) (string, error) {
+	t.Helper()
+
+	handler := sprout.New(sprout.WithRegistries(reg, sproutstrings.NewRegistry()))
+	tmpl := template.New("test").Funcs(handler.Build())
+	reg.LinkTemplate(tmpl)
+
+	tmpl, err := tmpl.Parse(input)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestInclude(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestInclude", Input: `{{ define "greet" }}Hello {{ . }}{{ end }}{{ include "greet" "World" }}`, ExpectedOutput: "Hello World"},
+		{Name: "TestIncludePipeline", Input: `{{ define "greet" }}Hello {{ . }}{{ end }}{{ "world" | include "greet" | toUpper }}`, ExpectedOutput: "HELLO WORLD"},
+		{Name: "TestIncludeNested", Input: `{{ define "name" }}{{ .name }}{{ end }}{{ define "greet" }}Hello {{ include "name" . }}{{ end }}{{ include "greet" .user }}`, Data: map[string]any{"user": map[string]any{"name": "Ada"}}, ExpectedOutput: "Hello Ada"},
+		{Name: "TestIncludeUnknownTemplate", Input: `{{ include "unknown" . }}`, ExpectedErr: `cannot include template "unknown"`},
+		{Name: "TestIncludeInfiniteRecursion", Input: `{{ define "loop" }}{{ include "loop" . }}{{ end }}{{ include "loop" . }}`, ExpectedErr: `maximum template nesting depth exceeded: more than 100 nested include calls`},
+		{Name: "TestIncludeBoundedRecursion", Input: `{{ define "count" }}{{ if gt . 0 }}{{ . }}{{ include "count" (sub . 1) }}{{ end }}{{ end }}{{ include "count" 3 }}`, ExpectedOutput: "321"},
+	}
+
+	for _, test := range tc {
+		t.Run(test.Name, func(t *testing.T) {
+			reg := templating.NewRegistry()
+			funcs := sprout.FuncRegistry("test/templating.sub", map[string]any{"sub": func(a, b int) int { return a - b }})
+			handler := sprout.New(sprout.WithRegistries(reg, sproutstrings.NewRegistry(), funcs))
+			tmpl := template.New("test").Funcs(handler.Build())
+			reg.LinkTemplate(tmpl)
+
+			tmpl, err := tmpl.Parse(test.Input)
+			require.NoError(t, err)
+
+			var buf strings.Builder
+			err = tmpl.Execute(&buf, test.Data)
+			if test.ExpectedErr != "" {
+				require.ErrorContains(t, err, test.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedOutput, buf.String())
+		})
+	}
+}
+
+func TestIncludeWithoutLinkedTemplate(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestNoTemplateLinked", Input: `{{ include "greet" . }}`, ExpectedErr: "no template linked to the registry"},
+	}
+
+	pesticide.RunTestCases(t, templating.NewRegistry(), tc)
+}
+
+func TestTpl(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestTpl", Input: `{{ tpl "Hello {{ .name }}" . }}`, Data: map[string]any{"name": "Ada"}, ExpectedOutput: "Hello Ada"},
+		{Name: "TestTplWithFunctions", Input: `{{ tpl .tpl . }}`, Data: map[string]any{"tpl": "{{ .name | toUpper }}", "name": "Ada"}, ExpectedOutput: "ADA"},
+		{Name: "TestTplPipeline", Input: `{{ tpl "{{ . }}" "ada" | toUpper }}`, ExpectedOutput: "ADA"},
+		{Name: "TestTplParseError", Input: `{{ tpl "{{ .name" . }}`, ExpectedErr: "cannot parse tpl template"},
+		{Name: "TestTplExecuteError", Input: `{{ tpl "{{ .name.first }}" . }}`, Data: map[string]any{"name": 1}, ExpectedErr: "cannot execute tpl template"},
+		{Name: "TestTplInfiniteRecursion", Input: `{{ tpl .tpl . }}`, Data: map[string]any{"tpl": "{{ tpl .tpl . }}"}, ExpectedErr: `maximum template nesting depth exceeded: more than 100 nested tpl calls`},
+	}
+
+	pesticide.RunTestCases(t, templating.NewRegistry(), tc)
+}
+
+func TestTplWithLinkedTemplate(t *testing.T) {
+	out, err := runLinkedTemplate(t, templating.NewRegistry(), `{{ define "name" }}{{ .name }}{{ end }}{{ tpl "Hello {{ include \"name\" . | toUpper }} {{ template \"name\" . }}" . }}`, map[string]any{"name": "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello ADA Ada", out)
+}
+
+func TestIncludeConcurrentRenders(t *testing.T) {
+	reg := templating.NewRegistry()
+	require.NoError(t, reg.Configure(map[string]any{"maxDepth": 2}))
+
+	handler := sprout.New(sprout.WithRegistries(reg))
+	tmpl := template.New("test").Funcs(handler.Build())
+	reg.LinkTemplate(tmpl)
+	tmpl = template.Must(tmpl.Parse(`{{ define "leaf" }}{{ . }}{{ end }}{{ define "node" }}{{ include "leaf" . }}{{ end }}{{ include "node" . }}`))
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf strings.Builder
+			assert.NoError(t, tmpl.Execute(&buf, i), "Concurrent renders should not add up their depth")
+			assert.Equal(t, strconv.Itoa(i), buf.String())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTplReusesTemplateSets(t *testing.T) {
+	reg := templating.NewRegistry()
+	handler := sprout.New(sprout.WithRegistries(reg))
+	tmpl := template.New("test").Funcs(handler.Build())
+	reg.LinkTemplate(tmpl)
+	tmpl = template.Must(tmpl.Parse(`{{ define "name" }}{{ .name }}{{ end }}{{ tpl .text . }}`))
+
+	render := func(text string) (string, error) {
+		var buf strings.Builder
+		err := tmpl.Execute(&buf, map[string]any{"name": "Ada", "text": text})
+		return buf.String(), err
+	}
+
+	out, err := render(`{{ define "local" }}local{{ end }}{{ template "local" }} {{ template "name" . }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "local Ada", out)
+
+	_, err = render(`{{ template "local" }}`)
+	require.ErrorContains(t, err, `template "local" not defined`, "Templates defined by a tpl text should not leak into the next ones")
+
+	template.Must(tmpl.New("added").Parse(`added {{ .name }}`))
+	out, err = render(`{{ template "added" . }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "added Ada", out, "Templates added to the linked set should be available")
+}
+
+func TestConfigure(t *testing.T) {
+	reg := templating.NewRegistry()
+	require.NoError(t, reg.Configure(map[string]any{}))
+	require.NoError(t, reg.Configure(map[string]any{"maxDepth": 2}))
+
+	out, err := runLinkedTemplate(t, reg, `{{ define "once" }}{{ if . }}again {{ include "once" false }}{{ else }}done{{ end }}{{ end }}{{ include "once" true }}`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "again done", out)
+
+	_, err = runLinkedTemplate(t, reg, `{{ define "loop" }}{{ include "loop" . }}{{ end }}{{ include "loop" . }}`, nil)
+	require.ErrorContains(t, err, `more than 2 nested include calls`)
+
+	require.ErrorContains(t, reg.Configure(map[string]any{"maxDepth": "many"}), "maxDepth must be a positive integer, got many")
+	require.ErrorContains(t, reg.Configure(map[string]any{"maxDepth": 0}), "maxDepth must be a positive integer, got 0")
+}
@@ -0,0 +1,73 @@
+package templating
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// tplSet is a template set the texts of `tpl` are parsed into, holding the
+// functions of the handler and a copy of the linked template set, if any.
+type tplSet struct {
+	template *template.Template
+	size     int        // number of templates of the linked set when copied
+	pool     *sync.Pool // pool the set is given back to
+}
+
+// linkedTemplate returns the template set linked to the registry, or nil.
+func (tr *TemplatingRegistry) linkedTemplate() *template.Template {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.template
+}
+
+// checkDepth returns an error when depth, the number of nested calls of the
+// function fn, exceeds the maximum depth.
+func (tr *TemplatingRegistry) checkDepth(fn string, depth int) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if depth > tr.maxDepth {
+		return fmt.Errorf("%w: more than %d nested %s calls", ErrMaxDepthExceeded, tr.maxDepth, fn)
+	}
+	return nil
+}
+
+// acquireTplSet returns a template set to parse a text of `tpl` into. Sets are
+// reused across calls, a set copied from the linked template set before
+// templates were added to it is dropped.
+func (tr *TemplatingRegistry) acquireTplSet() (*tplSet, error) {
+	tr.mu.Lock()
+	linked, pool := tr.template, tr.tplSets
+	tr.mu.Unlock()
+
+	if linked == nil {
+		if set, ok := pool.Get().(*tplSet); ok {
+			return set, nil
+		}
+		return &tplSet{template: template.New(tplTemplateName).Funcs(tr.handler.Build()), pool: pool}, nil
+	}
+
+	size := len(linked.Templates())
+	if set, ok := pool.Get().(*tplSet); ok && set.size == size {
+		return set, nil
+	}
+
+	clone, err := linked.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("cannot clone linked template: %w", err)
+	}
+	return &tplSet{template: clone, size: size, pool: pool}, nil
+}
+
+// releaseTplSet gives back a set once the text of `tpl` parsed into it is
+// rendered. A set the text defined templates in is not reused, these
+// templates must not leak into the next texts.
+func (tr *TemplatingRegistry) releaseTplSet(set *tplSet, text string) {
+	if strings.Contains(text, "define") || strings.Contains(text, "block") {
+		return
+	}
+	set.pool.Put(set)
+}
@@ -0,0 +1,112 @@
+// Package templating provides the Helm-style `include` and `tpl` functions,
+// rendering a named template or a string as a template with the functions of
+// the handler.
+//
+// The `include` function needs to know the template set to render from, link
+// it with [TemplatingRegistry.LinkTemplate] before parsing your templates. A
+// registry instance is linked to one template set at a time.
+package templating
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/spf13/cast"
+
+	"github.com/go-sprout/sprout"
+)
+
+// DefaultMaxDepth is the default maximum number of nested `include` calls, and
+// of nested `tpl` calls, protecting against infinite recursion.
+const DefaultMaxDepth = 100
+
+var (
+	// ErrNoTemplateLinked is returned by `include` when no template is linked
+	// to the registry.
+	ErrNoTemplateLinked = errors.New("no template linked to the registry, use LinkTemplate")
+	// ErrMaxDepthExceeded is returned when the maximum number of nested
+	// `include` calls, or of nested `tpl` calls, is exceeded.
+	ErrMaxDepthExceeded = errors.New("maximum template nesting depth exceeded")
+)
+
+type TemplatingRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+
+	mu       sync.Mutex
+	template *template.Template
+	tplSets  *sync.Pool // template sets reused by tpl, see acquireTplSet
+	maxDepth int
+}
+
+// NewRegistry creates a new instance of templating registry.
+func NewRegistry() *TemplatingRegistry {
+	return &TemplatingRegistry{
+		tplSets:  new(sync.Pool),
+		maxDepth: DefaultMaxDepth,
+	}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (tr *TemplatingRegistry) UID() string {
+	return "go-sprout/sprout.templating"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (tr *TemplatingRegistry) LinkHandler(fh sprout.Handler) error {
+	tr.handler = fh
+	return nil
+}
+
+// LinkTemplate links the template set rendered by `include` to the registry.
+// Every template associated with tmpl, even parsed after this call, can be
+// included.
+//
+// `tpl` renders its text with a copy of the template set, made on its first
+// call and reused by the next ones. The copy is made again when templates are
+// added to the set, but not when a template is redefined.
+//
+// Example:
+//
+//	reg := templating.NewRegistry()
+//	handler := sprout.New(sprout.WithRegistries(reg))
+//
+//	tmpl := template.New("base").Funcs(handler.Build())
+//	reg.LinkTemplate(tmpl)
+//	tmpl = template.Must(tmpl.ParseGlob("*.tmpl"))
+func (tr *TemplatingRegistry) LinkTemplate(tmpl *template.Template) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.template = tmpl
+	tr.tplSets = new(sync.Pool)
+}
+
+// Configure applies the options of the registry, it accepts the `maxDepth`
+// option to change the maximum number of nested `include` calls, and of
+// nested `tpl` calls.
+func (tr *TemplatingRegistry) Configure(options map[string]any) error {
+	if value, ok := options["maxDepth"]; ok {
+		maxDepth, err := cast.ToIntE(value)
+		if err != nil || maxDepth <= 0 {
+			return fmt.Errorf("maxDepth must be a positive integer, got %v", value)
+		}
+
+		tr.mu.Lock()
+		tr.maxDepth = maxDepth
+		tr.mu.Unlock()
+	}
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (tr *TemplatingRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "include", tr.Include)
+	sprout.AddFunction(funcsMap, "tpl", tr.Tpl)
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/spf13/cast"
+
+	"github.com/go-sprout/sprout/internal/helpers"
 )
 
 // Date formats a given date or current time into a specified format string.
@@ -397,3 +399,68 @@ func (tr *TimeRegistry) HtmlDateInZone(date any, zone string) (string, error) {
 	// TODO: Change signature
 	return tr.DateInZone("2006-01-02", date, zone)
 }
+
+// DateFormatLocale formats a date in the language of a locale, with a CLDR
+// date style ("full", "long", "medium" or "short"), a CLDR pattern like
+// "EEEE d MMMM y", or a strftime layout like "%d %B %Y". The languages with
+// locale data are de, en, es, fr, it, ja, nl, pt and zh.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "fr-FR" or "en".
+//	format string - the date style, the CLDR pattern or the strftime layout.
+//	date any - the date to format, or the current time if not a date type.
+//
+// Returns:
+//
+//	string - the formatted date.
+//	error - an error if the locale is invalid or unsupported, or the format is invalid.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: dateFormatLocale].
+//
+// [Sprout Documentation: dateFormatLocale]: https://docs.atom.codes/sprout/registries/time#dateformatlocale
+func (tr *TimeRegistry) DateFormatLocale(locale string, format string, date any) (string, error) {
+	tag, err := helpers.ParseLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	names, err := calendarFor(tag)
+	if err != nil {
+		return "", err
+	}
+	t := computeTimeFromFormat(date)
+
+	if pattern, ok := names.dateFormats[format]; ok {
+		return formatPattern(t, pattern, names)
+	}
+	if strings.Contains(format, "%") {
+		return formatStrftime(t, format, names)
+	}
+	return formatPattern(t, format, names)
+}
+
+// RelativeTime describes how far a date is from now in the language of a
+// locale, like "3 days ago" or "in 2 hours", with the largest unit reached
+// from seconds to years, rounded to the nearest whole number. The languages
+// with locale data are the ones of DateFormatLocale.
+//
+// Parameters:
+//
+//	locale string - the BCP 47 locale, like "fr-FR" or "en".
+//	date any - the date to describe.
+//
+// Returns:
+//
+//	string - the relative time.
+//	error - an error if the locale is invalid or unsupported.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: relativeTime].
+//
+// [Sprout Documentation: relativeTime]: https://docs.atom.codes/sprout/registries/time#relativetime
+func (tr *TimeRegistry) RelativeTime(locale string, date any) (string, error) {
+	tag, err := helpers.ParseLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return formatRelative(tag, time.Until(computeTimeFromFormat(date)))
+}
@@ -286,3 +286,46 @@ func TestHtmlDateInZone(t *testing.T) {
 
 	pesticide.RunTestCases(t, rtime.NewRegistry(), tc)
 }
+
+func TestDateFormatLocale(t *testing.T) {
+	timeTest := time.Date(2024, 5, 7, 15, 4, 5, 0, time.UTC)
+
+	tc := []pesticide.TestCase{
+		{Name: "TestFullStyle", Input: `{{ .V | dateFormatLocale "fr-FR" "full" }}`, ExpectedOutput: "mardi 7 mai 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestLongStyle", Input: `{{ .V | dateFormatLocale "fr-FR" "long" }}`, ExpectedOutput: "7 mai 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestMediumStyle", Input: `{{ .V | dateFormatLocale "en-US" "medium" }}`, ExpectedOutput: "May 7, 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestShortStyle", Input: `{{ .V | dateFormatLocale "de" "short" }}`, ExpectedOutput: "07.05.24", Data: map[string]any{"V": timeTest}},
+		{Name: "TestRegionalStyle", Input: `{{ .V | dateFormatLocale "en-GB" "long" }}`, ExpectedOutput: "7 May 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestUnderscoreLocale", Input: `{{ .V | dateFormatLocale "es_ES" "long" }}`, ExpectedOutput: "7 de mayo de 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestUnsupportedLanguage", Input: `{{ .V | dateFormatLocale "sw" "long" }}`, ExpectedErr: `unsupported locale "sw"`, Data: map[string]any{"V": timeTest}},
+		{Name: "TestStandaloneMonth", Input: `{{ .V | dateFormatLocale "de" "LLL y, d. MMM" }}`, ExpectedOutput: "Mär 2024, 7. März", Data: map[string]any{"V": timeTest.AddDate(0, -2, 0)}},
+		{Name: "TestPattern", Input: `{{ .V | dateFormatLocale "en" "yyyy-MM-dd HH:mm:ss" }}`, ExpectedOutput: "2024-05-07 15:04:05", Data: map[string]any{"V": timeTest}},
+		{Name: "TestPatternNames", Input: `{{ .V | dateFormatLocale "it" "EEEE d MMMM" }}`, ExpectedOutput: "martedì 7 maggio", Data: map[string]any{"V": timeTest}},
+		{Name: "TestPatternQuotes", Input: `{{ .V | dateFormatLocale "en" "h 'o''clock' a" }}`, ExpectedOutput: "3 o'clock PM", Data: map[string]any{"V": timeTest}},
+		{Name: "TestStrftime", Input: `{{ .V | dateFormatLocale "fr" "%A %d %B %Y" }}`, ExpectedOutput: "mardi 07 mai 2024", Data: map[string]any{"V": timeTest}},
+		{Name: "TestTimeObjectPointer", Input: `{{ .V | dateFormatLocale "en" "yyyy-MM-dd" }}`, ExpectedOutput: "2024-05-07", Data: map[string]any{"V": &timeTest}},
+		{Name: "TestTimeObjectUnix", Input: `{{ .V | dateFormatLocale "en" "yyyy-MM-dd" }}`, ExpectedOutput: timeTest.Local().Format("2006-01-02"), Data: map[string]any{"V": timeTest.Unix()}},
+		{Name: "TestInvalidLocale", Input: `{{ .V | dateFormatLocale "not a locale" "long" }}`, ExpectedErr: "invalid locale", Data: map[string]any{"V": timeTest}},
+		{Name: "TestUnsupportedField", Input: `{{ .V | dateFormatLocale "en" "yyyy-QQ" }}`, ExpectedErr: "unsupported field", Data: map[string]any{"V": timeTest}},
+		{Name: "TestUnsupportedDirective", Input: `{{ .V | dateFormatLocale "en" "%Y-%Q" }}`, ExpectedErr: "unsupported directive", Data: map[string]any{"V": timeTest}},
+	}
+
+	pesticide.RunTestCases(t, rtime.NewRegistry(), tc)
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Now()
+	twoYearsAgo := now.AddDate(-2, 0, 0)
+
+	tc := []pesticide.TestCase{
+		{Name: "TestNow", Input: `{{ .V | relativeTime "en" }}`, ExpectedOutput: "now", Data: map[string]any{"V": now}},
+		{Name: "TestPastFrench", Input: `{{ .V | relativeTime "fr-FR" }}`, ExpectedOutput: "il y a 3 jours", Data: map[string]any{"V": now.Add(-3 * 24 * time.Hour)}},
+		{Name: "TestFutureEnglish", Input: `{{ .V | relativeTime "en" }}`, ExpectedOutput: "in 2 hours", Data: map[string]any{"V": now.Add(2*time.Hour + time.Minute)}},
+		{Name: "TestSingular", Input: `{{ .V | relativeTime "de" }}`, ExpectedOutput: "vor 1 Woche", Data: map[string]any{"V": now.Add(-7 * 24 * time.Hour)}},
+		{Name: "TestTimeObjectPointer", Input: `{{ .V | relativeTime "es" }}`, ExpectedOutput: "hace 2 años", Data: map[string]any{"V": &twoYearsAgo}},
+		{Name: "TestInvalidLocale", Input: `{{ .V | relativeTime "not a locale" }}`, ExpectedErr: "invalid locale", Data: map[string]any{"V": now}},
+		{Name: "TestUnsupportedLanguage", Input: `{{ .V | relativeTime "sw" }}`, ExpectedErr: `unsupported locale "sw"`, Data: map[string]any{"V": now}},
+	}
+
+	pesticide.RunTestCases(t, rtime.NewRegistry(), tc)
+}
@@ -0,0 +1,319 @@
+package time
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"github.com/go-sprout/sprout/internal/helpers"
+)
+
+// The tables below are extracted from the Unicode CLDR, for the Gregorian
+// calendar. Unlisted languages are not supported.
+
+// calendarNames holds the names used to format dates in a language, and its
+// CLDR date formats by style.
+type calendarNames struct {
+	months     [12]string
+	monthsAbbr [12]string
+	// The stand-alone month names, used without a day like in "LLLL y", when
+	// they differ from the format ones.
+	standaloneMonths     *[12]string
+	standaloneMonthsAbbr *[12]string
+	days                 [7]string // from Sunday
+	daysAbbr             [7]string // from Sunday
+	dayPeriods           [2]string // AM and PM
+	dateFormats          map[string]string
+}
+
+// standaloneMonth returns the stand-alone name of a month, abbreviated or
+// not, which is the format name in most languages.
+func (names *calendarNames) standaloneMonth(month time.Month, abbreviated bool) string {
+	switch {
+	case abbreviated && names.standaloneMonthsAbbr != nil:
+		return names.standaloneMonthsAbbr[month-1]
+	case abbreviated:
+		return names.monthsAbbr[month-1]
+	case names.standaloneMonths != nil:
+		return names.standaloneMonths[month-1]
+	default:
+		return names.months[month-1]
+	}
+}
+
+// relativeUnit holds the CLDR patterns of a unit of relative time: in the
+// future for one and other, then in the past for one and other. {0} is
+// replaced by the number of units.
+type relativeUnit [4]string
+
+// relativeNames holds the CLDR data to format relative times in a language.
+type relativeNames struct {
+	now   string
+	units [7]relativeUnit // second, minute, hour, day, week, month, year
+}
+
+// relativeUnitSizes holds the sizes of the units of relativeNames; a month
+// is 30 days and a year 365 days.
+var relativeUnitSizes = [7]time.Duration{
+	time.Second,
+	time.Minute,
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	365 * 24 * time.Hour,
+}
+
+var calendars = map[string]*calendarNames{
+	"de": {
+		months:               [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr:           [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		standaloneMonthsAbbr: &[12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		days:                 [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		daysAbbr:             [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		dayPeriods:           [2]string{"AM", "PM"},
+		dateFormats: map[string]string{
+			"full": "EEEE, d. MMMM y", "long": "d. MMMM y", "medium": "dd.MM.y", "short": "dd.MM.yy",
+		},
+	},
+	"en": {
+		months:     [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsAbbr: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		daysAbbr:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		dayPeriods: [2]string{"AM", "PM"},
+		dateFormats: map[string]string{
+			"full": "EEEE, MMMM d, y", "long": "MMMM d, y", "medium": "MMM d, y", "short": "M/d/yy",
+		},
+	},
+	"es": {
+		months:     [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		daysAbbr:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		dayPeriods: [2]string{"a. m.", "p. m."},
+		dateFormats: map[string]string{
+			"full": "EEEE, d 'de' MMMM 'de' y", "long": "d 'de' MMMM 'de' y", "medium": "d MMM y", "short": "d/M/yy",
+		},
+	},
+	"fr": {
+		months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		daysAbbr:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		dayPeriods: [2]string{"AM", "PM"},
+		dateFormats: map[string]string{
+			"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd/MM/y",
+		},
+	},
+	"it": {
+		months:     [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:       [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		daysAbbr:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		dayPeriods: [2]string{"AM", "PM"},
+		dateFormats: map[string]string{
+			"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd/MM/yy",
+		},
+	},
+	"ja": {
+		months:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		monthsAbbr: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		days:       [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		daysAbbr:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		dayPeriods: [2]string{"午前", "午後"},
+		dateFormats: map[string]string{
+			"full": "y年M月d日EEEE", "long": "y年M月d日", "medium": "y/MM/dd", "short": "y/MM/dd",
+		},
+	},
+	"nl": {
+		months:     [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		monthsAbbr: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:       [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		daysAbbr:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		dayPeriods: [2]string{"a.m.", "p.m."},
+		dateFormats: map[string]string{
+			"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd-MM-y",
+		},
+	},
+	"pt": {
+		months:     [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		days:       [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		daysAbbr:   [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		dayPeriods: [2]string{"AM", "PM"},
+		dateFormats: map[string]string{
+			"full": "EEEE, d 'de' MMMM 'de' y", "long": "d 'de' MMMM 'de' y", "medium": "d 'de' MMM 'de' y", "short": "dd/MM/y",
+		},
+	},
+	"zh": {
+		months:     [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		monthsAbbr: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		days:       [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		daysAbbr:   [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		dayPeriods: [2]string{"上午", "下午"},
+		dateFormats: map[string]string{
+			"full": "y年M月d日EEEE", "long": "y年M月d日", "medium": "y年M月d日", "short": "y/M/d",
+		},
+	},
+}
+
+// calendarsByRegion holds the regional variants of calendars, which only
+// differ by their date formats.
+var calendarsByRegion = map[string]struct {
+	language    string
+	dateFormats map[string]string
+}{
+	"en-GB": {"en", map[string]string{"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd/MM/y"}},
+	"en-AU": {"en", map[string]string{"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "d/M/yy"}},
+	"en-CA": {"en", map[string]string{"full": "EEEE, MMMM d, y", "long": "MMMM d, y", "medium": "MMM d, y", "short": "y-MM-dd"}},
+	"en-IN": {"en", map[string]string{"full": "EEEE, d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd/MM/yy"}},
+	"fr-CA": {"fr", map[string]string{"full": "EEEE d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "y-MM-dd"}},
+	"fr-CH": {"fr", map[string]string{"full": "EEEE, d MMMM y", "long": "d MMMM y", "medium": "d MMM y", "short": "dd.MM.yy"}},
+}
+
+var relativeTimes = map[string]*relativeNames{
+	"de": {now: "jetzt", units: [7]relativeUnit{
+		{"in {0} Sekunde", "in {0} Sekunden", "vor {0} Sekunde", "vor {0} Sekunden"},
+		{"in {0} Minute", "in {0} Minuten", "vor {0} Minute", "vor {0} Minuten"},
+		{"in {0} Stunde", "in {0} Stunden", "vor {0} Stunde", "vor {0} Stunden"},
+		{"in {0} Tag", "in {0} Tagen", "vor {0} Tag", "vor {0} Tagen"},
+		{"in {0} Woche", "in {0} Wochen", "vor {0} Woche", "vor {0} Wochen"},
+		{"in {0} Monat", "in {0} Monaten", "vor {0} Monat", "vor {0} Monaten"},
+		{"in {0} Jahr", "in {0} Jahren", "vor {0} Jahr", "vor {0} Jahren"},
+	}},
+	"en": {now: "now", units: [7]relativeUnit{
+		{"in {0} second", "in {0} seconds", "{0} second ago", "{0} seconds ago"},
+		{"in {0} minute", "in {0} minutes", "{0} minute ago", "{0} minutes ago"},
+		{"in {0} hour", "in {0} hours", "{0} hour ago", "{0} hours ago"},
+		{"in {0} day", "in {0} days", "{0} day ago", "{0} days ago"},
+		{"in {0} week", "in {0} weeks", "{0} week ago", "{0} weeks ago"},
+		{"in {0} month", "in {0} months", "{0} month ago", "{0} months ago"},
+		{"in {0} year", "in {0} years", "{0} year ago", "{0} years ago"},
+	}},
+	"es": {now: "ahora", units: [7]relativeUnit{
+		{"dentro de {0} segundo", "dentro de {0} segundos", "hace {0} segundo", "hace {0} segundos"},
+		{"dentro de {0} minuto", "dentro de {0} minutos", "hace {0} minuto", "hace {0} minutos"},
+		{"dentro de {0} hora", "dentro de {0} horas", "hace {0} hora", "hace {0} horas"},
+		{"dentro de {0} día", "dentro de {0} días", "hace {0} día", "hace {0} días"},
+		{"dentro de {0} semana", "dentro de {0} semanas", "hace {0} semana", "hace {0} semanas"},
+		{"dentro de {0} mes", "dentro de {0} meses", "hace {0} mes", "hace {0} meses"},
+		{"dentro de {0} año", "dentro de {0} años", "hace {0} año", "hace {0} años"},
+	}},
+	"fr": {now: "maintenant", units: [7]relativeUnit{
+		{"dans {0} seconde", "dans {0} secondes", "il y a {0} seconde", "il y a {0} secondes"},
+		{"dans {0} minute", "dans {0} minutes", "il y a {0} minute", "il y a {0} minutes"},
+		{"dans {0} heure", "dans {0} heures", "il y a {0} heure", "il y a {0} heures"},
+		{"dans {0} jour", "dans {0} jours", "il y a {0} jour", "il y a {0} jours"},
+		{"dans {0} semaine", "dans {0} semaines", "il y a {0} semaine", "il y a {0} semaines"},
+		{"dans {0} mois", "dans {0} mois", "il y a {0} mois", "il y a {0} mois"},
+		{"dans {0} an", "dans {0} ans", "il y a {0} an", "il y a {0} ans"},
+	}},
+	"it": {now: "ora", units: [7]relativeUnit{
+		{"tra {0} secondo", "tra {0} secondi", "{0} secondo fa", "{0} secondi fa"},
+		{"tra {0} minuto", "tra {0} minuti", "{0} minuto fa", "{0} minuti fa"},
+		{"tra {0} ora", "tra {0} ore", "{0} ora fa", "{0} ore fa"},
+		{"tra {0} giorno", "tra {0} giorni", "{0} giorno fa", "{0} giorni fa"},
+		{"tra {0} settimana", "tra {0} settimane", "{0} settimana fa", "{0} settimane fa"},
+		{"tra {0} mese", "tra {0} mesi", "{0} mese fa", "{0} mesi fa"},
+		{"tra {0} anno", "tra {0} anni", "{0} anno fa", "{0} anni fa"},
+	}},
+	"ja": {now: "今", units: [7]relativeUnit{
+		{"{0} 秒後", "{0} 秒後", "{0} 秒前", "{0} 秒前"},
+		{"{0} 分後", "{0} 分後", "{0} 分前", "{0} 分前"},
+		{"{0} 時間後", "{0} 時間後", "{0} 時間前", "{0} 時間前"},
+		{"{0} 日後", "{0} 日後", "{0} 日前", "{0} 日前"},
+		{"{0} 週間後", "{0} 週間後", "{0} 週間前", "{0} 週間前"},
+		{"{0} か月後", "{0} か月後", "{0} か月前", "{0} か月前"},
+		{"{0} 年後", "{0} 年後", "{0} 年前", "{0} 年前"},
+	}},
+	"nl": {now: "nu", units: [7]relativeUnit{
+		{"over {0} seconde", "over {0} seconden", "{0} seconde geleden", "{0} seconden geleden"},
+		{"over {0} minuut", "over {0} minuten", "{0} minuut geleden", "{0} minuten geleden"},
+		{"over {0} uur", "over {0} uur", "{0} uur geleden", "{0} uur geleden"},
+		{"over {0} dag", "over {0} dagen", "{0} dag geleden", "{0} dagen geleden"},
+		{"over {0} week", "over {0} weken", "{0} week geleden", "{0} weken geleden"},
+		{"over {0} maand", "over {0} maanden", "{0} maand geleden", "{0} maanden geleden"},
+		{"over {0} jaar", "over {0} jaar", "{0} jaar geleden", "{0} jaar geleden"},
+	}},
+	"pt": {now: "agora", units: [7]relativeUnit{
+		{"em {0} segundo", "em {0} segundos", "há {0} segundo", "há {0} segundos"},
+		{"em {0} minuto", "em {0} minutos", "há {0} minuto", "há {0} minutos"},
+		{"em {0} hora", "em {0} horas", "há {0} hora", "há {0} horas"},
+		{"em {0} dia", "em {0} dias", "há {0} dia", "há {0} dias"},
+		{"em {0} semana", "em {0} semanas", "há {0} semana", "há {0} semanas"},
+		{"em {0} mês", "em {0} meses", "há {0} mês", "há {0} meses"},
+		{"em {0} ano", "em {0} anos", "há {0} ano", "há {0} anos"},
+	}},
+	"zh": {now: "现在", units: [7]relativeUnit{
+		{"{0}秒钟后", "{0}秒钟后", "{0}秒钟前", "{0}秒钟前"},
+		{"{0}分钟后", "{0}分钟后", "{0}分钟前", "{0}分钟前"},
+		{"{0}小时后", "{0}小时后", "{0}小时前", "{0}小时前"},
+		{"{0}天后", "{0}天后", "{0}天前", "{0}天前"},
+		{"{0}周后", "{0}周后", "{0}周前", "{0}周前"},
+		{"{0}个月后", "{0}个月后", "{0}个月前", "{0}个月前"},
+		{"{0}年后", "{0}年后", "{0}年前", "{0}年前"},
+	}},
+}
+
+// errUnsupportedLocale returns the error of a locale whose language has no
+// data in the tables.
+func errUnsupportedLocale(locale language.Tag) error {
+	return fmt.Errorf("unsupported locale %q, supported languages are %s", locale, strings.Join(slices.Sorted(maps.Keys(calendars)), ", "))
+}
+
+// calendarFor returns the calendar names of a locale, with the date formats
+// of its region when they differ from the ones of its language.
+func calendarFor(locale language.Tag) (*calendarNames, error) {
+	if variant, ok := helpers.LookupLocale(calendarsByRegion, locale); ok {
+		names := *calendars[variant.language]
+		names.dateFormats = variant.dateFormats
+		return &names, nil
+	}
+	if names, ok := helpers.LookupLocale(calendars, locale); ok {
+		return names, nil
+	}
+	return nil, errUnsupportedLocale(locale)
+}
+
+// formatRelative formats a duration from now, positive in the future, in the
+// largest unit it reaches, rounded to the nearest whole number of units.
+func formatRelative(locale language.Tag, duration time.Duration) (string, error) {
+	names, ok := helpers.LookupLocale(relativeTimes, locale)
+	if !ok {
+		return "", errUnsupportedLocale(locale)
+	}
+
+	abs := duration.Abs()
+	if abs < time.Second/2 {
+		return names.now, nil
+	}
+
+	unit := 0
+	for i, size := range relativeUnitSizes {
+		if abs >= size {
+			unit = i
+		}
+	}
+	count := int((abs + relativeUnitSizes[unit]/2) / relativeUnitSizes[unit])
+	// Rounding may reach the next unit, like 59.5 minutes.
+	if next := unit + 1; next < len(relativeUnitSizes) && time.Duration(count)*relativeUnitSizes[unit] >= relativeUnitSizes[next] {
+		unit = next
+		count = int((abs + relativeUnitSizes[unit]/2) / relativeUnitSizes[unit])
+	}
+
+	index := 1
+	if plural.Cardinal.MatchPlural(locale, count, 0, 0, 0, 0) == plural.One {
+		index = 0
+	}
+	if duration < 0 {
+		index += 2
+	}
+	return strings.ReplaceAll(names.units[unit][index], "{0}", strconv.Itoa(count)), nil
+}
@@ -0,0 +1,72 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestCalendarFor(t *testing.T) {
+	calendar := func(locale string) *calendarNames {
+		names, err := calendarFor(language.MustParse(locale))
+		require.NoError(t, err, locale)
+		return names
+	}
+
+	assert.Equal(t, "mai", calendar("fr-FR").months[4])
+	assert.Equal(t, "y-MM-dd", calendar("fr-CA").dateFormats["short"])
+	assert.Equal(t, "dd/MM/y", calendar("fr").dateFormats["short"], "regional formats must not change the language ones")
+	assert.Equal(t, "Januar", calendar("de-AT").months[0])
+
+	_, err := calendarFor(language.Swahili)
+	require.EqualError(t, err, `unsupported locale "sw", supported languages are de, en, es, fr, it, ja, nl, pt, zh`)
+}
+
+func TestStandaloneMonth(t *testing.T) {
+	de, en := calendars["de"], calendars["en"]
+
+	assert.Equal(t, "Mär", de.standaloneMonth(time.March, true))
+	assert.Equal(t, "März", de.monthsAbbr[time.March-1])
+	assert.Equal(t, "März", de.standaloneMonth(time.March, false))
+	assert.Equal(t, "Sep", en.standaloneMonth(time.September, true))
+	assert.Equal(t, "September", en.standaloneMonth(time.September, false))
+}
+
+func TestFormatRelative(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		locale   string
+		duration time.Duration
+		want     string
+	}{
+		{name: "now", locale: "en", duration: 400 * time.Millisecond, want: "now"},
+		{name: "now in French", locale: "fr", duration: -400 * time.Millisecond, want: "maintenant"},
+		{name: "seconds", locale: "en", duration: 45 * time.Second, want: "in 45 seconds"},
+		{name: "one minute", locale: "en", duration: -time.Minute, want: "1 minute ago"},
+		{name: "rounded down", locale: "en", duration: 2*time.Hour + 29*time.Minute, want: "in 2 hours"},
+		{name: "rounded up", locale: "en", duration: -(2*time.Hour + 30*time.Minute), want: "3 hours ago"},
+		{name: "rounded to next unit", locale: "en", duration: 59*time.Minute + 40*time.Second, want: "in 1 hour"},
+		{name: "days", locale: "fr", duration: -3 * day, want: "il y a 3 jours"},
+		{name: "weeks", locale: "es", duration: 14 * day, want: "dentro de 2 semanas"},
+		{name: "months", locale: "pt-BR", duration: -60 * day, want: "há 2 meses"},
+		{name: "years", locale: "nl", duration: 3 * 365 * day, want: "over 3 jaar"},
+		{name: "French one for zero and one", locale: "fr", duration: -day, want: "il y a 1 jour"},
+		{name: "no plural", locale: "ja", duration: -5 * time.Minute, want: "5 分前"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := formatRelative(language.MustParse(test.locale), test.duration)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	_, err := formatRelative(language.Swahili, -2*day)
+	require.ErrorContains(t, err, `unsupported locale "sw"`)
+}
@@ -0,0 +1,268 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// isPatternLetter reports whether b is a field letter of a CLDR pattern.
+func isPatternLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// pad formats a number with at least width digits.
+func pad(n, width int) string {
+	s := strconv.Itoa(n)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// narrow returns the first character of a name, its CLDR narrow form.
+func narrow(name string) string {
+	_, size := utf8.DecodeRuneInString(name)
+	return name[:size]
+}
+
+// zoneOffset formats the offset of the time zone of t as +hhmm, or +hh:mm
+// with a colon.
+func zoneOffset(t time.Time, colon bool) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	separator := ""
+	if colon {
+		separator = ":"
+	}
+	return fmt.Sprintf("%c%02d%s%02d", sign, offset/3600, separator, offset/60%60)
+}
+
+// hour12 returns the hour of t on a 12-hour clock, from 1 to 12.
+func hour12(t time.Time) int {
+	if h := t.Hour() % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+// dayPeriod returns the AM or PM name of t.
+func dayPeriod(t time.Time, names *calendarNames) string {
+	if t.Hour() < 12 {
+		return names.dayPeriods[0]
+	}
+	return names.dayPeriods[1]
+}
+
+// formatPattern formats a time with a CLDR date pattern, like "yyyy-MM-dd"
+// or "EEEE d MMMM y", using the names of the given calendar. Text between
+// single quotes is written as is, and two single quotes write one.
+//
+// Parameters:
+//
+//	t time.Time - the time to format.
+//	pattern string - the CLDR pattern.
+//	names *calendarNames - the names of the months, days and day periods.
+//
+// Returns:
+//
+//	string - the formatted time.
+//	error - an error if the pattern has an unsupported field or an unterminated quote.
+func formatPattern(t time.Time, pattern string, names *calendarNames) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i += 2
+				continue
+			}
+			for i++; ; i++ {
+				if i == len(pattern) {
+					return "", fmt.Errorf("unterminated quote in pattern %q", pattern)
+				}
+				if pattern[i] != '\'' {
+					sb.WriteByte(pattern[i])
+					continue
+				}
+				if i+1 < len(pattern) && pattern[i+1] == '\'' {
+					sb.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			i++
+			continue
+		}
+		if !isPatternLetter(c) {
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(pattern) && pattern[i+count] == c {
+			count++
+		}
+		i += count
+
+		switch c {
+		case 'y':
+			if count == 2 {
+				sb.WriteString(pad(t.Year()%100, 2))
+			} else {
+				sb.WriteString(pad(t.Year(), count))
+			}
+		case 'M':
+			switch count {
+			case 1, 2:
+				sb.WriteString(pad(int(t.Month()), count))
+			case 3:
+				sb.WriteString(names.monthsAbbr[t.Month()-1])
+			case 4:
+				sb.WriteString(names.months[t.Month()-1])
+			default:
+				sb.WriteString(narrow(names.months[t.Month()-1]))
+			}
+		case 'L':
+			switch count {
+			case 1, 2:
+				sb.WriteString(pad(int(t.Month()), count))
+			case 3:
+				sb.WriteString(names.standaloneMonth(t.Month(), true))
+			case 4:
+				sb.WriteString(names.standaloneMonth(t.Month(), false))
+			default:
+				sb.WriteString(narrow(names.standaloneMonth(t.Month(), false)))
+			}
+		case 'd':
+			sb.WriteString(pad(t.Day(), count))
+		case 'D':
+			sb.WriteString(pad(t.YearDay(), count))
+		case 'E':
+			switch count {
+			case 1, 2, 3:
+				sb.WriteString(names.daysAbbr[t.Weekday()])
+			case 4:
+				sb.WriteString(names.days[t.Weekday()])
+			default:
+				sb.WriteString(narrow(names.days[t.Weekday()]))
+			}
+		case 'a':
+			sb.WriteString(dayPeriod(t, names))
+		case 'h':
+			sb.WriteString(pad(hour12(t), count))
+		case 'H':
+			sb.WriteString(pad(t.Hour(), count))
+		case 'K':
+			sb.WriteString(pad(t.Hour()%12, count))
+		case 'k':
+			sb.WriteString(pad((t.Hour()+23)%24+1, count))
+		case 'm':
+			sb.WriteString(pad(t.Minute(), count))
+		case 's':
+			sb.WriteString(pad(t.Second(), count))
+		case 'S':
+			sb.WriteString(pad(t.Nanosecond(), 9)[:min(count, 9)])
+		case 'z':
+			name, _ := t.Zone()
+			sb.WriteString(name)
+		case 'Z':
+			sb.WriteString(zoneOffset(t, count == 5))
+		case 'X':
+			if _, offset := t.Zone(); offset == 0 {
+				sb.WriteByte('Z')
+			} else {
+				sb.WriteString(zoneOffset(t, count >= 3))
+			}
+		default:
+			return "", fmt.Errorf("unsupported field %q in pattern %q", strings.Repeat(string(c), count), pattern)
+		}
+	}
+	return sb.String(), nil
+}
+
+// formatStrftime formats a time with a strftime layout, like "%Y-%m-%d",
+// using the names of the given calendar.
+//
+// Parameters:
+//
+//	t time.Time - the time to format.
+//	layout string - the strftime layout.
+//	names *calendarNames - the names of the months, days and day periods.
+//
+// Returns:
+//
+//	string - the formatted time.
+//	error - an error if the layout has an unsupported directive.
+func formatStrftime(t time.Time, layout string, names *calendarNames) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			sb.WriteByte(layout[i])
+			continue
+		}
+		if i+1 == len(layout) {
+			return "", fmt.Errorf("unterminated directive in layout %q", layout)
+		}
+		i++
+
+		switch directive := layout[i]; directive {
+		case 'a':
+			sb.WriteString(names.daysAbbr[t.Weekday()])
+		case 'A':
+			sb.WriteString(names.days[t.Weekday()])
+		case 'b', 'h':
+			sb.WriteString(names.monthsAbbr[t.Month()-1])
+		case 'B':
+			sb.WriteString(names.months[t.Month()-1])
+		case 'd':
+			sb.WriteString(pad(t.Day(), 2))
+		case 'e':
+			fmt.Fprintf(&sb, "%2d", t.Day())
+		case 'F':
+			fmt.Fprintf(&sb, "%s-%s-%s", pad(t.Year(), 4), pad(int(t.Month()), 2), pad(t.Day(), 2))
+		case 'H':
+			sb.WriteString(pad(t.Hour(), 2))
+		case 'I':
+			sb.WriteString(pad(hour12(t), 2))
+		case 'j':
+			sb.WriteString(pad(t.YearDay(), 3))
+		case 'm':
+			sb.WriteString(pad(int(t.Month()), 2))
+		case 'M':
+			sb.WriteString(pad(t.Minute(), 2))
+		case 'p':
+			sb.WriteString(dayPeriod(t, names))
+		case 'S':
+			sb.WriteString(pad(t.Second(), 2))
+		case 'T':
+			fmt.Fprintf(&sb, "%s:%s:%s", pad(t.Hour(), 2), pad(t.Minute(), 2), pad(t.Second(), 2))
+		case 'u':
+			sb.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'w':
+			sb.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'y':
+			sb.WriteString(pad(t.Year()%100, 2))
+		case 'Y':
+			sb.WriteString(strconv.Itoa(t.Year()))
+		case 'z':
+			sb.WriteString(zoneOffset(t, false))
+		case 'Z':
+			name, _ := t.Zone()
+			sb.WriteString(name)
+		case '%':
+			sb.WriteByte('%')
+		default:
+			return "", fmt.Errorf("unsupported directive %%%c in layout %q", directive, layout)
+		}
+	}
+	return sb.String(), nil
+}
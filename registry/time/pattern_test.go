@@ -0,0 +1,99 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPattern(t *testing.T) {
+	zone := time.FixedZone("CEST", 2*60*60)
+	date := time.Date(2024, 5, 7, 9, 4, 5, 123456789, zone)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{name: "year", pattern: "y yy yyyy", want: "2024 24 2024"},
+		{name: "month", pattern: "M MM MMM MMMM MMMMM", want: "5 05 May May M"},
+		{name: "standalone month", pattern: "L LL LLL LLLL LLLLL", want: "5 05 May May M"},
+		{name: "day", pattern: "d dd D", want: "7 07 128"},
+		{name: "weekday", pattern: "E EEEE EEEEE", want: "Tue Tuesday T"},
+		{name: "hours", pattern: "h hh H HH K k a", want: "9 09 9 09 9 9 AM"},
+		{name: "midnight", pattern: "h K k", want: "12 0 24"},
+		{name: "minutes and seconds", pattern: "mm:ss.SSS", want: "04:05.123"},
+		{name: "zone", pattern: "z Z ZZZZZ X XXX", want: "CEST +0200 +02:00 +0200 +02:00"},
+		{name: "quotes", pattern: "'week' d 'of' MMMM", want: "week 7 of May"},
+		{name: "escaped quote", pattern: "HH''mm", want: "09'04"},
+		{name: "literals", pattern: "yyyy-MM-dd'T'HH:mm", want: "2024-05-07T09:04"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := date
+			if test.name == "midnight" {
+				d = time.Date(2024, 5, 7, 0, 0, 0, 0, zone)
+			}
+			got, err := formatPattern(d, test.pattern, calendars["en"])
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("UTC offset", func(t *testing.T) {
+		got, err := formatPattern(date.UTC(), "X", calendars["en"])
+		require.NoError(t, err)
+		assert.Equal(t, "Z", got)
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		_, err := formatPattern(date, "yyyy QQ", calendars["en"])
+		assert.EqualError(t, err, `unsupported field "QQ" in pattern "yyyy QQ"`)
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := formatPattern(date, "d 'of MMMM", calendars["en"])
+		assert.EqualError(t, err, `unterminated quote in pattern "d 'of MMMM"`)
+	})
+}
+
+func TestFormatStrftime(t *testing.T) {
+	zone := time.FixedZone("CEST", 2*60*60)
+	date := time.Date(2024, 5, 7, 21, 4, 5, 0, zone)
+
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{name: "date", layout: "%Y-%m-%d %y %j", want: "2024-05-07 24 128"},
+		{name: "shortcuts", layout: "%F %T", want: "2024-05-07 21:04:05"},
+		{name: "names", layout: "%a %A %b %h %B", want: "mar. mardi mai mai mai"},
+		{name: "hours", layout: "%H %I %p %M %S", want: "21 09 PM 04 05"},
+		{name: "padded day", layout: "%e", want: " 7"},
+		{name: "weekday numbers", layout: "%u %w", want: "2 2"},
+		{name: "zone", layout: "%z %Z", want: "+0200 CEST"},
+		{name: "percent", layout: "100%%", want: "100%"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := formatStrftime(date, test.layout, calendars["fr"])
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("unsupported directive", func(t *testing.T) {
+		_, err := formatStrftime(date, "%Y %Q", calendars["fr"])
+		assert.EqualError(t, err, `unsupported directive %Q in layout "%Y %Q"`)
+	})
+
+	t.Run("unterminated directive", func(t *testing.T) {
+		_, err := formatStrftime(date, "%Y %", calendars["fr"])
+		assert.EqualError(t, err, `unterminated directive in layout "%Y %"`)
+	})
+}
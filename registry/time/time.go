@@ -11,6 +11,10 @@ func NewRegistry() *TimeRegistry {
 	return &TimeRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (tr *TimeRegistry) UID() string {
 	return "go-sprout/sprout.time"
@@ -39,6 +43,8 @@ func (tr *TimeRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
 	sprout.AddFunction(funcsMap, "durationRound", tr.DurationRound)
 	sprout.AddFunction(funcsMap, "htmlDate", tr.HtmlDate)
 	sprout.AddFunction(funcsMap, "htmlDateInZone", tr.HtmlDateInZone)
+	sprout.AddFunction(funcsMap, "dateFormatLocale", tr.DateFormatLocale)
+	sprout.AddFunction(funcsMap, "relativeTime", tr.RelativeTime)
 	return nil
 }
 
@@ -11,6 +11,10 @@ func NewRegistry() *UniqueIDRegistry {
 	return &UniqueIDRegistry{}
 }
 
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
 // UID returns the unique identifier of the registry.
 func (ur *UniqueIDRegistry) UID() string {
 	return "go-sprout/sprout.uniqueid"
@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+
+	"github.com/go-sprout/sprout/internal/helpers"
+)
+
+// Required returns the given value, or fails with the given message when the
+// value is missing, i.e. nil or an empty string. Other zero values, like 0 or
+// false, are considered as provided.
+//
+// Parameters:
+//
+//	message string - the message of the error when the value is missing.
+//	value any - the value to check.
+//
+// Returns:
+//
+//	any - the given value.
+//	error - a *ValidationError when the value is missing.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: required].
+//
+// [Sprout Documentation: required]: https://docs.atom.codes/sprout/registries/validation#required
+func (vr *ValidationRegistry) Required(message string, value any) (any, error) {
+	if value == nil {
+		return nil, newValidationError(value, "%s", message)
+	}
+
+	if str, ok := value.(string); ok && str == "" {
+		return value, newValidationError(value, "%s", message)
+	}
+
+	return value, nil
+}
+
+// Assert fails with the given message when the condition is not truthy, using
+// the same truthiness as the template `if` action.
+//
+// Parameters:
+//
+//	condition any - the condition to check.
+//	message string - the message of the error when the condition is falsy.
+//
+// Returns:
+//
+//	string - always an empty string, so the assertion renders nothing.
+//	error - a *ValidationError when the condition is falsy.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: assert].
+//
+// [Sprout Documentation: assert]: https://docs.atom.codes/sprout/registries/validation#assert
+func (vr *ValidationRegistry) Assert(condition any, message string) (string, error) {
+	if helpers.Empty(condition) {
+		return "", newValidationError(condition, "%s", message)
+	}
+	return "", nil
+}
+
+// Fail unconditionally fails with the given message. The function has the
+// same name as the deprecated `fail` of the backward registry, so it is
+// shadowed when the backward registry is registered first.
+//
+// Parameters:
+//
+//	message string - the message of the error.
+//
+// Returns:
+//
+//	string - always an empty string.
+//	error - always a *ValidationError with the given message.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: fail].
+//
+// [Sprout Documentation: fail]: https://docs.atom.codes/sprout/registries/validation#fail
+func (vr *ValidationRegistry) Fail(message string) (string, error) {
+	return "", newValidationError(nil, "%s", message)
+}
+
+// MustMatch returns the given value, or fails when the value does not match
+// the regular expression.
+//
+// Parameters:
+//
+//	regex string - the regular expression to match.
+//	value string - the value to check.
+//
+// Returns:
+//
+//	string - the given value.
+//	error - a *ValidationError when the value does not match, or an error when
+//	        the regular expression is invalid.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: mustMatch].
+//
+// [Sprout Documentation: mustMatch]: https://docs.atom.codes/sprout/registries/validation#mustmatch
+func (vr *ValidationRegistry) MustMatch(regex string, value string) (string, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return "", fmt.Errorf("invalid regular expression: %w", err)
+	}
+
+	if !re.MatchString(value) {
+		return value, newValidationError(value, "value %q does not match %q", value, regex)
+	}
+	return value, nil
+}
+
+// OneOf returns the given value, or fails when the value is not one of the
+// allowed values. Numbers are compared by value, whatever their type, so
+// numbers decoded from JSON match integers of the list. Other values are
+// compared with deep equality.
+//
+// Parameters:
+//
+//	list any - the list of allowed values.
+//	value any - the value to check.
+//
+// Returns:
+//
+//	any - the given value.
+//	error - a *ValidationError when the value is not allowed, or an error when
+//	        the list is not a slice or an array.
+//
+// For an example of this function in a Go template, refer to [Sprout Documentation: oneOf].
+//
+// [Sprout Documentation: oneOf]: https://docs.atom.codes/sprout/registries/validation#oneof
+func (vr *ValidationRegistry) OneOf(list any, value any) (any, error) {
+	valueOfList := reflect.ValueOf(list)
+	switch valueOfList.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOfList.Len(); i++ {
+			if equalValues(valueOfList.Index(i).Interface(), value) {
+				return value, nil
+			}
+		}
+		return value, newValidationError(value, "value %v is not one of %v", value, list)
+	default:
+		return nil, fmt.Errorf("first argument must be a slice but got %T", list)
+	}
+}
+
+// equalValues reports whether both values are equal, comparing numbers of any
+// type by value and other values with deep equality.
+func equalValues(a, b any) bool {
+	x, okA := numberValue(a)
+	y, okB := numberValue(b)
+	if okA && okB {
+		return x.Cmp(y) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// numberValue returns the exact value of an integer or a finite float.
+func numberValue(value any) (*big.Rat, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if r := new(big.Rat).SetFloat64(rv.Float()); r != nil {
+			return r, true
+		}
+	}
+	return nil, false
+}
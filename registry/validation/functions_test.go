@@ -0,0 +1,103 @@
+package validation_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sprout/sprout"
+	"github.com/go-sprout/sprout/pesticide"
+	"github.com/go-sprout/sprout/registry/encoding"
+	"github.com/go-sprout/sprout/registry/slices"
+	"github.com/go-sprout/sprout/registry/validation"
+)
+
+func TestRequired(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestWithValue", Input: `{{ required "name is required" .name }}`, Data: map[string]any{"name": "Ada"}, ExpectedOutput: "Ada"},
+		{Name: "TestWithPipeline", Input: `{{ .name | required "name is required" }}`, Data: map[string]any{"name": "Ada"}, ExpectedOutput: "Ada"},
+		{Name: "TestWithZeroValue", Input: `{{ required "port is required" .port }}`, Data: map[string]any{"port": 0}, ExpectedOutput: "0"},
+		{Name: "TestWithFalse", Input: `{{ required "enabled is required" .enabled }}`, Data: map[string]any{"enabled": false}, ExpectedOutput: "false"},
+		{Name: "TestWithMissingValue", Input: `{{ required "name is required" .name }}`, ExpectedErr: "name is required"},
+		{Name: "TestWithEmptyString", Input: `{{ required "name is required" .name }}`, Data: map[string]any{"name": ""}, ExpectedErr: "name is required"},
+	}
+
+	pesticide.RunTestCases(t, validation.NewRegistry(), tc)
+}
+
+func TestAssert(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestWithTrue", Input: `{{ assert true "must be true" }}`, ExpectedOutput: ""},
+		{Name: "TestWithTruthyValue", Input: `{{ assert .replicas "replicas must be set" }}`, Data: map[string]any{"replicas": 3}, ExpectedOutput: ""},
+		{Name: "TestWithFalse", Input: `{{ assert false "must be true" }}`, ExpectedErr: "must be true"},
+		{Name: "TestWithNil", Input: `{{ assert .replicas "replicas must be set" }}`, ExpectedErr: "replicas must be set"},
+		{Name: "TestWithComparison", Input: `{{ assert (gt .replicas 5) "replicas must be greater than 5" }}`, Data: map[string]any{"replicas": 3}, ExpectedErr: "replicas must be greater than 5"},
+	}
+
+	pesticide.RunTestCases(t, validation.NewRegistry(), tc)
+}
+
+func TestFail(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestFail", Input: `{{ fail "unsupported mode" }}`, ExpectedErr: "unsupported mode"},
+		{Name: "TestFailInCondition", Input: `{{ if eq .mode "a" }}ok{{ else }}{{ fail "unsupported mode" }}{{ end }}`, Data: map[string]any{"mode": "a"}, ExpectedOutput: "ok"},
+	}
+
+	pesticide.RunTestCases(t, validation.NewRegistry(), tc)
+}
+
+func TestMustMatch(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestWithMatch", Input: `{{ "v1.2.3" | mustMatch "^v[0-9.]+$" }}`, ExpectedOutput: "v1.2.3"},
+		{Name: "TestWithoutMatch", Input: `{{ "latest" | mustMatch "^v[0-9.]+$" }}`, ExpectedErr: `value "latest" does not match "^v[0-9.]+$"`},
+		{Name: "TestWithInvalidRegex", Input: `{{ "latest" | mustMatch "[" }}`, ExpectedErr: "invalid regular expression"},
+	}
+
+	pesticide.RunTestCases(t, validation.NewRegistry(), tc)
+}
+
+func TestOneOf(t *testing.T) {
+	tc := []pesticide.TestCase{
+		{Name: "TestWithAllowedValue", Input: `{{ "prod" | oneOf (list "dev" "prod") }}`, ExpectedOutput: "prod"},
+		{Name: "TestWithAllowedNumber", Input: `{{ oneOf .allowed 2 }}`, Data: map[string]any{"allowed": []int{1, 2, 3}}, ExpectedOutput: "2"},
+		{Name: "TestWithDifferentNumberTypes", Input: `{{ oneOf .allowed 2.0 }}`, Data: map[string]any{"allowed": []uint8{1, 2}}, ExpectedOutput: "2"},
+		{Name: "TestWithDisallowedNumber", Input: `{{ oneOf (list 1 3 5) 2.5 }}`, ExpectedErr: "value 2.5 is not one of [1 3 5]"},
+		{Name: "TestWithNumberAndString", Input: `{{ oneOf (list "3") 3 }}`, ExpectedErr: "value 3 is not one of [3]"},
+		{Name: "TestWithDisallowedValue", Input: `{{ "qa" | oneOf (list "dev" "prod") }}`, ExpectedErr: "value qa is not one of [dev prod]"},
+		{Name: "TestWithInvalidList", Input: `{{ "qa" | oneOf "dev" }}`, ExpectedErr: "first argument must be a slice but got string"},
+	}
+
+	pesticide.RunTestCases(t, validation.NewRegistry(), tc)
+}
+
+func TestOneOf_FromJSON(t *testing.T) {
+	handler := sprout.New(sprout.WithRegistries(validation.NewRegistry(), encoding.NewRegistry(), slices.NewRegistry()))
+
+	tmpl, err := template.New("test").Funcs(handler.Build()).Parse(`{{ (fromJSON .config).replicas | oneOf (list 1 3 5) }}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, map[string]any{"config": `{"replicas": 3}`}))
+	assert.Equal(t, "3", buf.String())
+
+	buf.Reset()
+	require.ErrorContains(t, tmpl.Execute(&buf, map[string]any{"config": `{"replicas": 2}`}), "value 2 is not one of [1 3 5]")
+}
+
+func TestValidationError(t *testing.T) {
+	_, err := pesticide.TestTemplate(t, validation.NewRegistry(), `{{ "qa" | oneOf (list "dev" "prod") }}`, nil)
+	require.Error(t, err)
+
+	var validationErr *validation.ValidationError
+	require.ErrorAs(t, err, &validationErr, "the template error should wrap the validation error")
+	assert.Equal(t, "value qa is not one of [dev prod]", validationErr.Message)
+	assert.Equal(t, "qa", validationErr.Value)
+
+	_, err = pesticide.TestTemplate(t, validation.NewRegistry(), `{{ "qa" | mustMatch "[" }}`, nil)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &validationErr), "function errors should not be validation errors")
+}
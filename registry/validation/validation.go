@@ -0,0 +1,65 @@
+// Package validation provides assertion functions to validate the data given
+// to a template. Every failed assertion returns a [*ValidationError], so
+// callers can distinguish template assertions from function errors with
+// [errors.As].
+package validation
+
+import (
+	"fmt"
+
+	"github.com/go-sprout/sprout"
+)
+
+// ValidationError is the error returned when an assertion of the validation
+// registry fails. It holds the message of the assertion and the offending
+// value.
+type ValidationError struct {
+	// Message is the message describing the failed assertion.
+	Message string
+	// Value is the value which failed the assertion, if any.
+	Value any
+}
+
+// Error returns the message of the failed assertion.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// newValidationError creates a new ValidationError with a formatted message.
+func newValidationError(value any, format string, args ...any) *ValidationError {
+	return &ValidationError{Message: fmt.Sprintf(format, args...), Value: value}
+}
+
+type ValidationRegistry struct {
+	handler sprout.Handler // Embedding Handler for shared functionality
+}
+
+// NewRegistry creates a new instance of validation registry.
+func NewRegistry() *ValidationRegistry {
+	return &ValidationRegistry{}
+}
+
+func init() {
+	sprout.RegisterRegistry(func() sprout.Registry { return NewRegistry() })
+}
+
+// UID returns the unique identifier of the registry.
+func (vr *ValidationRegistry) UID() string {
+	return "go-sprout/sprout.validation"
+}
+
+// LinkHandler links the handler to the registry at runtime.
+func (vr *ValidationRegistry) LinkHandler(fh sprout.Handler) error {
+	vr.handler = fh
+	return nil
+}
+
+// RegisterFunctions registers all functions of the registry.
+func (vr *ValidationRegistry) RegisterFunctions(funcsMap sprout.FunctionMap) error {
+	sprout.AddFunction(funcsMap, "required", vr.Required)
+	sprout.AddFunction(funcsMap, "assert", vr.Assert)
+	sprout.AddFunction(funcsMap, "fail", vr.Fail)
+	sprout.AddFunction(funcsMap, "mustMatch", vr.MustMatch)
+	sprout.AddFunction(funcsMap, "oneOf", vr.OneOf)
+	return nil
+}
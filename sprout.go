@@ -39,9 +39,11 @@ func New(opts ...HandlerOption[*DefaultHandler]) *DefaultHandler {
 		registries: make([]Registry, 0),
 		notices:    make([]FunctionNotice, 0),
 
-		wantSafeFuncs: false,
+		wantSafeFuncs:  false,
+		wantNamespaces: false,
 
 		cachedFuncsMap:   make(FunctionMap),
+		funcsOwners:      make(map[string]string),
 		cachedFuncsAlias: make(FunctionAliasMap),
 	}
 
@@ -0,0 +1,97 @@
+package sprout
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/go-sprout/sprout/internal/runtime"
+)
+
+// maxTemplateFunctionDepth is the maximum number of nested calls of template
+// functions, to stop snippets calling themselves endlessly.
+const maxTemplateFunctionDepth = 100
+
+// WithTemplateFunction returns a HandlerOption that adds a function defined by
+// a template snippet to the handler. The snippet can use every function of the
+// handler, including other template functions, and is executed with the
+// arguments of the function as dot:
+//
+//   - without argument, dot is nil;
+//   - with one argument, dot is this argument;
+//   - with more arguments, dot is the list of arguments.
+//
+// The function returns the rendered snippet as a string. The syntax of the
+// snippet is checked immediately, the snippet itself is parsed on its first
+// call, once all functions of the handler are known. If the function name
+// already exists, the function is not added.
+//
+// Template functions can call each other, and themselves, up to 100 nested
+// calls. Deeper calls return an error.
+//
+// Example:
+//
+//	handler := New(WithTemplateFunction("fullName", "{{ .first }} {{ .last }}"))
+//
+//	{{ fullName (dict "first" "Ada" "last" "Lovelace") }} // Output: Ada Lovelace
+func WithTemplateFunction(name, snippet string) HandlerOption[*DefaultHandler] {
+	return func(dh *DefaultHandler) error {
+		if err := validateFunctionName(name); err != nil {
+			return err
+		}
+
+		// Check the syntax only, functions of the handler may not be all registered yet
+		tree := parse.New(name)
+		tree.Mode = parse.SkipFuncCheck
+		if _, err := tree.Parse(snippet, "", "", make(map[string]*parse.Tree)); err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidFunction, name, err)
+		}
+
+		AddFunction(dh.cachedFuncsMap, name, templateFunction(dh, name, snippet))
+		return nil
+	}
+}
+
+// templateFunction creates the function rendering the given snippet with the
+// functions of the handler. The snippet is parsed once, on the first call.
+//
+// The nested calls are counted on the stack of the execution, every template
+// function sharing the code of the returned function.
+func templateFunction(h Handler, name, snippet string) func(args ...any) (string, error) {
+	var (
+		once    sync.Once
+		tmpl    *template.Template
+		errTmpl error
+	)
+
+	return func(args ...any) (string, error) {
+		if runtime.CallDepth() > maxTemplateFunctionDepth {
+			return "", fmt.Errorf("cannot execute template function %q: more than %d nested template function calls", name, maxTemplateFunctionDepth)
+		}
+
+		once.Do(func() {
+			tmpl, errTmpl = template.New(name).Funcs(h.Build()).Parse(snippet)
+		})
+		if errTmpl != nil {
+			return "", fmt.Errorf("cannot parse template function %q: %w", name, errTmpl)
+		}
+
+		var data any
+		switch len(args) {
+		case 0:
+			data = nil
+		case 1:
+			data = args[0]
+		default:
+			data = args
+		}
+
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("cannot execute template function %q: %w", name, err)
+		}
+		return buf.String(), nil
+	}
+}
@@ -0,0 +1,119 @@
+package sprout
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTemplateFunction(t *testing.T) {
+	upper := &funcsRegistry{uid: "test/template.upper", funcs: FunctionMap{
+		"upper": func(s string) string { return string(bytes.ToUpper([]byte(s))) },
+	}}
+
+	handler := New(
+		WithTemplateFunction("greet", `Hello {{ . }}`),
+		WithTemplateFunction("fullName", `{{ .first }} {{ .last | upper }}`),
+		WithTemplateFunction("join", `{{ range $i, $v := . }}{{ if $i }}-{{ end }}{{ $v }}{{ end }}`),
+		WithTemplateFunction("nothing", `{{ . }}`),
+		WithTemplateFunction("welcome", `{{ greet (fullName .) }}!`),
+		WithRegistries(upper),
+	)
+
+	tc := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "OneArgument", input: `{{ greet "Ada" }}`, expected: "Hello Ada"},
+		{name: "MapArgument", input: `{{ fullName .person }}`, expected: "Ada LOVELACE"},
+		{name: "MultipleArguments", input: `{{ join 1 2 3 }}`, expected: "1-2-3"},
+		{name: "NoArgument", input: `{{ nothing }}`, expected: "<no value>"},
+		{name: "NestedTemplateFunctions", input: `{{ welcome .person }}`, expected: "Hello Ada LOVELACE!"},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(handler.Build()).Parse(test.input)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.Execute(&buf, map[string]any{"person": map[string]any{"first": "Ada", "last": "Lovelace"}}))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestWithTemplateFunction_Errors(t *testing.T) {
+	handler := New()
+	err := WithTemplateFunction("", `{{ . }}`)(handler)
+	require.ErrorIs(t, err, ErrInvalidFunction)
+
+	err = WithTemplateFunction("my.fn", `{{ . }}`)(handler)
+	require.ErrorIs(t, err, ErrInvalidFunction)
+	require.ErrorContains(t, err, `invalid function "my.fn": function name is not a valid identifier`)
+	assert.NotContains(t, handler.RawFunctions(), "my.fn")
+
+	err = WithTemplateFunction("broken", `{{ .`)(handler)
+	require.ErrorIs(t, err, ErrInvalidFunction)
+	require.ErrorContains(t, err, `invalid function "broken"`)
+	assert.NotContains(t, handler.RawFunctions(), "broken")
+
+	require.NoError(t, WithTemplateFunction("unknown", `{{ unknownFunc }}`)(handler))
+	require.NoError(t, WithTemplateFunction("failing", `{{ .missing.field }}`)(handler))
+
+	fn, ok := handler.Build()["unknown"].(func(args ...any) (string, error))
+	require.True(t, ok)
+	_, err = fn()
+	require.ErrorContains(t, err, `cannot parse template function "unknown"`)
+
+	fn, ok = handler.Build()["failing"].(func(args ...any) (string, error))
+	require.True(t, ok)
+	_, err = fn(1)
+	require.ErrorContains(t, err, `cannot execute template function "failing"`)
+}
+
+func TestWithTemplateFunction_Recursion(t *testing.T) {
+	sub := &funcsRegistry{uid: "test/template.sub", funcs: FunctionMap{
+		"sub": func(a, b int) int { return a - b },
+	}}
+
+	handler := New(
+		WithTemplateFunction("loop", `{{ loop . }}`),
+		WithTemplateFunction("ping", `{{ pong . }}`),
+		WithTemplateFunction("pong", `{{ ping . }}`),
+		WithTemplateFunction("count", `{{ if gt . 0 }}{{ . }}{{ count (sub . 1) }}{{ end }}`),
+		WithRegistries(sub),
+	)
+
+	tc := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		{name: "InfiniteRecursion", input: `{{ loop 1 }}`, expectedErr: "more than 100 nested template function calls"},
+		{name: "MutualRecursion", input: `{{ ping 1 }}`, expectedErr: "more than 100 nested template function calls"},
+		{name: "BoundedRecursion", input: `{{ count 3 }}`, expected: "321"},
+		{name: "MaximumDepth", input: `{{ count 99 | len }}`, expected: "189"},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(handler.Build()).Parse(test.input)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			err = tmpl.Execute(&buf, nil)
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
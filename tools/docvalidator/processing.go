@@ -8,20 +8,50 @@ import (
 
 	"github.com/go-sprout/sprout"
 	"github.com/go-sprout/sprout/group/all"
+	"github.com/go-sprout/sprout/registry/decimal"
+	"github.com/go-sprout/sprout/registry/formatting"
+	"github.com/go-sprout/sprout/registry/jsonschema"
 	"github.com/go-sprout/sprout/registry/regex"
+	"github.com/go-sprout/sprout/registry/templating"
+	"github.com/go-sprout/sprout/registry/validation"
 )
 
 var sproutHandler = sprout.New(sprout.WithGroups(all.RegistryGroup()))
 
+// templatingRegistry is the registry of the `templating` documentation, it is
+// linked to each template of its examples so `include` can render them.
+var templatingRegistry = templating.NewRegistry()
+
 // dedicatedHandlers associates a documentation file with its own handler. It is
-// needed for registries sharing their function names with a registry of the
-// `all` group, like `regex` and `regexp`, which cannot be registered together.
-// The dedicated registry is registered first so its functions take precedence.
+// needed for registries outside of the `all` group, and for registries sharing
+// their function names with a registry of the `all` group, like `regex` and
+// `regexp`, which cannot be registered together. The dedicated registry is
+// registered first so its functions take precedence.
 var dedicatedHandlers = map[string]*sprout.DefaultHandler{
+	filepath.Join("docs", "registries", "decimal.md"): sprout.New(
+		sprout.WithRegistries(decimal.NewRegistry()),
+		sprout.WithGroups(all.RegistryGroup()),
+	),
+	filepath.Join("docs", "registries", "formatting.md"): sprout.New(
+		sprout.WithRegistries(formatting.NewRegistry(), decimal.NewRegistry()),
+		sprout.WithGroups(all.RegistryGroup()),
+	),
+	filepath.Join("docs", "registries", "jsonschema.md"): sprout.New(
+		sprout.WithRegistries(jsonschema.NewRegistry()),
+		sprout.WithGroups(all.RegistryGroup()),
+	),
 	filepath.Join("docs", "registries", "regex.md"): sprout.New(
 		sprout.WithRegistries(regex.NewRegistry()),
 		sprout.WithGroups(all.RegistryGroup()),
 	),
+	filepath.Join("docs", "registries", "templating.md"): sprout.New(
+		sprout.WithRegistries(templatingRegistry),
+		sprout.WithGroups(all.RegistryGroup()),
+	),
+	filepath.Join("docs", "registries", "validation.md"): sprout.New(
+		sprout.WithRegistries(validation.NewRegistry()),
+		sprout.WithGroups(all.RegistryGroup()),
+	),
 }
 
 // handlerFor returns the handler to use to validate the examples of the given
@@ -42,7 +72,10 @@ func processExample(example Example) error {
 	}
 
 	// Build the template with custom functions
-	tmpl, err := template.New("example").Funcs(handlerFor(example.File).Build()).Parse(example.Code)
+	tmpl := template.New("example").Funcs(handlerFor(example.File).Build())
+	templatingRegistry.LinkTemplate(tmpl)
+
+	tmpl, err := tmpl.Parse(example.Code)
 	if err != nil {
 		return fmt.Errorf("error parsing template: %w", err)
 	}